@@ -19,6 +19,8 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/nuts-foundation/nuts-consent-store/pkg"
@@ -57,23 +59,77 @@ func (cr ConsentRecord) ToConsentRecord() (pkg.ConsentRecord, error) {
 	if err != nil {
 		return pkg.ConsentRecord{}, err
 	}
-	var validTo time.Time
+	var validTo *time.Time
 	if cr.ValidTo != nil {
-		validTo, err = time.Parse(time.RFC3339, string(*cr.ValidTo))
+		t, err := time.Parse(time.RFC3339, string(*cr.ValidTo))
 		if err != nil {
 			return pkg.ConsentRecord{}, err
 		}
+		validTo = &t
+	}
+
+	var deletedAt *time.Time
+	if cr.DeletedAt != nil {
+		t, err := time.Parse(time.RFC3339, *cr.DeletedAt)
+		if err != nil {
+			return pkg.ConsentRecord{}, err
+		}
+		deletedAt = &t
+	}
+
+	var version uint
+	if cr.Version != nil {
+		version = uint(*cr.Version)
 	}
 
 	return pkg.ConsentRecord{
-		ValidFrom:    validFrom,
-		ValidTo:      &validTo,
-		Hash:         cr.RecordHash,
-		PreviousHash: cr.PreviousRecordHash,
-		DataClasses:  resources,
+		ValidFrom:     validFrom,
+		ValidTo:       validTo,
+		Hash:          cr.RecordHash,
+		PreviousHash:  cr.PreviousRecordHash,
+		Version:       version,
+		DataClasses:   resources,
+		DeletedAt:     deletedAt,
+		DeletedReason: cr.DeletedReason,
+		DeletedBy:     cr.DeletedBy,
 	}, nil
 }
 
+// validate checks if the consent holds everything needed to record it, the errors are used as message of the 400 response
+func (sc PatientConsent) validate() error {
+	if len(sc.Id) == 0 {
+		return errors.New("missing ID in createRequest")
+	}
+
+	if len(sc.Subject) == 0 {
+		return errors.New("missing subject in createRequest")
+	}
+
+	if len(sc.Custodian) == 0 {
+		return errors.New("missing custodian in createRequest")
+	}
+
+	if len(sc.Actor) == 0 {
+		return errors.New("missing actor in createRequest")
+	}
+
+	if len(sc.Records) == 0 {
+		return errors.New("missing records in createRequest")
+	}
+
+	for _, r := range sc.Records {
+		if len(r.DataClasses) == 0 {
+			return errors.New("missing resources in one or more records within createRequest")
+		}
+
+		if len(r.RecordHash) == 0 {
+			return errors.New("missing recordHash in one or more records within createRequest")
+		}
+	}
+
+	return nil
+}
+
 // FromPatientConsent converts a slice of pkg.PatientConsent to a slice of api.PatientConsent
 func FromPatientConsents(pc []pkg.PatientConsent) []PatientConsent {
 	var consents []PatientConsent
@@ -125,5 +181,66 @@ func FromConsentRecord(consentRecord pkg.ConsentRecord) ConsentRecord {
 		cr.ValidTo = &validTo
 	}
 
+	if consentRecord.DeletedAt != nil {
+		deletedAt := consentRecord.DeletedAt.Format(time.RFC3339)
+		cr.DeletedAt = &deletedAt
+		cr.DeletedReason = consentRecord.DeletedReason
+		cr.DeletedBy = consentRecord.DeletedBy
+	}
+
 	return cr
 }
+
+// FromErasureCertificate converts a pkg.ErasureCertificate to an ErasureCertificate
+func FromErasureCertificate(ec pkg.ErasureCertificate) ErasureCertificate {
+	return ErasureCertificate{
+		Pseudonym:         ec.Pseudonym,
+		ErasedAt:          ec.ErasedAt.Format(time.RFC3339),
+		ErasedBy:          ec.ErasedBy,
+		PatientConsentIds: ec.PatientConsentIDs,
+		RecordHashes:      ec.RecordHashes,
+	}
+}
+
+// ToErasureCertificate converts the API erasure certificate to a pkg.ErasureCertificate
+func (ec ErasureCertificate) ToErasureCertificate() (pkg.ErasureCertificate, error) {
+	erasedAt, err := time.Parse(time.RFC3339, ec.ErasedAt)
+	if err != nil {
+		return pkg.ErasureCertificate{}, err
+	}
+
+	return pkg.ErasureCertificate{
+		Pseudonym:         ec.Pseudonym,
+		ErasedAt:          erasedAt,
+		ErasedBy:          ec.ErasedBy,
+		PatientConsentIDs: ec.PatientConsentIds,
+		RecordHashes:      ec.RecordHashes,
+	}, nil
+}
+
+// FromWebhookDelivery converts a pkg.WebhookDelivery, including its outbox entry, to a WebhookDelivery
+func FromWebhookDelivery(d pkg.WebhookDelivery) (WebhookDelivery, error) {
+	wd := WebhookDelivery{
+		Id:            int(d.ID),
+		Url:           d.URL,
+		Status:        WebhookDeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastAttemptAt: d.LastAttemptAt,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+	}
+
+	err := json.Unmarshal([]byte(d.Outbox.Payload), &wd.Event)
+
+	return wd, err
+}
+
+// FromBackup converts a pkg.Backup to a Backup
+func FromBackup(b pkg.Backup) Backup {
+	return Backup{
+		File:      b.File,
+		Size:      b.Size,
+		CreatedAt: b.CreatedAt,
+	}
+}
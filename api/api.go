@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -36,62 +37,119 @@ type Wrapper struct {
 	Cs *pkg.ConsentStore
 }
 
+// the values of consentGiven in the ConsentCheckResponse
+const (
+	consentGivenYes = "yes"
+	consentGivenNo  = "no"
+)
+
 // CreateConsent creates or updates a PatientConsent in the consent store
 func (w *Wrapper) CreateConsent(ctx echo.Context) error {
+	if err := w.limit(ctx, pkg.OperationWrite); err != nil {
+		return err
+	}
+
 	buf, err := readBody(ctx)
 	if err != nil {
 		return err
 	}
 
+	ir, err := newIdempotentRequest(ctx, "createConsent", []byte(ctx.Request().Header.Get("If-Match")), buf)
+	if err != nil {
+		return err
+	}
+	if replayed, err := w.replay(ctx, ir); replayed || err != nil {
+		return err
+	}
+
 	var createRequest = &PatientConsent{}
 	err = json.Unmarshal(buf, createRequest)
 
-	if len(createRequest.Id) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing ID in createRequest")
+	if err := createRequest.validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	if len(createRequest.Subject) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing subject in createRequest")
+	c, err := createRequest.ToPatientConsent()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	if len(createRequest.Custodian) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing custodian in createRequest")
+	// the If-Match header holds the ETag of the head the new record is appended to
+	if ifMatch := ctx.Request().Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" {
+		if len(c.Records) != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "If-Match requires a single record in createRequest")
+		}
+		head := parseETag(ifMatch)
+		if c.Records[0].PreviousHash == nil {
+			c.Records[0].PreviousHash = &head
+		} else if *c.Records[0].PreviousHash != head {
+			return echo.NewHTTPError(http.StatusBadRequest, "If-Match doesn't match previousRecordHash in createRequest")
+		}
 	}
 
-	if len(createRequest.Actor) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing actor in createRequest")
-	}
+	err = w.Cs.RecordConsent(ir.context(ctx, http.StatusCreated), []pkg.PatientConsent{c})
 
-	if len(createRequest.Records) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing records in createRequest")
+	if err != nil {
+		return err
 	}
 
-	for _, r := range createRequest.Records {
-		if len(r.DataClasses) == 0 {
-			return echo.NewHTTPError(http.StatusBadRequest, "missing resources in one or more records within createRequest")
-		}
+	return ctx.NoContent(http.StatusCreated)
+}
 
-		if len(r.RecordHash) == 0 {
-			return echo.NewHTTPError(http.StatusBadRequest, "missing recordHash in one or more records within createRequest")
-		}
+// CreateConsentBatch records the consent of multiple C-S-A combinations in a single transaction, none is recorded when one of them fails
+func (w *Wrapper) CreateConsentBatch(ctx echo.Context) error {
+	if err := w.limit(ctx, pkg.OperationWrite); err != nil {
+		return err
 	}
 
-	c, err := createRequest.ToPatientConsent()
+	buf, err := readBody(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	err = w.Cs.RecordConsent(ctx.Request().Context(), []pkg.PatientConsent{c})
-
+	ir, err := newIdempotentRequest(ctx, "createConsentBatch", buf)
 	if err != nil {
 		return err
 	}
+	if replayed, err := w.replay(ctx, ir); replayed || err != nil {
+		return err
+	}
+
+	var createRequest []PatientConsent
+	if err := json.Unmarshal(buf, &createRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid createRequest: %v", err))
+	}
+
+	if len(createRequest) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing consent in createRequest")
+	}
+
+	var consent []pkg.PatientConsent
+	for i, pc := range createRequest {
+		if err := pc.validate(); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("consent %d: %v", i+1, err))
+		}
+
+		c, err := pc.ToPatientConsent()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("consent %d: %v", i+1, err))
+		}
+		consent = append(consent, c)
+	}
 
-	return ctx.NoContent(201)
+	if err := w.Cs.RecordConsent(ir.context(ctx, http.StatusCreated), consent); err != nil {
+		return err
+	}
+
+	return ctx.NoContent(http.StatusCreated)
 }
 
 // CheckConsent checks if a given resource is allowed for a given actor, subject, custodian triple
 func (w *Wrapper) CheckConsent(ctx echo.Context) error {
+	if err := w.limit(ctx, pkg.OperationCheck); err != nil {
+		return err
+	}
+
 	buf, err := readBody(ctx)
 	if err != nil {
 		return err
@@ -137,9 +195,27 @@ func (w *Wrapper) CheckConsent(ctx echo.Context) error {
 		return err
 	}
 
-	authValue := "no"
+	// clients may cache the outcome until a record starts or ends, or revalidate it with the ETag
+	hint, err := w.Cs.ConsentAuthCacheHint(ctx.Request().Context(), string(checkRequest.Custodian), string(checkRequest.Subject), string(checkRequest.Actor), checkRequest.DataClass, checkpoint, auth, time.Now())
+	if err != nil {
+		return err
+	}
+
+	header := ctx.Response().Header()
+	header.Set("ETag", hint.ETag)
+	if hint.MaxAge > 0 {
+		header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(hint.MaxAge.Seconds())))
+	} else {
+		header.Set("Cache-Control", "no-cache")
+	}
+
+	if ctx.Request().Header.Get("If-None-Match") == hint.ETag {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	authValue := consentGivenNo
 	if auth {
-		authValue = "yes"
+		authValue = consentGivenYes
 	}
 
 	checkResponse := ConsentCheckResponse{
@@ -152,26 +228,54 @@ func (w *Wrapper) CheckConsent(ctx echo.Context) error {
 // ErrorMissingHash is returned when the consentRecordHash parameter is missing
 var ErrorMissingHash = errors.New("missing consentRecordHash")
 
-// DeleteConsent deletes the consentRecord for a given consentRecordHash
-func (w *Wrapper) DeleteConsent(ctx echo.Context, consentRecordHash string) error {
+// DeleteConsent replaces the consentRecord for a given consentRecordHash with a tombstone.
+// When deletedBy is not given, the authenticated caller is recorded.
+func (w *Wrapper) DeleteConsent(ctx echo.Context, consentRecordHash string, params DeleteConsentParams) error {
+	if err := w.limit(ctx, pkg.OperationWrite); err != nil {
+		return err
+	}
+
 	if len(consentRecordHash) == 0 {
 		return echo.NewHTTPError(http.StatusBadRequest, ErrorMissingHash)
 	}
 
+	var reason, deletedBy string
+	if params.Reason != nil {
+		reason = *params.Reason
+	}
+	if params.DeletedBy != nil {
+		deletedBy = *params.DeletedBy
+	} else {
+		deletedBy = authenticatedCaller(ctx)
+	}
+
+	ir, err := newIdempotentRequest(ctx, "deleteConsent", []byte(consentRecordHash), []byte(reason), []byte(deletedBy))
+	if err != nil {
+		return err
+	}
+	if replayed, err := w.replay(ctx, ir); replayed || err != nil {
+		return err
+	}
+
 	// delete record, if it doesn't exist an error is returned
-	if f, err := w.Cs.DeleteConsentRecordByHash(ctx.Request().Context(), consentRecordHash); err != nil || !f {
-		if !f {
+	if _, err := w.Cs.DeleteConsentRecordByHash(ir.context(ctx, http.StatusAccepted), consentRecordHash, reason, deletedBy); err != nil {
+		if errors.Is(err, pkg.ErrorNotFound) {
 			return echo.NewHTTPError(http.StatusNotFound, err)
 		}
 
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		// a 500 unless it's an error of the idempotency key
+		return err
 	}
 
-	return ctx.NoContent(202)
+	return ctx.NoContent(http.StatusAccepted)
 }
 
 // FindConsentRecord returns a ConsentRecord based on a hash. A latest flag can be added to indicate a record may only be returned if it's the latest in the chain.
 func (w *Wrapper) FindConsentRecord(ctx echo.Context, consentRecordHash string, params FindConsentRecordParams) error {
+	if err := w.limit(ctx, pkg.OperationQuery); err != nil {
+		return err
+	}
+
 	if len(consentRecordHash) == 0 {
 		return echo.NewHTTPError(http.StatusBadRequest, ErrorMissingHash)
 	}
@@ -194,11 +298,27 @@ func (w *Wrapper) FindConsentRecord(ctx echo.Context, consentRecordHash string,
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
+	ctx.Response().Header().Set("ETag", recordETag(record.Hash))
 	return ctx.JSON(200, FromConsentRecord(record))
 }
 
+// recordETag returns the ETag identifying a consent record
+func recordETag(hash string) string {
+	return fmt.Sprintf("\"%s\"", hash)
+}
+
+// parseETag returns the value of an entity tag, a weak tag is treated as a strong one
+func parseETag(tag string) string {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	return strings.Trim(tag, "\"")
+}
+
 // QueryConsent finds given consent for a combination of actor, subject and/or custodian
 func (w *Wrapper) QueryConsent(ctx echo.Context) error {
+	if err := w.limit(ctx, pkg.OperationQuery); err != nil {
+		return err
+	}
+
 	buf, err := readBody(ctx)
 	if err != nil {
 		return err
@@ -239,7 +359,12 @@ func (w *Wrapper) QueryConsent(ctx echo.Context) error {
 		}
 	}
 
-	rules, err = w.Cs.QueryConsent(ctx.Request().Context(), actor, custodian, subject, &va)
+	var includeDeleted bool
+	if checkRequest.IncludeDeleted != nil {
+		includeDeleted = *checkRequest.IncludeDeleted
+	}
+
+	rules, err = w.Cs.QueryConsent(ctx.Request().Context(), actor, custodian, subject, &va, includeDeleted)
 
 	if err != nil {
 		return err
@@ -35,6 +35,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
 	"github.com/nuts-foundation/nuts-consent-store/pkg"
 	"github.com/nuts-foundation/nuts-go-core/mock"
 )
@@ -58,6 +59,7 @@ func TestDefaultConsentStore_CheckConsent(t *testing.T) {
 
 		authValue := "no"
 		echo.EXPECT().Request().Return(request).AnyTimes()
+		echo.EXPECT().Response().Return(testResponse()).AnyTimes()
 		echo.EXPECT().JSON(200, ConsentCheckResponse{
 			ConsentGiven: &authValue,
 		})
@@ -81,6 +83,7 @@ func TestDefaultConsentStore_CheckConsent(t *testing.T) {
 
 		authValue := "yes"
 		echo.EXPECT().Request().Return(request).AnyTimes()
+		echo.EXPECT().Response().Return(testResponse()).AnyTimes()
 		echo.EXPECT().JSON(200, ConsentCheckResponse{
 			ConsentGiven: &authValue,
 		})
@@ -107,6 +110,7 @@ func TestDefaultConsentStore_CheckConsent(t *testing.T) {
 
 		authValue := "yes"
 		echo.EXPECT().Request().Return(request).AnyTimes()
+		echo.EXPECT().Response().Return(testResponse()).AnyTimes()
 		echo.EXPECT().JSON(200, ConsentCheckResponse{
 			ConsentGiven: &authValue,
 		})
@@ -347,6 +351,31 @@ func TestDefaultConsentStore_CreateConsent(t *testing.T) {
 		}
 	})
 
+	t.Run("unknown previousRecordHash gives 422", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		consent := testConsent()
+		consent.Subject = "unknown-previous"
+		unknown := "unknown"
+		consent.Records[0].PreviousRecordHash = &unknown
+		json, _ := json.Marshal(consent)
+		request := &http.Request{
+			Body: ioutil.NopCloser(bytes.NewReader(json)),
+		}
+
+		echo.EXPECT().Request().Return(request).AnyTimes()
+
+		err := client.CreateConsent(echo)
+
+		if assert.True(t, errors.Is(err, pkg.ErrorUnknownPreviousHash), "expected ErrorUnknownPreviousHash, got %v", err) {
+			p := NewProblem(err)
+			assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+			assert.Equal(t, ProblemCodeUnknownPreviousHash, p.Code)
+		}
+	})
+
 	t.Run("Missing body gives 400", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -802,7 +831,7 @@ func TestDefaultConsentStore_DeleteConsent(t *testing.T) {
 
 		echo.EXPECT().Request().Return(request).AnyTimes()
 
-		err := client.DeleteConsent(echo, "")
+		err := client.DeleteConsent(echo, "", DeleteConsentParams{})
 
 		if assert.Error(t, err) {
 			expected := "code=400, message=missing consentRecordHash"
@@ -819,13 +848,37 @@ func TestDefaultConsentStore_DeleteConsent(t *testing.T) {
 
 		echo.EXPECT().Request().Return(request).AnyTimes()
 
-		err := client.DeleteConsent(echo, "a")
+		err := client.DeleteConsent(echo, "a", DeleteConsentParams{})
 
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), pkg.ErrorNotFound.Error())
 		}
 	})
 
+	t.Run("store error returns 500", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		crq := consentRuleForQuery()
+		client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+		client.Cs.Db.Callback().Update().Before("gorm:update").Register("test:fail", func(scope *gorm.Scope) {
+			scope.Err(errors.New("b00m!"))
+		})
+
+		echo.EXPECT().Request().Return(&http.Request{}).AnyTimes()
+
+		err := client.DeleteConsent(echo, crq.Records[0].Hash, DeleteConsentParams{})
+
+		if assert.Error(t, err) {
+			p := NewProblem(err)
+			assert.Equal(t, http.StatusInternalServerError, p.Status)
+			assert.Equal(t, ProblemCodeInternal, p.Code)
+		}
+	})
+
 	t.Run("Correct delete", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -836,11 +889,20 @@ func TestDefaultConsentStore_DeleteConsent(t *testing.T) {
 		echo.EXPECT().Request().Return(request).AnyTimes()
 		echo.EXPECT().NoContent(202)
 
-		err := client.DeleteConsent(echo, crq.Records[0].Hash)
+		reason := "revoked"
+		err := client.DeleteConsent(echo, crq.Records[0].Hash, DeleteConsentParams{Reason: &reason})
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%v]", err)
 		}
+
+		actor := string(crq.Actor)
+		pcs, _ := client.Cs.QueryConsent(context.Background(), &actor, nil, nil, nil, true)
+		if assert.Len(t, pcs, 1) {
+			record := pcs[0].Records[0]
+			assert.Equal(t, reason, *record.DeletedReason)
+			assert.Equal(t, "", *record.DeletedBy)
+		}
 	})
 }
 
@@ -890,13 +952,16 @@ func TestDefaultConsentStore_FindConsentRecord(t *testing.T) {
 
 		request := &http.Request{}
 
+		response := testResponse()
 		echo.EXPECT().Request().Return(request).AnyTimes()
+		echo.EXPECT().Response().Return(response).AnyTimes()
 		echo.EXPECT().JSON(200, gomock.Any())
 
 		tt := true
 		err := client.FindConsentRecord(echo, crq.Records[0].Hash, FindConsentRecordParams{Latest: &tt})
 
 		assert.NoError(t, err)
+		assert.Equal(t, "\""+crq.Records[0].Hash+"\"", response.Header().Get("ETag"))
 	})
 
 	t.Run("find previous with latest flag", func(t *testing.T) {
@@ -924,6 +989,176 @@ func TestDefaultConsentStore_FindConsentRecord(t *testing.T) {
 	})
 }
 
+func TestDefaultConsentStore_CreateConsent_IfMatch(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+
+	v1 := testConsent()
+	c, _ := v1.ToPatientConsent()
+	if err := client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{c}); err != nil {
+		t.Fatal(err)
+	}
+	head := v1.Records[0].RecordHash
+
+	// request returns a request appending a new record to the chain of v1
+	request := func(ifMatch string, previous *string) (*http.Request, string) {
+		next := v1
+		next.Records = []ConsentRecord{v1.Records[0]}
+		next.Records[0].RecordHash = random.String(8)
+		next.Records[0].PreviousRecordHash = previous
+		b, _ := json.Marshal(next)
+		return &http.Request{Body: ioutil.NopCloser(bytes.NewReader(b)), Header: http.Header{"If-Match": []string{ifMatch}}}, next.Records[0].RecordHash
+	}
+
+	t.Run("appends to the record in If-Match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		req, hash := request(recordETag(head), nil)
+		echo.EXPECT().Request().Return(req).AnyTimes()
+		echo.EXPECT().NoContent(http.StatusCreated)
+
+		if !assert.NoError(t, client.CreateConsent(echo)) {
+			return
+		}
+
+		record, err := client.Cs.FindConsentRecordByHash(context.Background(), hash, true)
+		if assert.NoError(t, err) {
+			assert.Equal(t, head, *record.PreviousHash)
+			assert.Equal(t, uint(2), record.Version)
+		}
+	})
+
+	t.Run("400 when If-Match doesn't match previousRecordHash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		other := "other"
+		req, _ := request(recordETag(head), &other)
+		echo.EXPECT().Request().Return(req).AnyTimes()
+
+		err := client.CreateConsent(echo)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "code=400, message=If-Match doesn't match previousRecordHash in createRequest", err.Error())
+		}
+	})
+
+	t.Run("conflict when If-Match isn't the head", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		// the first subtest appended version 2
+		req, _ := request("W/"+recordETag(head), nil)
+		echo.EXPECT().Request().Return(req).AnyTimes()
+
+		err := client.CreateConsent(echo)
+
+		var conflict *pkg.ConflictError
+		if assert.True(t, errors.As(err, &conflict)) {
+			assert.Equal(t, uint(2), conflict.Head.Version)
+		}
+	})
+}
+
+func TestDefaultConsentStore_CreateConsentBatch(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+
+	request := func(body interface{}) *http.Request {
+		b, _ := json.Marshal(body)
+		return &http.Request{Body: ioutil.NopCloser(bytes.NewReader(b))}
+	}
+
+	t.Run("API call returns 201 Created and records all consent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		consent := []PatientConsent{testConsent(), testConsent()}
+		consent[1].Actor = "other"
+
+		echo.EXPECT().Request().Return(request(consent)).AnyTimes()
+		echo.EXPECT().NoContent(http.StatusCreated)
+
+		if !assert.NoError(t, client.CreateConsentBatch(echo)) {
+			return
+		}
+		for _, c := range consent {
+			_, err := client.Cs.FindConsentRecordByHash(context.Background(), c.Records[0].RecordHash, false)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("invalid consent gives 400 with its position", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		consent := []PatientConsent{testConsent(), testConsent()}
+		consent[1].Actor = ""
+
+		echo.EXPECT().Request().Return(request(consent)).AnyTimes()
+
+		err := client.CreateConsentBatch(echo)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "code=400, message=consent 2: missing actor in createRequest")
+		}
+		_, err = client.Cs.FindConsentRecordByHash(context.Background(), consent[0].Records[0].RecordHash, false)
+		assert.Equal(t, pkg.ErrorNotFound, err)
+	})
+
+	t.Run("nothing is recorded when one of the consents fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		consent := []PatientConsent{testConsent(), testConsent()}
+		unknown := "unknown"
+		consent[1].Actor = "other"
+		consent[1].Records[0].PreviousRecordHash = &unknown
+
+		echo.EXPECT().Request().Return(request(consent)).AnyTimes()
+
+		assert.Error(t, client.CreateConsentBatch(echo))
+
+		_, err := client.Cs.FindConsentRecordByHash(context.Background(), consent[0].Records[0].RecordHash, false)
+		assert.Equal(t, pkg.ErrorNotFound, err)
+	})
+
+	t.Run("empty list gives 400", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		echo.EXPECT().Request().Return(request([]PatientConsent{})).AnyTimes()
+
+		err := client.CreateConsentBatch(echo)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "code=400, message=missing consent in createRequest")
+		}
+	})
+
+	t.Run("missing body gives 400", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		echo := mock.NewMockContext(ctrl)
+
+		echo.EXPECT().Request().Return(&http.Request{}).AnyTimes()
+
+		err := client.CreateConsentBatch(echo)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "code=400, message=missing body in request")
+		}
+	})
+}
+
 func testConsent() PatientConsent {
 	validTo := ValidTo("2030-01-01T12:00:00+01:00")
 	return PatientConsent{
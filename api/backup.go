@@ -0,0 +1,47 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// CreateBackup writes an online backup of the database to the configured backup directory.
+// It's only available for callers authenticated with a client certificate.
+func (w *Wrapper) CreateBackup(ctx echo.Context) error {
+	caller := authenticatedCaller(ctx)
+	if caller == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "client certificate required")
+	}
+
+	backup, err := w.Cs.Backup(ctx.Request().Context())
+	if err != nil {
+		if errors.Is(err, pkg.ErrorMissingBackupDir) {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	pkg.Logger().Infof("backup %s created by %s", backup.File, caller)
+
+	return ctx.JSON(http.StatusCreated, FromBackup(backup))
+}
@@ -0,0 +1,89 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_CreateBackup(t *testing.T) {
+	newContext := func(caller string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if caller != "" {
+			req.TLS = &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: caller}}}},
+			}
+		}
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("201", func(t *testing.T) {
+		dir, _ := ioutil.TempDir("", "consent-store")
+		defer os.RemoveAll(dir)
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		client.Cs.Config.BackupDir = dir
+		ctx, rec := newContext("admin")
+
+		err := client.CreateBackup(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			var backup Backup
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &backup))
+			assert.FileExists(t, backup.File)
+		}
+	})
+
+	t.Run("401 without client certificate", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, _ := newContext("")
+
+		err := client.CreateBackup(ctx)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("503 without backup dir", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, _ := newContext("admin")
+
+		err := client.CreateBackup(ctx)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.(*echo.HTTPError).Code)
+		}
+	})
+}
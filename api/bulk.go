@@ -0,0 +1,272 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+const (
+	// ImportFormatNDJSON is the import format with a PatientConsent as JSON on every line
+	ImportFormatNDJSON = "ndjson"
+	// ImportFormatCSV is the import format with a consent record on every row, the columns are mapped to fields by a CSVMapping
+	ImportFormatCSV = "csv"
+)
+
+// maxImportLineSize is the maximum size of a single NDJSON line
+const maxImportLineSize = 1024 * 1024
+
+// csvFields are the fields that can be mapped to a CSV column, dataClasses are separated by a |
+var csvFields = []string{"id", "subject", "custodian", "actor", "recordHash", "previousRecordHash", "validFrom", "validTo", "dataClasses"}
+
+// csvOptionalFields are the fields that don't need a column
+var csvOptionalFields = map[string]bool{"previousRecordHash": true, "validTo": true}
+
+// CSVMapping maps the import fields to the names of the CSV columns holding them
+type CSVMapping map[string]string
+
+// ParseCSVMapping parses a mapping in the field=column;field=column form. Fields that aren't mapped are read from a column with the field name.
+func ParseCSVMapping(value string) (CSVMapping, error) {
+	mapping := CSVMapping{}
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid mapping [%s], expected field=column pairs", pair)
+		}
+
+		known := false
+		for _, f := range csvFields {
+			known = known || f == kv[0]
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown field [%s] in mapping, expected one of %s", kv[0], strings.Join(csvFields, ", "))
+		}
+		mapping[kv[0]] = kv[1]
+	}
+
+	return mapping, nil
+}
+
+// ReadNDJSON reads a PatientConsent, in the format of the REST api, from every non empty line
+func ReadNDJSON(r io.Reader) ([]pkg.ImportLine, error) {
+	var lines []pkg.ImportLine
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLineSize)
+
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		line := pkg.ImportLine{Line: n}
+		var pc PatientConsent
+		if err := json.Unmarshal([]byte(text), &pc); err != nil {
+			line.Err = err
+		} else {
+			line.Consent, line.Err = pc.ToPatientConsent()
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// ReadCSV reads a consent with a single record from every row after the header row. Line is the number of the row, the header being 1.
+func ReadCSV(r io.Reader, mapping CSVMapping) ([]pkg.ImportLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for _, f := range csvFields {
+		name := f
+		if mapped, ok := mapping[f]; ok {
+			name = mapped
+		}
+
+		columns[f] = -1
+		for i, h := range header {
+			if strings.TrimSpace(h) == name {
+				columns[f] = i
+			}
+		}
+		if columns[f] == -1 && !csvOptionalFields[f] {
+			return nil, fmt.Errorf("missing column [%s] for field %s", name, f)
+		}
+	}
+
+	var lines []pkg.ImportLine
+	for n := 2; ; n++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line := pkg.ImportLine{Line: n}
+		if err != nil {
+			line.Err = err
+		} else {
+			line.Consent, line.Err = csvConsent(row, columns)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// csvConsent converts a CSV row to a consent with a single record
+func csvConsent(row []string, columns map[string]int) (pkg.PatientConsent, error) {
+	value := func(field string) string {
+		i := columns[field]
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	record := pkg.ConsentRecord{Hash: value("recordHash")}
+
+	if v := value("previousRecordHash"); v != "" {
+		record.PreviousHash = &v
+	}
+
+	var err error
+	if v := value("validFrom"); v != "" {
+		if record.ValidFrom, err = time.Parse(time.RFC3339, v); err != nil {
+			return pkg.PatientConsent{}, err
+		}
+	}
+	if v := value("validTo"); v != "" {
+		validTo, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return pkg.PatientConsent{}, err
+		}
+		record.ValidTo = &validTo
+	}
+
+	for _, dc := range strings.Split(value("dataClasses"), "|") {
+		if dc = strings.TrimSpace(dc); dc != "" {
+			record.DataClasses = append(record.DataClasses, pkg.DataClass{Code: dc})
+		}
+	}
+
+	return pkg.PatientConsent{
+		ID:        value("id"),
+		Subject:   value("subject"),
+		Custodian: value("custodian"),
+		Actor:     value("actor"),
+		Records:   []pkg.ConsentRecord{record},
+	}, nil
+}
+
+// BulkImportConsent records the consent in the NDJSON or CSV body, depending on the content type.
+// The response holds a report with the errors per line, also when some lines failed.
+func (w *Wrapper) BulkImportConsent(ctx echo.Context, params BulkImportConsentParams) error {
+	if err := w.limit(ctx, pkg.OperationWrite); err != nil {
+		return err
+	}
+
+	req := ctx.Request()
+	if req.Body == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing body in request")
+	}
+
+	var (
+		lines []pkg.ImportLine
+		err   error
+	)
+	// the digest of the body is part of the fingerprint of a request with an Idempotency-Key
+	digest := sha256.New()
+	body := io.TeeReader(req.Body, digest)
+	contentType := strings.TrimSpace(strings.Split(req.Header.Get(echo.HeaderContentType), ";")[0])
+	switch contentType {
+	case "application/x-ndjson":
+		lines, err = ReadNDJSON(body)
+	case "text/csv":
+		var mapping CSVMapping
+		if params.Mapping != nil {
+			if mapping, err = ParseCSVMapping(*params.Mapping); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+		}
+		lines, err = ReadCSV(body, mapping)
+	default:
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, fmt.Sprintf("unsupported content type [%s], expected application/x-ndjson or text/csv", contentType))
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	batchSize := w.Cs.Config.ImportBatchSize
+	if params.BatchSize != nil {
+		batchSize = *params.BatchSize
+	}
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	// a CSV body is read up to the last row, the remainder is read for the digest
+	if _, err := io.Copy(ioutil.Discard, body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	var mapping string
+	if params.Mapping != nil {
+		mapping = *params.Mapping
+	}
+	ir, err := newIdempotentRequest(ctx, "bulkImportConsent", []byte(contentType), []byte(mapping),
+		[]byte(strconv.Itoa(batchSize)), []byte(strconv.FormatBool(dryRun)), digest.Sum(nil))
+	if err != nil {
+		return err
+	}
+	replayed, err := w.replayWith(ctx, ir, func(response pkg.IdempotentResponse) error {
+		return ctx.JSONBlob(response.Status, []byte(response.Body))
+	})
+	if replayed || err != nil {
+		return err
+	}
+
+	report, err := w.Cs.ImportConsent(ir.context(ctx, http.StatusOK), lines, batchSize, dryRun)
+	if err != nil {
+		// a 500 unless it's an error of the idempotency key
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, report)
+}
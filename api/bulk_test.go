@@ -0,0 +1,197 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+const csvImport = `identifier,bsn,custodian,actor,recordHash,validFrom,validTo,dataClasses
+1,subject,custodian,actor,hash1,2020-01-01T12:00:00Z,,a|b
+2,subject,custodian,actor,hash2,yesterday,,a
+`
+
+func ndjsonImport(t *testing.T, consent ...pkg.PatientConsent) string {
+	var lines []string
+	for _, pc := range consent {
+		bytes, err := json.Marshal(FromPatientConsent(pc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(bytes))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestParseCSVMapping(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		m, err := ParseCSVMapping("subject=bsn; id=identifier;")
+
+		assert.NoError(t, err)
+		assert.Equal(t, CSVMapping{"subject": "bsn", "id": "identifier"}, m)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParseCSVMapping("patient=bsn")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := ParseCSVMapping("subject=")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReadNDJSON(t *testing.T) {
+	body := ndjsonImport(t, consentRuleForQuery()) + "\n\n{invalid\n"
+
+	lines, err := ReadNDJSON(strings.NewReader(body))
+
+	if assert.NoError(t, err) && assert.Len(t, lines, 2) {
+		assert.Equal(t, 1, lines[0].Line)
+		assert.NoError(t, lines[0].Err)
+		assert.Equal(t, "subject", lines[0].Consent.Subject)
+		assert.Equal(t, 3, lines[1].Line)
+		assert.Error(t, lines[1].Err)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Run("maps columns to fields", func(t *testing.T) {
+		lines, err := ReadCSV(strings.NewReader(csvImport), CSVMapping{"subject": "bsn", "id": "identifier"})
+
+		if assert.NoError(t, err) && assert.Len(t, lines, 2) {
+			assert.Equal(t, 2, lines[0].Line)
+			if assert.NoError(t, lines[0].Err) {
+				pc := lines[0].Consent
+				assert.Equal(t, "1", pc.ID)
+				assert.Equal(t, "subject", pc.Subject)
+				assert.Equal(t, "hash1", pc.Records[0].Hash)
+				assert.Nil(t, pc.Records[0].ValidTo)
+				assert.Len(t, pc.Records[0].DataClasses, 2)
+			}
+			assert.Equal(t, 3, lines[1].Line)
+			assert.Error(t, lines[1].Err)
+		}
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader(csvImport), CSVMapping{"id": "identifier"})
+
+		assert.EqualError(t, err, "missing column [subject] for field subject")
+	})
+}
+
+func TestWrapper_BulkImportConsent(t *testing.T) {
+	newContext := func(contentType string, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("imports NDJSON", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		other := consentRuleForQuery()
+		other.Subject = "other"
+		ctx, rec := newContext("application/x-ndjson", ndjsonImport(t, consentRuleForQuery(), other))
+
+		err := client.BulkImportConsent(ctx, BulkImportConsentParams{})
+
+		if assert.NoError(t, err) {
+			var report pkg.ImportReport
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, 2, report.Recorded)
+		}
+	})
+
+	t.Run("imports CSV with a mapping and reports failed lines", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, rec := newContext("text/csv; charset=utf-8", csvImport)
+		mapping := "subject=bsn;id=identifier"
+
+		err := client.BulkImportConsent(ctx, BulkImportConsentParams{Mapping: &mapping})
+
+		if assert.NoError(t, err) {
+			var report pkg.ImportReport
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, 1, report.Recorded)
+			if assert.Len(t, report.Errors, 1) {
+				assert.Equal(t, 3, report.Errors[0].Line)
+			}
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, rec := newContext("application/x-ndjson", ndjsonImport(t, consentRuleForQuery()))
+		dryRun := true
+
+		err := client.BulkImportConsent(ctx, BulkImportConsentParams{DryRun: &dryRun})
+
+		if assert.NoError(t, err) {
+			var report pkg.ImportReport
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.True(t, report.DryRun)
+			assert.Equal(t, 1, report.Recorded)
+		}
+		actor := "actor"
+		consent, _ := client.Cs.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		assert.Empty(t, consent)
+	})
+
+	t.Run("400 on invalid mapping", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, _ := newContext("text/csv", csvImport)
+		mapping := "patient=bsn"
+
+		err := client.BulkImportConsent(ctx, BulkImportConsentParams{Mapping: &mapping})
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("415 on unsupported content type", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, _ := newContext("application/json", "[]")
+
+		err := client.BulkImportConsent(ctx, BulkImportConsentParams{})
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusUnsupportedMediaType, err.(*echo.HTTPError).Code)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// maxWatchAttempt caps the backoff between reconnects of the event stream
+const maxWatchAttempt = 10
+
+// AuthCache caches the outcome of consent checks of the HttpClient for the max-age given by the server. Expired outcomes
+// are revalidated with their ETag. When the HttpClient watches the event stream of the server, the outcomes of a consent are
+// removed as soon as it changes and the cache is only used while the stream is connected.
+type AuthCache struct {
+	size int
+	now  func() time.Time
+
+	mutex     sync.Mutex
+	entries   map[authKey]authEntry
+	watched   bool
+	connected bool
+}
+
+// authKey identifies a consent check, a check without checkpoint has an empty checkpoint
+type authKey struct {
+	custodian  string
+	subject    string
+	actor      string
+	dataClass  string
+	checkpoint string
+}
+
+type authEntry struct {
+	granted bool
+	etag    string
+	expires time.Time
+}
+
+// NewAuthCache creates a cache holding at most size outcomes, it returns nil when size is 0 or less
+func NewAuthCache(size int) *AuthCache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &AuthCache{
+		size:    size,
+		now:     time.Now,
+		entries: map[authKey]authEntry{},
+	}
+}
+
+// Len returns the number of cached outcomes
+func (c *AuthCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// Invalidate removes the outcomes of the consent of the given custodian, subject and actor. An empty subject removes the outcomes of all subjects.
+func (c *AuthCache) Invalidate(custodian string, subject string, actor string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for k := range c.entries {
+		if k.custodian == custodian && (subject == "" || k.subject == subject) && k.actor == actor {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// Clear removes all outcomes
+func (c *AuthCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = map[authKey]authEntry{}
+}
+
+// get returns the cached outcome, fresh is false when it has to be revalidated. Nothing is returned while the watched event stream is disconnected.
+func (c *AuthCache) get(key authKey) (entry authEntry, fresh bool, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.watched && !c.connected {
+		return authEntry{}, false, false
+	}
+
+	entry, ok = c.entries[key]
+	return entry, ok && c.now().Before(entry.expires), ok
+}
+
+// put caches the outcome for the max-age, outcomes without max-age are only cached when they can be revalidated with an ETag
+func (c *AuthCache) put(key authKey, granted bool, etag string, maxAge time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if maxAge <= 0 && etag == "" {
+		delete(c.entries, key)
+		return
+	}
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[key] = authEntry{granted: granted, etag: etag, expires: c.now().Add(maxAge)}
+}
+
+// evict removes the expired outcomes, or a single outcome when none has expired
+func (c *AuthCache) evict() {
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	for k := range c.entries {
+		if len(c.entries) < c.size {
+			return
+		}
+		delete(c.entries, k)
+	}
+}
+
+// setConnected marks the event stream as (dis)connected, the cache is cleared on connect since events may have been missed
+func (c *AuthCache) setConnected(connected bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.watched = true
+	c.connected = connected
+	if connected {
+		c.entries = map[authKey]authEntry{}
+	}
+}
+
+// cacheMaxAge returns the max-age of a Cache-Control header, 0 when it's missing or caching isn't allowed
+func cacheMaxAge(header http.Header) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "no-store" {
+			return 0
+		}
+		if strings.HasPrefix(directive, "max-age=") {
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err != nil || seconds < 0 {
+				return 0
+			}
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
+
+// WatchEvents keeps the AuthCache up to date with the event stream of the server until the context is done.
+// When the stream disconnects it reconnects with the backoff of the retry policy. It returns immediately when the client has no cache.
+func (hb HttpClient) WatchEvents(ctx context.Context) {
+	if hb.Cache == nil {
+		return
+	}
+
+	// the stream stays open, so it must not be cut off by the timeout of a single call
+	hb.Timeout = 0
+
+	for attempt := 1; ctx.Err() == nil; {
+		err := hb.watchEvents(ctx)
+		hb.Cache.setConnected(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		wait := hb.Retry.Wait(attempt)
+		if wait == 0 {
+			wait = time.Second
+		}
+		hb.Logger.Warnf("consent event stream disconnected, reconnecting in %s: %v", wait, err)
+		if sleep(ctx, wait) != nil {
+			return
+		}
+		if attempt < maxWatchAttempt {
+			attempt++
+		}
+	}
+}
+
+// StartWatchingEvents runs WatchEvents in the background until the client is closed
+func (hb *HttpClient) StartWatchingEvents() {
+	if hb.Cache == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hb.stopWatching = cancel
+	go hb.WatchEvents(ctx)
+}
+
+// Close stops watching the event stream, which closes the connection to the server
+func (hb HttpClient) Close() error {
+	if hb.stopWatching != nil {
+		hb.stopWatching()
+	}
+	return nil
+}
+
+// watchEvents reads the event stream until it ends and removes the outcomes of every changed consent from the cache
+func (hb HttpClient) watchEvents(ctx context.Context) error {
+	result, err := hb.client().ConsentEvents(ctx, &ConsentEventsParams{})
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK {
+		return fmt.Errorf("consent store returned %d", result.StatusCode)
+	}
+	hb.Cache.setConnected(true)
+
+	var eventType string
+	scanner := bufio.NewScanner(result.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:") && eventType == EventResetType:
+			hb.Cache.Clear()
+		case strings.HasPrefix(line, "data:"):
+			var e pkg.Event
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &e); err != nil {
+				// an event that can't be read might have changed anything
+				hb.Cache.Clear()
+				continue
+			}
+			subject := e.Subject
+			if strings.HasPrefix(subject, pkg.PseudonymPrefix) {
+				// the erased subject is unknown, drop the outcomes of every subject of the custodian and actor
+				subject = ""
+			}
+			hb.Cache.Invalidate(e.Custodian, subject, e.Actor)
+		case line == "":
+			eventType = ""
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("event stream ended")
+}
@@ -0,0 +1,288 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// testResponse returns a response for a mocked echo context
+func testResponse() *echo.Response {
+	return echo.NewResponse(httptest.NewRecorder(), echo.New())
+}
+
+func TestWrapper_CheckConsent_CacheHeaders(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{consentRuleForQuery()})
+
+	check := func(etag string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(consentCheckRequest())
+		req := httptest.NewRequest(http.MethodPost, "/consent/check", bytes.NewReader(body))
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		if err := client.CheckConsent(echo.New().NewContext(req, rec)); err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+
+	t.Run("max-age ends at the configured maximum and ETag is set", func(t *testing.T) {
+		rec := check("")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "private, max-age=300", rec.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+
+	t.Run("matching ETag returns 304", func(t *testing.T) {
+		rec := check(check("").Header().Get("ETag"))
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, "private, max-age=300", rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("other ETag returns outcome", func(t *testing.T) {
+		rec := check("\"other\"")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestAuthCache(t *testing.T) {
+	now := time.Now()
+	key := authKey{custodian: "custodian", subject: "subject", actor: "actor", dataClass: "resource"}
+	other := authKey{custodian: "custodian", subject: "other", actor: "actor", dataClass: "resource"}
+
+	newCache := func(size int) *AuthCache {
+		c := NewAuthCache(size)
+		c.now = func() time.Time { return now }
+		return c
+	}
+
+	t.Run("fresh until max-age", func(t *testing.T) {
+		c := newCache(10)
+		c.put(key, true, "\"tag\"", time.Minute)
+
+		e, fresh, ok := c.get(key)
+		assert.True(t, ok && fresh && e.granted)
+
+		c.now = func() time.Time { return now.Add(time.Minute) }
+		e, fresh, ok = c.get(key)
+		assert.True(t, ok)
+		assert.False(t, fresh)
+		assert.Equal(t, "\"tag\"", e.etag)
+	})
+
+	t.Run("outcome without max-age and ETag isn't cached", func(t *testing.T) {
+		c := newCache(10)
+		c.put(key, true, "", 0)
+
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("full cache evicts", func(t *testing.T) {
+		c := newCache(1)
+		c.put(key, true, "", time.Minute)
+		c.put(other, true, "", time.Minute)
+
+		assert.Equal(t, 1, c.Len())
+		_, _, ok := c.get(other)
+		assert.True(t, ok)
+	})
+
+	t.Run("invalidate removes outcomes of the consent", func(t *testing.T) {
+		c := newCache(10)
+		c.put(key, true, "", time.Minute)
+		c.put(authKey{custodian: "custodian", subject: "subject", actor: "actor", dataClass: "other"}, true, "", time.Minute)
+		c.put(other, true, "", time.Minute)
+
+		c.Invalidate("custodian", "subject", "actor")
+
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("invalidate without subject removes outcomes of all subjects", func(t *testing.T) {
+		c := newCache(10)
+		c.put(key, true, "", time.Minute)
+		c.put(other, true, "", time.Minute)
+		c.put(authKey{custodian: "custodian", subject: "subject", actor: "other", dataClass: "resource"}, true, "", time.Minute)
+
+		c.Invalidate("custodian", "", "actor")
+
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("not used while the watched stream is disconnected", func(t *testing.T) {
+		c := newCache(10)
+		c.setConnected(true)
+		c.put(key, true, "", time.Minute)
+		c.setConnected(false)
+
+		_, _, ok := c.get(key)
+		assert.False(t, ok)
+	})
+
+	t.Run("size 0 disables the cache", func(t *testing.T) {
+		assert.Nil(t, NewAuthCache(0))
+	})
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"private, max-age=60": time.Minute,
+		"max-age=0":           0,
+		"no-store, max-age=5": 0,
+		"no-cache":            0,
+		"max-age=soon":        0,
+		"":                    0,
+	} {
+		assert.Equal(t, expected, cacheMaxAge(http.Header{"Cache-Control": []string{value}}), value)
+	}
+}
+
+func TestHttpClient_ConsentAuth_Cache(t *testing.T) {
+	given := "yes"
+	body, _ := json.Marshal(ConsentCheckResponse{ConsentGiven: &given})
+
+	// cachingClient returns a client with a cache, responding with the given Cache-Control header and counting the calls
+	cachingClient := func(calls *int, cacheControl string) HttpClient {
+		client := newTestClient(func(req *http.Request) *http.Response {
+			*calls++
+			header := http.Header{"Cache-Control": []string{cacheControl}, "Etag": []string{"\"tag\""}}
+			if req.Header.Get("If-None-Match") == "\"tag\"" {
+				return &http.Response{StatusCode: http.StatusNotModified, Body: ioutil.NopCloser(bytes.NewReader(nil)), Header: header}
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(body)), Header: header}
+		})
+		client.Cache = NewAuthCache(10)
+		return client
+	}
+
+	t.Run("outcome is cached for the max-age", func(t *testing.T) {
+		calls := 0
+		client := cachingClient(&calls, "max-age=60")
+
+		for i := 0; i < 3; i++ {
+			granted, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+			assert.NoError(t, err)
+			assert.True(t, granted)
+		}
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("checkpoint is part of the key", func(t *testing.T) {
+		calls := 0
+		client := cachingClient(&calls, "max-age=60")
+		checkpoint := time.Now().Add(-time.Hour)
+
+		client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+		client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", &checkpoint)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("expired outcome is revalidated with the ETag", func(t *testing.T) {
+		calls := 0
+		client := cachingClient(&calls, "no-cache")
+
+		granted, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+		assert.NoError(t, err)
+		assert.True(t, granted)
+
+		granted, err = client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+		assert.NoError(t, err)
+		assert.True(t, granted)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestHttpClient_WatchEvents(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+
+	e := echo.New()
+	RegisterHandlers(e, &client)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	hc := HttpClient{
+		ServerAddress: strings.TrimPrefix(server.URL, "http://"),
+		Timeout:       time.Second,
+		Cache:         NewAuthCache(10),
+		Logger:        logrus.StandardLogger().WithField("component", "API-client"),
+	}
+
+	hc.StartWatchingEvents()
+	defer hc.Close()
+
+	// eventually polls the condition until it's true or a second has passed
+	eventually := func(condition func() bool) bool {
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if condition() {
+				return true
+			}
+		}
+		return false
+	}
+
+	connected := eventually(func() bool {
+		hc.Cache.mutex.Lock()
+		defer hc.Cache.mutex.Unlock()
+		return hc.Cache.connected
+	})
+	if !assert.True(t, connected, "expected event stream to connect") {
+		return
+	}
+
+	hc.Cache.put(authKey{custodian: "custodian", subject: "subject", actor: "actor", dataClass: "resource"}, false, "", time.Hour)
+	hc.Cache.put(authKey{custodian: "custodian", subject: "other", actor: "actor", dataClass: "resource"}, false, "", time.Hour)
+
+	t.Run("recorded consent invalidates its outcomes", func(t *testing.T) {
+		client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{consentRuleForQuery()})
+
+		assert.True(t, eventually(func() bool { return hc.Cache.Len() == 1 }))
+	})
+
+	t.Run("close disconnects and stops using the cache", func(t *testing.T) {
+		hc.Close()
+
+		assert.True(t, eventually(func() bool {
+			_, _, ok := hc.Cache.get(authKey{custodian: "custodian", subject: "other", actor: "actor", dataClass: "resource"})
+			return !ok
+		}))
+	})
+}
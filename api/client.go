@@ -20,6 +20,7 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,11 +37,26 @@ type HttpClient struct {
 	ServerAddress string
 	Timeout       time.Duration
 	Logger        *logrus.Entry
-	customClient  *http.Client
+	// TLSConfig is optional, when set the client connects over https using the given CA and client certificates
+	TLSConfig *tls.Config
+	// Transport is optional, it's shared by all calls so connections are reused. With a TLSConfig it should be created by NewTransport,
+	// otherwise every call opens a new connection. The default transport is used when both are nil.
+	Transport http.RoundTripper
+	// Retry is the retry policy of the idempotent calls, by default every call is attempted once
+	Retry pkg.RetryPolicy
+	// Breaker is optional, when set calls fail fast with pkg.ErrorCircuitOpen while the server is unavailable
+	Breaker *pkg.CircuitBreaker
+	// Cache is optional, when set the outcome of consent checks is cached as allowed by the server
+	Cache        *AuthCache
+	customClient *http.Client
+	// stopWatching stops the event stream started by StartWatchingEvents
+	stopWatching context.CancelFunc
 }
 
 // FindConsentRecordByHash returns a ConsentRecord based on a hash. A latest flag can be added to indicate a record may only be returned if it's the latest in the chain.
-func (hb HttpClient) FindConsentRecordByHash(context context.Context, consentRecordHash string, latest bool) (pkg.ConsentRecord, error) {
+func (hb HttpClient) FindConsentRecordByHash(ctx context.Context, consentRecordHash string, latest bool) (_ pkg.ConsentRecord, err error) {
+	ctx, done := startSpan(ctx, "FindConsentRecordByHash")
+	defer done(&err)
 
 	var consentRecord pkg.ConsentRecord
 
@@ -48,14 +64,9 @@ func (hb HttpClient) FindConsentRecordByHash(context context.Context, consentRec
 		return consentRecord, ErrorMissingHash
 	}
 
-	result, err := hb.client().FindConsentRecord(context, consentRecordHash, &FindConsentRecordParams{Latest: &latest})
-	if err != nil {
-		err = fmt.Errorf("error while finding consent record in consent-store: %w", err)
-		hb.Logger.Error(err)
-		return consentRecord, err
-	}
-
-	body, err := hb.checkResponse(result)
+	body, err := hb.call(ctx, true, "finding consent record", func() (*http.Response, error) {
+		return hb.client().FindConsentRecord(ctx, consentRecordHash, &FindConsentRecordParams{Latest: &latest})
+	})
 	if err != nil {
 		return consentRecord, err
 	}
@@ -70,8 +81,38 @@ func (hb HttpClient) FindConsentRecordByHash(context context.Context, consentRec
 	return cr.ToConsentRecord()
 }
 
+// EraseSubject removes all consent data of the subject. The server requires a client certificate (mTLS) for this operation.
+func (hb HttpClient) EraseSubject(ctx context.Context, subject string, erasedBy string) (_ pkg.ErasureCertificate, err error) {
+	ctx, done := startSpan(ctx, "EraseSubject")
+	defer done(&err)
+
+	params := &EraseSubjectParams{}
+	if erasedBy != "" {
+		params.ErasedBy = &erasedBy
+	}
+
+	body, err := hb.call(ctx, false, "erasing subject", func() (*http.Response, error) {
+		return hb.client().EraseSubject(ctx, Identifier(subject), params)
+	})
+	if err != nil {
+		return pkg.ErasureCertificate{}, err
+	}
+
+	var ec ErasureCertificate
+	if err := json.Unmarshal(body, &ec); err != nil {
+		err = fmt.Errorf("could not unmarshal response body, reason: %w", err)
+		hb.Logger.Error(err)
+		return pkg.ErasureCertificate{}, err
+	}
+
+	return ec.ToErasureCertificate()
+}
+
 // QueryConsent returns PatientConsent records based on a combination of actor, custodian and subject. The only constraint is that either actor or custodian must not be empty.
-func (hb HttpClient) QueryConsent(context context.Context, actor *string, custodian *string, subject *string, validAt *time.Time) ([]pkg.PatientConsent, error) {
+func (hb HttpClient) QueryConsent(ctx context.Context, actor *string, custodian *string, subject *string, validAt *time.Time, includeDeleted bool) (_ []pkg.PatientConsent, err error) {
+	ctx, done := startSpan(ctx, "QueryConsent")
+	defer done(&err)
+
 	var (
 		rules []pkg.PatientConsent
 		req   QueryConsentJSONRequestBody
@@ -97,14 +138,13 @@ func (hb HttpClient) QueryConsent(context context.Context, actor *string, custod
 		req.Subject = &s
 	}
 
-	result, err := hb.client().QueryConsent(context, req)
-	if err != nil {
-		err = fmt.Errorf("error while querying for consent in consent-store: %v", err)
-		hb.Logger.Error(err)
-		return rules, err
+	if includeDeleted {
+		req.IncludeDeleted = &includeDeleted
 	}
 
-	body, err := hb.checkResponse(result)
+	body, err := hb.call(ctx, true, "querying for consent", func() (*http.Response, error) {
+		return hb.client().QueryConsent(ctx, req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -127,16 +167,23 @@ func (hb HttpClient) QueryConsent(context context.Context, actor *string, custod
 	return rules, nil
 }
 
-func (hb HttpClient) DeleteConsentRecordByHash(context context.Context, consentRecordHash string) (bool, error) {
-	// delete record, if it doesn't exist an error is returned
-	result, err := hb.client().DeleteConsent(context, consentRecordHash)
-	if err != nil {
-		err := fmt.Errorf("error while deleting consent in consent-store: %v", err)
-		hb.Logger.Error(err)
-		return false, err
+func (hb HttpClient) DeleteConsentRecordByHash(ctx context.Context, consentRecordHash string, reason string, deletedBy string) (_ bool, err error) {
+	ctx, done := startSpan(ctx, "DeleteConsentRecordByHash")
+	defer done(&err)
+
+	params := &DeleteConsentParams{}
+	if reason != "" {
+		params.Reason = &reason
+	}
+	if deletedBy != "" {
+		params.DeletedBy = &deletedBy
 	}
 
-	_, err = hb.checkResponse(result)
+	// delete record, if it doesn't exist an error is returned
+	c := hb.idempotentClient(ctx)
+	_, err = hb.call(ctx, false, "deleting consent", func() (*http.Response, error) {
+		return c.DeleteConsent(ctx, consentRecordHash, params)
+	})
 	if err != nil {
 		return false, err
 	}
@@ -145,7 +192,10 @@ func (hb HttpClient) DeleteConsentRecordByHash(context context.Context, consentR
 }
 
 // ConsentAuth checks if there is an active consent for a given custodian, subject, actor, dataClass and an optional moment in time (checkpoint)
-func (hb HttpClient) ConsentAuth(ctx context.Context, custodian string, subject string, actor string, dataClass string, checkpoint *time.Time) (bool, error) {
+func (hb HttpClient) ConsentAuth(ctx context.Context, custodian string, subject string, actor string, dataClass string, checkpoint *time.Time) (_ bool, err error) {
+	ctx, done := startSpan(ctx, "ConsentAuth")
+	defer done(&err)
+
 	req := CheckConsentJSONRequestBody{
 		Actor:     Identifier(actor),
 		Custodian: Identifier(custodian),
@@ -153,82 +203,156 @@ func (hb HttpClient) ConsentAuth(ctx context.Context, custodian string, subject
 		DataClass: dataClass,
 	}
 
+	key := authKey{custodian: custodian, subject: subject, actor: actor, dataClass: dataClass}
 	if checkpoint != nil {
 		s := checkpoint.Format(time.RFC3339)
 		req.ValidAt = &s
+		key.checkpoint = s
 	}
 
-	result, err := hb.client().CheckConsent(ctx, req)
-	if err != nil {
-		err := fmt.Errorf("error while checking for consent in consent-store: %v", err)
-		hb.Logger.Error(err)
-		return false, err
+	var cached authEntry
+	if hb.Cache != nil {
+		entry, fresh, ok := hb.Cache.get(key)
+		if fresh {
+			return entry.granted, nil
+		}
+		if ok {
+			cached = entry
+		}
 	}
 
-	body, err := hb.checkResponse(result)
+	var response *http.Response
+	body, err := hb.call(ctx, true, "checking for consent", func() (*http.Response, error) {
+		c := hb.client()
+		if cached.etag != "" {
+			c.RequestEditor = func(ctx context.Context, req *http.Request) error {
+				req.Header.Set("If-None-Match", cached.etag)
+				return injectTraceContext(ctx, req)
+			}
+		}
+		var err error
+		response, err = c.CheckConsent(ctx, req)
+		return response, err
+	})
 	if err != nil {
 		return false, err
 	}
 
+	if response.StatusCode == http.StatusNotModified {
+		hb.Cache.put(key, cached.granted, cached.etag, cacheMaxAge(response.Header))
+		return cached.granted, nil
+	}
+
 	var ccr ConsentCheckResponse
 	if err := json.Unmarshal(body, &ccr); err != nil {
 		err := fmt.Errorf("could not unmarshal response body, reason: %v", err)
 		return false, err
 	}
 
-	return *ccr.ConsentGiven == "true", nil
+	granted := ccr.ConsentGiven != nil && *ccr.ConsentGiven == consentGivenYes
+	if hb.Cache != nil {
+		hb.Cache.put(key, granted, response.Header.Get("ETag"), cacheMaxAge(response.Header))
+	}
+
+	return granted, nil
 }
 
-// RecordConsent currently only supports the creation of a single record
-func (hb HttpClient) RecordConsent(ctx context.Context, consent []pkg.PatientConsent) error {
-	var req CreateConsentJSONRequestBody
+// RecordConsent records the consent in a single transaction, like the local ConsentStore does.
+// A single consent is sent to the create endpoint, multiple consents to the batch endpoint.
+// The idempotency key of the context is sent along, see WithIdempotencyKey.
+func (hb HttpClient) RecordConsent(ctx context.Context, consent []pkg.PatientConsent) (err error) {
+	ctx, done := startSpan(ctx, "RecordConsent")
+	defer done(&err)
 
-	if len(consent) != 1 {
-		var err error
-		if len(consent) > 1 {
-			err = errors.New("creating multiple consent records currently not supported")
-		}
-		if len(consent) == 0 {
-			err = errors.New("at least one consent record is needed")
-		}
+	if len(consent) == 0 {
+		err := errors.New("at least one consent record is needed")
 		hb.Logger.Error(err)
 		return err
 	}
 
-	req.Actor = Identifier(consent[0].Actor)
-	req.Custodian = Identifier(consent[0].Custodian)
-	req.Subject = Identifier(consent[0].Subject)
+	c := hb.idempotentClient(ctx)
+	if len(consent) == 1 {
+		_, err = hb.call(ctx, false, "storing consent", func() (*http.Response, error) {
+			return c.CreateConsent(ctx, CreateConsentJSONRequestBody(FromPatientConsent(consent[0])))
+		})
+	} else {
+		_, err = hb.call(ctx, false, "storing consent", func() (*http.Response, error) {
+			return c.CreateConsentBatch(ctx, FromPatientConsents(consent))
+		})
+	}
+
+	return err
+}
 
-	for _, r := range consent[0].Records {
-		version := int(r.Version)
+// call performs the request through the circuit breaker and returns the body of the response. Idempotent requests are retried
+// according to the retry policy when the server couldn't be reached or is unavailable (502, 503 or 504), with a jittered backoff in between.
+func (hb HttpClient) call(ctx context.Context, idempotent bool, action string, request func() (*http.Response, error)) ([]byte, error) {
+	attempts := 1
+	if idempotent && hb.Retry.MaxAttempts > 1 {
+		attempts = hb.Retry.MaxAttempts
+	}
 
-		cr := ConsentRecord{
-			RecordHash:         r.Hash,
-			PreviousRecordHash: r.PreviousHash,
-			ValidFrom:          ValidFrom(r.ValidFrom.Format(time.RFC3339)),
-			Version:            &version,
+	for attempt := 1; ; attempt++ {
+		if err := hb.Breaker.Allow(); err != nil {
+			err = fmt.Errorf("error while %s in consent-store: %w", action, err)
+			hb.Logger.Error(err)
+			return nil, err
 		}
-		if r.ValidTo != nil {
-			validTo := ValidTo(r.ValidTo.Format(time.RFC3339))
-			cr.ValidTo = &validTo
 
+		result, err := request()
+		if err != nil || result.StatusCode >= http.StatusInternalServerError {
+			hb.Breaker.Failure()
+		} else {
+			hb.Breaker.Success()
 		}
-		for _, sr := range r.DataClasses {
-			cr.DataClasses = append(cr.DataClasses, sr.Code)
+
+		retry := err != nil || retryableStatus(result.StatusCode)
+		if retry && attempt < attempts {
+			if result != nil {
+				ioutil.ReadAll(result.Body)
+				result.Body.Close()
+			}
+			if err == nil {
+				err = fmt.Errorf("consent store returned %d", result.StatusCode)
+			}
+
+			wait := hb.Retry.Wait(attempt)
+			hb.Logger.Warnf("attempt %d of %d failed while %s, retrying in %s: %v", attempt, attempts, action, wait, err)
+			if err := sleep(ctx, wait); err != nil {
+				err = fmt.Errorf("error while %s in consent-store: %w", action, err)
+				hb.Logger.Error(err)
+				return nil, err
+			}
+			continue
 		}
-		req.Records = append(req.Records, cr)
-	}
 
-	result, err := hb.client().CreateConsent(ctx, req)
+		if err != nil {
+			err = fmt.Errorf("error while %s in consent-store: %w", action, err)
+			hb.Logger.Error(err)
+			return nil, err
+		}
 
-	if err != nil {
-		hb.Logger.Error("error while storing consent in consent-store", err)
-		return err
+		defer result.Body.Close()
+		return hb.checkResponse(result)
 	}
+}
 
-	_, err = hb.checkResponse(result)
+// retryableStatus returns true for the status codes that indicate the server is temporarily unavailable
+func retryableStatus(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
 
-	return err
+// sleep waits for the given duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // checkResponse analyzes response code and body. It returns the body.
@@ -242,6 +366,12 @@ func (hb *HttpClient) checkResponse(result *http.Response) ([]byte, error) {
 	}
 
 	if result.StatusCode >= http.StatusBadRequest {
+		if problem, ok := readProblem(result.Header, body); ok {
+			err = &ProblemError{Problem: problem}
+			hb.Logger.Error(err.Error())
+			return nil, err
+		}
+
 		err = fmt.Errorf("consent store returned %d, reason: %s", result.StatusCode, body)
 		hb.Logger.Error(err.Error())
 		return nil, err
@@ -250,15 +380,54 @@ func (hb *HttpClient) checkResponse(result *http.Response) ([]byte, error) {
 	return body, nil
 }
 
+// idempotentClient returns a client that sends the idempotency key of the context with every request
+func (hb HttpClient) idempotentClient(ctx context.Context) *Client {
+	c := hb.client()
+	if key := IdempotencyKey(ctx); key != "" {
+		c.RequestEditor = func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(IdempotencyKeyHeader, key)
+			return injectTraceContext(ctx, req)
+		}
+	}
+	return c
+}
+
+// NewTransport returns a transport that connects with the given TLS config, it's meant to be shared by all calls of an HttpClient
+func NewTransport(tlsConfig *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
+
 func (hb HttpClient) client() *Client {
+	scheme := "http"
+	if hb.TLSConfig != nil {
+		scheme = "https"
+	}
+	server := fmt.Sprintf("%s://%v", scheme, hb.ServerAddress)
+
 	if hb.customClient != nil {
 		return &Client{
-			Server: fmt.Sprintf("http://%v", hb.ServerAddress),
-			Client: hb.customClient,
+			Server:        server,
+			Client:        hb.customClient,
+			RequestEditor: injectTraceContext,
 		}
 	}
 
+	transport := hb.Transport
+	if transport == nil && hb.TLSConfig != nil {
+		// without a shared transport the connection can't be reused, so it isn't kept open either
+		t := NewTransport(hb.TLSConfig)
+		t.DisableKeepAlives = true
+		transport = t
+	}
+
 	return &Client{
-		Server: fmt.Sprintf("http://%v", hb.ServerAddress),
+		Server: server,
+		Client: &http.Client{
+			Timeout:   hb.Timeout,
+			Transport: transport,
+		},
+		RequestEditor: injectTraceContext,
 	}
 }
@@ -21,9 +21,12 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -92,23 +95,46 @@ func TestHttpClient_RecordConsent(t *testing.T) {
 		}
 	})
 
-	t.Run("too many rules returns error", func(t *testing.T) {
-		client := testClient(201, []byte{})
+	t.Run("single consent is sent with its ID", func(t *testing.T) {
+		var sent PatientConsent
+		client := newTestClient(func(req *http.Request) *http.Response {
+			assert.True(t, strings.HasSuffix(req.URL.Path, "/consent"))
+			json.NewDecoder(req.Body).Decode(&sent)
+			return &http.Response{StatusCode: 201, Body: ioutil.NopCloser(bytes.NewReader([]byte{}))}
+		})
 
-		c := []pkg.PatientConsent{{}, {}}
-		err := client.RecordConsent(context.TODO(), c)
+		err := client.RecordConsent(context.TODO(), []pkg.PatientConsent{patientConsent()})
 
-		if err == nil {
-			t.Error("Expected error, got nothing")
-			return
-		}
+		assert.NoError(t, err)
+		assert.Equal(t, "patientConsentId", sent.Id)
+	})
 
-		expected := "creating multiple consent records currently not supported"
-		if expected != err.Error() {
-			t.Errorf("Expected error [%s], got [%v]", expected, err)
+	t.Run("multiple consents are sent as batch", func(t *testing.T) {
+		var sent []PatientConsent
+		client := newTestClient(func(req *http.Request) *http.Response {
+			assert.True(t, strings.HasSuffix(req.URL.Path, "/consent/batch"))
+			json.NewDecoder(req.Body).Decode(&sent)
+			return &http.Response{StatusCode: 201, Body: ioutil.NopCloser(bytes.NewReader([]byte{}))}
+		})
+
+		other := patientConsent()
+		other.ID = "otherId"
+		err := client.RecordConsent(context.TODO(), []pkg.PatientConsent{patientConsent(), other})
+
+		if assert.NoError(t, err) && assert.Len(t, sent, 2) {
+			assert.Equal(t, "patientConsentId", sent[0].Id)
+			assert.Equal(t, "otherId", sent[1].Id)
 		}
 	})
 
+	t.Run("rejected batch returns error", func(t *testing.T) {
+		client := testClient(400, []byte("consent 2: missing actor in createRequest"))
+
+		err := client.RecordConsent(context.TODO(), []pkg.PatientConsent{{}, {}})
+
+		assert.Error(t, err)
+	})
+
 	t.Run("body read error returns error", func(t *testing.T) {
 		client := newTestClient(func(req *http.Request) *http.Response {
 			// Test request parameters
@@ -139,7 +165,7 @@ func TestHttpClient_DeleteConsentRecordByHash(t *testing.T) {
 	t.Run("202", func(t *testing.T) {
 		client := testClient(202, []byte{})
 
-		res, err := client.DeleteConsentRecordByHash(context.TODO(), "hash")
+		res, err := client.DeleteConsentRecordByHash(context.TODO(), "hash", "", "")
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%s]", err.Error())
@@ -154,7 +180,7 @@ func TestHttpClient_DeleteConsentRecordByHash(t *testing.T) {
 	t.Run("500", func(t *testing.T) {
 		client := testClient(500, []byte("some error"))
 
-		res, err := client.DeleteConsentRecordByHash(context.TODO(), "hash")
+		res, err := client.DeleteConsentRecordByHash(context.TODO(), "hash", "", "")
 
 		if err == nil {
 			t.Errorf("Expected error, got nothing")
@@ -172,9 +198,36 @@ func TestHttpClient_DeleteConsentRecordByHash(t *testing.T) {
 	})
 }
 
+func TestHttpClient_EraseSubject(t *testing.T) {
+	t.Run("200", func(t *testing.T) {
+		body, _ := json.Marshal(ErasureCertificate{
+			Pseudonym:    "pseudonym:1",
+			ErasedAt:     "2020-01-01T12:00:00+01:00",
+			ErasedBy:     "dpo",
+			RecordHashes: []string{"hash"},
+		})
+		client := testClient(200, body)
+
+		res, err := client.EraseSubject(context.TODO(), "subject", "dpo")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"hash"}, res.RecordHashes)
+			assert.Equal(t, "pseudonym:1", res.Pseudonym)
+		}
+	})
+
+	t.Run("401", func(t *testing.T) {
+		client := testClient(401, []byte("client certificate required"))
+
+		_, err := client.EraseSubject(context.TODO(), "subject", "dpo")
+
+		assert.Error(t, err)
+	})
+}
+
 func TestHttpClient_ConsentAuth(t *testing.T) {
 	t.Run("200", func(t *testing.T) {
-		tr := "true"
+		tr := "yes"
 		resp, _ := json.Marshal(ConsentCheckResponse{ConsentGiven: &tr})
 		client := testClient(200, resp)
 
@@ -189,8 +242,19 @@ func TestHttpClient_ConsentAuth(t *testing.T) {
 		}
 	})
 
+	t.Run("200 without consent", func(t *testing.T) {
+		no := "no"
+		resp, _ := json.Marshal(ConsentCheckResponse{ConsentGiven: &no})
+		client := testClient(200, resp)
+
+		res, err := client.ConsentAuth(context.TODO(), "", "", "", "test", nil)
+
+		assert.NoError(t, err)
+		assert.False(t, res)
+	})
+
 	t.Run("200 with checkpoint", func(t *testing.T) {
-		tr := "true"
+		tr := "yes"
 		resp, _ := json.Marshal(ConsentCheckResponse{ConsentGiven: &tr})
 		client := testClient(200, resp)
 
@@ -269,7 +333,7 @@ func TestHttpClient_QueryConsentForActor(t *testing.T) {
 		})
 		client := testClient(200, resp)
 		a := "actor"
-		res, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil)
+		res, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil, false)
 
 		if assert.NoError(t, err) {
 			assert.Len(t, res, 1)
@@ -285,8 +349,26 @@ func TestHttpClient_FindConsentRecordByHash(t *testing.T) {
 
 		if assert.NoError(t, err) {
 			assert.Equal(t, "Hash", res.Hash)
+			assert.Equal(t, uint(2), res.Version)
 		}
 	})
+
+	t.Run("404 returns ErrorNotFound", func(t *testing.T) {
+		client := problemClient(NewProblem(pkg.ErrorNotFound))
+
+		_, err := client.FindConsentRecordByHash(context.TODO(), "hash", false)
+
+		assert.True(t, errors.Is(err, pkg.ErrorNotFound))
+		assert.EqualError(t, err, "consent store returned 404, reason: record not found")
+	})
+
+	t.Run("404 for record that is not the latest returns ErrorConsentRecordNotLatest", func(t *testing.T) {
+		client := problemClient(NewProblem(pkg.ErrorConsentRecordNotLatest))
+
+		_, err := client.FindConsentRecordByHash(context.TODO(), "hash", true)
+
+		assert.True(t, errors.Is(err, pkg.ErrorConsentRecordNotLatest))
+	})
 }
 
 func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
@@ -312,7 +394,7 @@ func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
 			},
 		})
 		client := testClient(200, resp)
-		res, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+		res, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%s]", err.Error())
@@ -327,7 +409,7 @@ func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
 		resp, _ := json.Marshal(ConsentQueryResponse{Results: []PatientConsent{}})
 		client := testClient(200, resp)
 		tt := time.Now().Add(time.Hour)
-		res, err := client.QueryConsent(context.TODO(), &a, nil, &s, &tt)
+		res, err := client.QueryConsent(context.TODO(), &a, nil, &s, &tt, false)
 
 		if err != nil {
 			t.Fatalf("Expected no error, got [%s]", err.Error())
@@ -339,7 +421,7 @@ func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
 	t.Run("client returns error", func(t *testing.T) {
 		client := testClient(500, []byte("error"))
 
-		_, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+		_, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 		if err == nil {
 			t.Error("Expected error, got nothing")
@@ -355,7 +437,7 @@ func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
 	t.Run("client returns invalid json", func(t *testing.T) {
 		client := testClient(200, []byte("{"))
 
-		_, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+		_, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 		if err == nil {
 			t.Error("Expected error, got nothing")
@@ -380,7 +462,7 @@ func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
 			}
 		})
 
-		_, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+		_, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 		if err == nil {
 			t.Error("Expected error, got nothing")
@@ -394,6 +476,179 @@ func TestHttpClient_QueryConsentForActorAndSubject(t *testing.T) {
 	})
 }
 
+func TestHttpClient_client(t *testing.T) {
+	t.Run("uses http by default", func(t *testing.T) {
+		client := HttpClient{ServerAddress: "localhost:1323", Timeout: time.Second}
+
+		c := client.client()
+
+		assert.Equal(t, "http://localhost:1323", c.Server)
+		if hc, ok := c.Client.(*http.Client); assert.True(t, ok) {
+			assert.Equal(t, time.Second, hc.Timeout)
+		}
+	})
+
+	t.Run("uses https and the shared transport when TLS is configured", func(t *testing.T) {
+		tlsConfig := &tls.Config{}
+		client := HttpClient{ServerAddress: "localhost:1323", Timeout: time.Second, TLSConfig: tlsConfig, Transport: NewTransport(tlsConfig)}
+
+		c := client.client()
+
+		assert.Equal(t, "https://localhost:1323", c.Server)
+		if hc, ok := c.Client.(*http.Client); assert.True(t, ok) {
+			assert.Equal(t, time.Second, hc.Timeout)
+			assert.Same(t, tlsConfig, hc.Transport.(*http.Transport).TLSClientConfig)
+			assert.Same(t, hc.Transport, client.client().Client.(*http.Client).Transport)
+		}
+	})
+
+	t.Run("TLS without shared transport doesn't keep connections open", func(t *testing.T) {
+		tlsConfig := &tls.Config{}
+		client := HttpClient{ServerAddress: "localhost:1323", Timeout: time.Second, TLSConfig: tlsConfig}
+
+		c := client.client()
+
+		if hc, ok := c.Client.(*http.Client); assert.True(t, ok) {
+			transport := hc.Transport.(*http.Transport)
+			assert.Same(t, tlsConfig, transport.TLSClientConfig)
+			assert.True(t, transport.DisableKeepAlives)
+		}
+	})
+}
+
+func TestHttpClient_call(t *testing.T) {
+	given := "yes"
+	ok, _ := json.Marshal(ConsentCheckResponse{ConsentGiven: &given})
+	retry := pkg.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	// statusClient returns a client responding with the given status codes in order and counts the calls
+	statusClient := func(calls *int, statuses ...int) HttpClient {
+		client := newTestClient(func(req *http.Request) *http.Response {
+			status := statuses[*calls]
+			*calls++
+			return &http.Response{StatusCode: status, Body: ioutil.NopCloser(bytes.NewReader(ok))}
+		})
+		client.Retry = retry
+		return client
+	}
+
+	t.Run("idempotent call is retried when the server is unavailable", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 503, 502, 200)
+
+		_, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("idempotent call is retried on connection errors", func(t *testing.T) {
+		calls := 0
+		client := newTestClient(nil)
+		client.Retry = retry
+		client.customClient.Transport = roundTripErrFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewReader(ok))}, nil
+		})
+
+		_, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("gives up after the max attempts", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 503, 503, 503, 200)
+
+		_, err := client.QueryConsent(context.TODO(), nil, nil, nil, nil, false)
+
+		assert.EqualError(t, err, "consent store returned 503, reason: "+string(ok))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 500, 200)
+
+		_, err := client.FindConsentRecordByHash(context.TODO(), "hash", false)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("writes are not retried", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 503, 200)
+
+		_, err := client.DeleteConsentRecordByHash(context.TODO(), "hash", "", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops retrying when the context is done", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 503, 200)
+		client.Retry.Backoff = time.Minute
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.ConsentAuth(ctx, "custodian", "subject", "actor", "resource", nil)
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("open circuit breaker fails fast", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 503, 503, 503, 200)
+		client.Breaker = pkg.NewCircuitBreaker(2, time.Minute)
+
+		_, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+
+		assert.True(t, errors.Is(err, pkg.ErrorCircuitOpen))
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, pkg.BreakerOpen, client.Breaker.Status().State)
+	})
+
+	t.Run("client errors close the circuit breaker", func(t *testing.T) {
+		calls := 0
+		client := statusClient(&calls, 503, 404)
+		client.Breaker = pkg.NewCircuitBreaker(2, time.Minute)
+
+		_, err := client.FindConsentRecordByHash(context.TODO(), "hash", false)
+
+		assert.Error(t, err)
+		assert.Equal(t, pkg.BreakerStatus{State: pkg.BreakerClosed}, client.Breaker.Status())
+	})
+}
+
+// roundTripErrFunc is a RoundTripper that can return an error
+type roundTripErrFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip .
+func (f roundTripErrFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// problemClient returns a client for a server responding with the given problem details
+func problemClient(problem Problem) HttpClient {
+	body, _ := json.Marshal(problem)
+	return newTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: problem.Status,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+			Header: http.Header{
+				"Content-Type": []string{ProblemContentType},
+			},
+		}
+	})
+}
+
 func testClient(status int, body []byte) HttpClient {
 	return newTestClient(func(req *http.Request) *http.Response {
 		// Test request parameters
@@ -0,0 +1,50 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/conformance"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/sirupsen/logrus"
+)
+
+func TestHttpClient_Conformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) pkg.ConsentStoreClient {
+		w := defaultConsentStore()
+		e := echo.New()
+		RegisterHandlers(ProblemRouter(e), &w)
+		server := httptest.NewServer(e)
+		t.Cleanup(func() {
+			server.Close()
+			w.Cs.Shutdown()
+		})
+
+		return HttpClient{
+			ServerAddress: strings.TrimPrefix(server.URL, "http://"),
+			Timeout:       time.Second,
+			Logger:        logrus.StandardLogger().WithField("component", "API-client"),
+		}
+	})
+}
@@ -0,0 +1,112 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// EventResetType is the SSE event name that tells a resuming client that events have been missed
+const EventResetType = "reset"
+
+// eventKeepAliveInterval is the interval at which a comment is sent to keep idle connections open
+var eventKeepAliveInterval = 15 * time.Second
+
+// ConsentEvents streams consent mutations as Server-Sent Events until the client disconnects
+func (w *Wrapper) ConsentEvents(ctx echo.Context, params ConsentEventsParams) error {
+	if err := w.limit(ctx, pkg.OperationQuery); err != nil {
+		return err
+	}
+
+	if w.Cs.Events == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "event stream not available")
+	}
+
+	var lastEventID uint64
+	for _, p := range []*string{params.LastEventID, params.LastEventId} {
+		if p == nil || *p == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(*p, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid event ID: %s", *p))
+		}
+		lastEventID = id
+		break
+	}
+
+	sub, missed, complete := w.Cs.Events.Subscribe(lastEventID)
+	defer w.Cs.Events.Unsubscribe(sub)
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	if !complete {
+		fmt.Fprintf(res, "event: %s\ndata: {}\n\n", EventResetType)
+	}
+	for _, e := range missed {
+		if err := writeEvent(res, e); err != nil {
+			return err
+		}
+	}
+	res.Flush()
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-keepAlive.C:
+			fmt.Fprint(res, ": keep-alive\n\n")
+		case e, ok := <-sub.C:
+			if !ok {
+				// the subscriber couldn't keep up, the client will reconnect with the last event ID
+				return nil
+			}
+			if err := writeEvent(res, e); err != nil {
+				return err
+			}
+		}
+		res.Flush()
+	}
+}
+
+// writeEvent writes a single event in the Server-Sent Events format
+func writeEvent(w io.Writer, e pkg.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,117 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_ConsentEvents(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+
+	e := echo.New()
+	RegisterHandlers(e, &client)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	// readEvent reads a single event, skipping comments
+	readEvent := func(r *bufio.Reader) (string, string, string) {
+		var id, name, data string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "" && name != "":
+				return id, name, data
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	connect := func(lastEventID string) (*bufio.Reader, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/consent/events", nil)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+		return bufio.NewReader(res.Body), cancel
+	}
+
+	reader, cancel := connect("")
+	crq := consentRuleForQuery()
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+
+	var firstID string
+
+	t.Run("streams recorded consent", func(t *testing.T) {
+		id, name, data := readEvent(reader)
+		cancel()
+		firstID = id
+
+		assert.Equal(t, string(pkg.EventConsentRecorded), name)
+		var event pkg.Event
+		if assert.NoError(t, json.Unmarshal([]byte(data), &event)) {
+			assert.Equal(t, crq.Records[0].Hash, event.RecordHash)
+			assert.Equal(t, id, fmt.Sprintf("%d", event.ID))
+		}
+	})
+
+	t.Run("resumes after the last event ID", func(t *testing.T) {
+		client.Cs.DeleteConsentRecordByHash(context.Background(), crq.Records[0].Hash, "", "")
+
+		reader, cancel := connect(firstID)
+		defer cancel()
+
+		_, name, _ := readEvent(reader)
+		assert.Equal(t, string(pkg.EventConsentDeleted), name)
+	})
+
+	t.Run("invalid event ID returns 400", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/consent/events?lastEventId=abc")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		}
+	})
+}
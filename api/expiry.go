@@ -0,0 +1,70 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// ExpiringConsent returns the consent of the actor and/or custodian that ends within the requested period
+func (w *Wrapper) ExpiringConsent(ctx echo.Context, params ExpiringConsentParams) error {
+	if err := w.limit(ctx, pkg.OperationQuery); err != nil {
+		return err
+	}
+
+	var actor, custodian *string
+	if params.Actor != nil && len(*params.Actor) > 0 {
+		a := string(*params.Actor)
+		actor = &a
+	}
+	if params.Custodian != nil && len(*params.Custodian) > 0 {
+		c := string(*params.Custodian)
+		custodian = &c
+	}
+	if actor == nil && custodian == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing actor or custodian")
+	}
+
+	within, err := w.Cs.Config.ExpiryHorizonPeriod()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	if params.Within != nil && *params.Within != "" {
+		if within, err = pkg.ParseDuration(*params.Within); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid within: %v", err))
+		}
+	}
+
+	consent, err := w.Cs.ExpiringConsent(ctx.Request().Context(), actor, custodian, time.Now(), within)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	results := FromPatientConsents(consent)
+
+	return ctx.JSON(http.StatusOK, ConsentQueryResponse{
+		Results:      results,
+		TotalResults: len(results),
+	})
+}
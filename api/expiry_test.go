@@ -0,0 +1,107 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_ExpiringConsent(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+	// the record of consentRuleForQuery ends in 24 hours
+	crq := consentRuleForQuery()
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+	actor := Identifier(crq.Actor)
+
+	t.Run("returns consent ending within the period", func(t *testing.T) {
+		ctx, rec := newContext()
+		within := "2d"
+
+		err := client.ExpiringConsent(ctx, ExpiringConsentParams{Within: &within, Actor: &actor})
+
+		if assert.NoError(t, err) {
+			var response ConsentQueryResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			if assert.Equal(t, 1, response.TotalResults) {
+				assert.Equal(t, crq.Records[0].Hash, response.Results[0].Records[0].RecordHash)
+			}
+		}
+	})
+
+	t.Run("defaults to the configured horizon", func(t *testing.T) {
+		ctx, rec := newContext()
+
+		err := client.ExpiringConsent(ctx, ExpiringConsentParams{Actor: &actor})
+
+		if assert.NoError(t, err) {
+			var response ConsentQueryResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, 1, response.TotalResults)
+		}
+	})
+
+	t.Run("ignores consent ending after the period", func(t *testing.T) {
+		ctx, rec := newContext()
+		within := "12h"
+
+		err := client.ExpiringConsent(ctx, ExpiringConsentParams{Within: &within, Actor: &actor})
+
+		if assert.NoError(t, err) {
+			var response ConsentQueryResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, 0, response.TotalResults)
+		}
+	})
+
+	t.Run("invalid period returns 400", func(t *testing.T) {
+		ctx, _ := newContext()
+		within := "soon"
+
+		err := client.ExpiringConsent(ctx, ExpiringConsentParams{Within: &within, Actor: &actor})
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("missing actor and custodian returns 400", func(t *testing.T) {
+		ctx, _ := newContext()
+
+		err := client.ExpiringConsent(ctx, ExpiringConsentParams{})
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		}
+	})
+}
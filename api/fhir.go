@@ -0,0 +1,201 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/fhir"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// SearchFHIRConsent returns a searchset Bundle with a FHIR Consent resource for the latest record of every chain matching the search.
+// The search is translated to a pkg.ConsentSearch, so all filtering and paging takes place in the database.
+// Like a consent query, the search must be narrowed down to an actor or organization.
+func (w *Wrapper) SearchFHIRConsent(ctx echo.Context, params SearchFHIRConsentParams) error {
+	if err := w.limit(ctx, pkg.OperationQuery); err != nil {
+		return err
+	}
+
+	if params.Actor == nil && params.Organization == nil {
+		return fhirError(ctx, http.StatusBadRequest, "required", errors.New("missing actor or organization"))
+	}
+
+	search := pkg.ConsentSearch{Limit: fhir.DefaultCount}
+	if params.Patient != nil {
+		subject := fhir.TokenToURN(*params.Patient)
+		search.Subject = &subject
+	}
+	if params.Actor != nil {
+		actor := fhir.TokenToURN(*params.Actor)
+		search.Actor = &actor
+	}
+	if params.Organization != nil {
+		custodian := fhir.TokenToURN(*params.Organization)
+		search.Custodian = &custodian
+	}
+	if params.Category != nil {
+		search.DataClass = fhir.CategoryToDataClass(*params.Category)
+	}
+	if params.Period != nil {
+		for _, v := range *params.Period {
+			r, err := fhir.ParseDate(v)
+			if err != nil {
+				return fhirError(ctx, http.StatusBadRequest, "invalid", fmt.Errorf("invalid period: %w", err))
+			}
+			search.Period = append(search.Period, r)
+		}
+	}
+	if params.LastUpdated != nil {
+		for _, v := range *params.LastUpdated {
+			r, err := fhir.ParseDate(v)
+			if err != nil {
+				return fhirError(ctx, http.StatusBadRequest, "invalid", fmt.Errorf("invalid _lastUpdated: %w", err))
+			}
+			search.LastUpdated = append(search.LastUpdated, r)
+		}
+	}
+	if params.Count != nil {
+		if *params.Count < 0 {
+			return fhirError(ctx, http.StatusBadRequest, "invalid", errors.New("_count can't be negative"))
+		}
+		search.Limit = *params.Count
+		if search.Limit > fhir.MaxCount {
+			search.Limit = fhir.MaxCount
+		}
+	}
+	if params.Offset != nil && *params.Offset > 0 {
+		search.Offset = *params.Offset
+	}
+
+	consent, total, err := w.Cs.SearchConsent(ctx.Request().Context(), search)
+	if err != nil {
+		return fhirError(ctx, http.StatusInternalServerError, "exception", err)
+	}
+
+	now := time.Now()
+	var resources []fhir.Consent
+	for _, pc := range consent {
+		resources = append(resources, fhir.FromPatientConsent(pc, now)...)
+	}
+
+	bundle := fhir.NewSearchSet(resources, total)
+	self := *ctx.Request().URL
+	self.Scheme = ctx.Scheme()
+	self.Host = ctx.Request().Host
+	bundle.Link = fhir.PageLinks(self, search.Offset, search.Limit, total)
+
+	return fhirResponse(ctx, http.StatusOK, bundle)
+}
+
+// CreateFHIRConsent records a FHIR Consent resource and responds with the resource as stored
+func (w *Wrapper) CreateFHIRConsent(ctx echo.Context) error {
+	if err := w.limit(ctx, pkg.OperationWrite); err != nil {
+		return err
+	}
+
+	buf, err := readBody(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resource fhir.Consent
+	if err := json.Unmarshal(buf, &resource); err != nil {
+		return fhirError(ctx, http.StatusBadRequest, "structure", err)
+	}
+
+	pc, err := resource.ToPatientConsent()
+	if err != nil {
+		return fhirError(ctx, http.StatusBadRequest, "invalid", err)
+	}
+
+	ir, err := newIdempotentRequest(ctx, "createFHIRConsent", buf)
+	if err != nil {
+		return err
+	}
+	// a retry gets the resource as it's stored now
+	replayed, err := w.replayWith(ctx, ir, func(response pkg.IdempotentResponse) error {
+		return w.storedFHIRConsent(ctx, response.Status, pc)
+	})
+	if err != nil {
+		return fhirWriteError(ctx, err)
+	}
+	if replayed {
+		return nil
+	}
+
+	if err := w.Cs.RecordConsent(ir.context(ctx, http.StatusCreated), []pkg.PatientConsent{pc}); err != nil {
+		return fhirWriteError(ctx, err)
+	}
+
+	return w.storedFHIRConsent(ctx, http.StatusCreated, pc)
+}
+
+// storedFHIRConsent responds with the Consent resource of the recorded consent
+func (w *Wrapper) storedFHIRConsent(ctx echo.Context, status int, pc pkg.PatientConsent) error {
+	record, err := w.Cs.FindConsentRecordByHash(ctx.Request().Context(), pc.Records[0].Hash, false)
+	if err != nil {
+		return fhirError(ctx, http.StatusInternalServerError, "exception", err)
+	}
+
+	stored := fhir.FromConsentRecord(pc, record, true, time.Now())
+	ctx.Response().Header().Set(echo.HeaderLocation, fhir.ResourceTypeConsent+"/"+stored.ID)
+	return fhirResponse(ctx, status, stored)
+}
+
+// fhirWriteError writes the OperationOutcome for the error of recording a Consent resource.
+// On a conflict the ETag header holds the current head of the chain, like for the REST api.
+func fhirWriteError(ctx echo.Context, err error) error {
+	var conflict *pkg.ConflictError
+	if errors.As(err, &conflict) {
+		ctx.Response().Header().Set("ETag", recordETag(conflict.Head.Hash))
+	}
+
+	switch {
+	case errors.Is(err, pkg.ErrorConflict):
+		return fhirError(ctx, http.StatusConflict, "conflict", err)
+	case errors.Is(err, pkg.ErrorNotFound), errors.Is(err, pkg.ErrorInvalidValidTo):
+		return fhirError(ctx, http.StatusBadRequest, "invalid", err)
+	case errors.Is(err, pkg.ErrorIdempotencyKeyReused):
+		return fhirError(ctx, http.StatusUnprocessableEntity, "business-rule", err)
+	case errors.Is(err, pkg.ErrorIdempotencyKeyInUse):
+		return fhirError(ctx, http.StatusConflict, "conflict", err)
+	}
+	return fhirError(ctx, http.StatusInternalServerError, "exception", err)
+}
+
+// fhirResponse writes the resource with the FHIR content type
+func fhirResponse(ctx echo.Context, code int, resource interface{}) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return ctx.Blob(code, fhir.ContentType, data)
+}
+
+// fhirError writes an OperationOutcome with the given FHIR issue code
+func fhirError(ctx echo.Context, status int, code string, err error) error {
+	return fhirResponse(ctx, status, fhir.NewOperationOutcome(code, err.Error()))
+}
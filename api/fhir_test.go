@@ -0,0 +1,248 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/random"
+	"github.com/nuts-foundation/nuts-consent-store/fhir"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_SearchFHIRConsent(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+	crq := consentRuleForQuery()
+	crq.Subject = "urn:oid:2.16.840.1.113883.2.4.6.3:999999990"
+	other := consentRuleForQuery()
+	other.Actor = "other"
+	other.Records[0].DataClasses = []pkg.DataClass{{Code: "urn:oid:1.3.6.1.4.1.54851.1:MEDICAL"}}
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{other})
+
+	search := func(t *testing.T, query string, params SearchFHIRConsentParams) fhir.Bundle {
+		req := httptest.NewRequest(http.MethodGet, "/fhir/Consent?"+query, nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+
+		var bundle fhir.Bundle
+		if assert.NoError(t, client.SearchFHIRConsent(ctx, params)) {
+			assert.Equal(t, fhir.ContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &bundle))
+		}
+		return bundle
+	}
+	str := func(s string) *string {
+		return &s
+	}
+
+	t.Run("returns a searchset Bundle for a FHIR token", func(t *testing.T) {
+		bundle := search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), Patient: str("urn:oid:2.16.840.1.113883.2.4.6.3|999999990")})
+
+		assert.Equal(t, fhir.BundleTypeSearchSet, bundle.Type)
+		if assert.Equal(t, 1, bundle.Total) {
+			assert.Equal(t, crq.Records[0].Hash, bundle.Entry[0].Resource.ID)
+			assert.Equal(t, fhir.ConsentStatusActive, bundle.Entry[0].Resource.Status)
+			assert.NotNil(t, bundle.Entry[0].Resource.Meta.LastUpdated)
+		}
+	})
+
+	t.Run("empty Bundle for unknown patient", func(t *testing.T) {
+		bundle := search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), Patient: str("unknown")})
+
+		assert.Equal(t, 0, bundle.Total)
+		assert.Empty(t, bundle.Entry)
+	})
+
+	t.Run("filters on actor and organization", func(t *testing.T) {
+		bundle := search(t, "", SearchFHIRConsentParams{Actor: str("other"), Organization: str("custodian")})
+
+		if assert.Equal(t, 1, bundle.Total) {
+			assert.Equal(t, other.Records[0].Hash, bundle.Entry[0].Resource.ID)
+		}
+	})
+
+	t.Run("filters on category", func(t *testing.T) {
+		assert.Equal(t, 1, search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), Category: str("urn:oid:1.3.6.1.4.1.54851.1|MEDICAL")}).Total)
+		assert.Equal(t, 2, search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), Category: str("http://loinc.org|59284-0")}).Total)
+	})
+
+	t.Run("filters on period", func(t *testing.T) {
+		tomorrow := time.Now().Add(48 * time.Hour).Format("2006-01-02")
+
+		assert.Equal(t, 2, search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), Period: &[]string{"lt" + tomorrow}}).Total)
+		assert.Equal(t, 0, search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), Period: &[]string{"ge" + tomorrow}}).Total)
+	})
+
+	t.Run("filters on _lastUpdated", func(t *testing.T) {
+		year := time.Now().Format("2006")
+
+		assert.Equal(t, 2, search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), LastUpdated: &[]string{year}}).Total)
+		assert.Equal(t, 0, search(t, "", SearchFHIRConsentParams{Organization: str("custodian"), LastUpdated: &[]string{"lt" + year}}).Total)
+	})
+
+	t.Run("pages with links", func(t *testing.T) {
+		count := 1
+		bundle := search(t, "_count=1", SearchFHIRConsentParams{Organization: str("custodian"), Count: &count})
+
+		assert.Equal(t, 2, bundle.Total)
+		assert.Len(t, bundle.Entry, 1)
+		links := map[string]string{}
+		for _, l := range bundle.Link {
+			links[l.Relation] = l.URL
+		}
+		assert.Equal(t, "http://example.com/fhir/Consent?_count=1&_offset=1", links["next"])
+		assert.NotContains(t, links, "previous")
+
+		offset := 1
+		next := search(t, "_count=1&_offset=1", SearchFHIRConsentParams{Organization: str("custodian"), Count: &count, Offset: &offset})
+
+		if assert.Len(t, next.Entry, 1) {
+			assert.NotEqual(t, bundle.Entry[0].Resource.ID, next.Entry[0].Resource.ID)
+		}
+	})
+
+	t.Run("400 without actor or organization", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+
+		err := client.SearchFHIRConsent(ctx, SearchFHIRConsentParams{Patient: str("urn:oid:2.16.840.1.113883.2.4.6.3|999999990")})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var oo fhir.OperationOutcome
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &oo))
+			assert.Equal(t, "required", oo.Issue[0].Code)
+		}
+	})
+
+	t.Run("400 on invalid period", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+
+		err := client.SearchFHIRConsent(ctx, SearchFHIRConsentParams{Organization: str("custodian"), Period: &[]string{"yesterday"}})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var oo fhir.OperationOutcome
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &oo))
+			assert.Equal(t, "invalid", oo.Issue[0].Code)
+		}
+	})
+}
+
+func TestWrapper_CreateFHIRConsent(t *testing.T) {
+	newContext := func(body interface{}) (echo.Context, *httptest.ResponseRecorder) {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+		req.Header.Set(echo.HeaderContentType, fhir.ContentType)
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("201", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		crq := consentRuleForQuery()
+		ctx, rec := newContext(fhir.FromConsentRecord(crq, crq.Records[0], true, time.Now()))
+
+		err := client.CreateFHIRConsent(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, "Consent/"+crq.Records[0].Hash, rec.Header().Get(echo.HeaderLocation))
+			var c fhir.Consent
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &c))
+			assert.Equal(t, "1", c.Meta.VersionID)
+		}
+		auth, _ := client.Cs.ConsentAuth(context.Background(), crq.Custodian, crq.Subject, crq.Actor, "resource", nil)
+		assert.True(t, auth)
+	})
+
+	t.Run("400 on invalid resource", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		ctx, rec := newContext(fhir.Consent{ResourceType: fhir.ResourceTypeConsent})
+
+		err := client.CreateFHIRConsent(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var oo fhir.OperationOutcome
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &oo))
+			assert.Equal(t, "invalid", oo.Issue[0].Code)
+		}
+	})
+
+	t.Run("400 on unknown previous record", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		crq := consentRuleForQuery()
+		previous := random.String(8)
+		crq.Records[0].PreviousHash = &previous
+		ctx, rec := newContext(fhir.FromConsentRecord(crq, crq.Records[0], true, time.Now()))
+
+		err := client.CreateFHIRConsent(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("409 on an append to a stale head", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Cs.Shutdown()
+		crq := consentRuleForQuery()
+		client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+		first := crq.Records[0].Hash
+		// both versions are appended to the first record, the second append is on a stale head
+		head, stale := crq, crq
+		head.Records = []pkg.ConsentRecord{crq.Records[0]}
+		head.Records[0].Hash = random.String(8)
+		head.Records[0].PreviousHash = &first
+		stale.Records = []pkg.ConsentRecord{crq.Records[0]}
+		stale.Records[0].Hash = random.String(8)
+		stale.Records[0].PreviousHash = &first
+		if err := client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{head}); err != nil {
+			t.Fatal(err)
+		}
+		ctx, rec := newContext(fhir.FromConsentRecord(stale, stale.Records[0], true, time.Now()))
+
+		err := client.CreateFHIRConsent(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusConflict, rec.Code)
+			assert.Equal(t, recordETag(head.Records[0].Hash), rec.Header().Get("ETag"))
+			var oo fhir.OperationOutcome
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &oo))
+			assert.Equal(t, "conflict", oo.Issue[0].Code)
+		}
+	})
+}
@@ -13,11 +13,60 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
+// Attributes defines model for Attributes.
+type Attributes struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// AuthorizationRequest defines model for AuthorizationRequest.
+type AuthorizationRequest struct {
+
+	// Attributes of a category by their ID
+	Action Attributes `json:"action"`
+
+	// Attributes of a category by their ID
+	Environment *Attributes `json:"environment,omitempty"`
+
+	// Attributes of a category by their ID
+	Resource Attributes `json:"resource"`
+
+	// Attributes of a category by their ID
+	Subject Attributes `json:"subject"`
+}
+
+// AuthorizationResponse defines model for AuthorizationResponse.
+type AuthorizationResponse struct {
+	Advice      *[]PolicyDirective `json:"advice,omitempty"`
+	Decision    string             `json:"decision"`
+	Obligations *[]PolicyDirective `json:"obligations,omitempty"`
+
+	// Only set when the decision is Indeterminate
+	Status *struct {
+
+		// XACML status code
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"status,omitempty"`
+}
+
+// Backup defines model for Backup.
+type Backup struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Path of the backup on the node
+	File string `json:"file"`
+
+	// Size of the backup in bytes
+	Size int64 `json:"size"`
+}
+
 // ConsentCheckRequest defines model for ConsentCheckRequest.
 type ConsentCheckRequest struct {
 
@@ -52,8 +101,11 @@ type ConsentQueryRequest struct {
 	Actor *Identifier `json:"actor,omitempty"`
 
 	// Generic identifier used for representing BSN, agbcode, etc. It's always constructed as an URN followed by a double colon (:) and then the identifying value of the given URN
-	Custodian *Identifier     `json:"custodian,omitempty"`
-	Page      *PageDefinition `json:"page,omitempty"`
+	Custodian *Identifier `json:"custodian,omitempty"`
+
+	// Also return deleted records (tombstones), for audit purposes. Optional, defaults to false
+	IncludeDeleted *bool           `json:"includeDeleted,omitempty"`
+	Page           *PageDefinition `json:"page,omitempty"`
 
 	// Generic identifier used for representing BSN, agbcode, etc. It's always constructed as an URN followed by a double colon (:) and then the identifying value of the given URN
 	Subject *Identifier `json:"subject,omitempty"`
@@ -77,6 +129,15 @@ type ConsentRecord struct {
 	// Array of consent classes
 	DataClasses []string `json:"dataClasses"`
 
+	// Only set for deleted records (tombstones), the moment of deletion. format: 2020-01-01T12:00:00+01:00
+	DeletedAt *string `json:"deletedAt,omitempty"`
+
+	// Only set for deleted records (tombstones), who deleted the record
+	DeletedBy *string `json:"deletedBy,omitempty"`
+
+	// Only set for deleted records (tombstones), the reason of deletion
+	DeletedReason *string `json:"deletedReason,omitempty"`
+
 	// the hash of the previous version of the hash
 	PreviousRecordHash *string `json:"previousRecordHash,omitempty"`
 
@@ -89,13 +150,72 @@ type ConsentRecord struct {
 	// DateTime to which a record is valid (exclusive)
 	ValidTo *ValidTo `json:"validTo,omitempty"`
 
-	// the version number for the record, starts at 1, equals the length of the chain when following the previousRecordHash
+	// the version number for the record, starts at 1, equals the length of the chain when following the previousRecordHash. When recording it's optional, if given it must be the next version of the chain.
 	Version *int `json:"version,omitempty"`
 }
 
+// ErasureCertificate defines model for ErasureCertificate.
+type ErasureCertificate struct {
+
+	// Moment of erasure. format: 2020-01-01T12:00:00+01:00
+	ErasedAt string `json:"erasedAt"`
+
+	// Who requested the erasure
+	ErasedBy          string   `json:"erasedBy"`
+	PatientConsentIds []string `json:"patientConsentIds"`
+
+	// The irreversible pseudonym that replaced the subject in the audit trail
+	Pseudonym string `json:"pseudonym"`
+
+	// The hashes of all removed consent records, including older versions and deleted records
+	RecordHashes []string `json:"recordHashes"`
+}
+
+// Event defines model for Event.
+type Event struct {
+
+	// Generic identifier used for representing BSN, agbcode, etc. It's always constructed as an URN followed by a double colon (:) and then the identifying value of the given URN
+	Actor Identifier `json:"actor"`
+
+	// Generic identifier used for representing BSN, agbcode, etc. It's always constructed as an URN followed by a double colon (:) and then the identifying value of the given URN
+	Custodian          Identifier `json:"custodian"`
+	DataClasses        []string   `json:"dataClasses"`
+	Id                 uint64     `json:"id"`
+	PatientConsentId   string     `json:"patientConsentId"`
+	PreviousRecordHash *string    `json:"previousRecordHash,omitempty"`
+	RecordHash         string     `json:"recordHash"`
+
+	// Generic identifier used for representing BSN, agbcode, etc. It's always constructed as an URN followed by a double colon (:) and then the identifying value of the given URN
+	Subject   Identifier `json:"subject"`
+	Timestamp time.Time  `json:"timestamp"`
+	Type      string     `json:"type"`
+	ValidFrom time.Time  `json:"validFrom"`
+	ValidTo   *time.Time `json:"validTo,omitempty"`
+	Version   int        `json:"version"`
+}
+
 // Identifier defines model for Identifier.
 type Identifier string
 
+// ImportError defines model for ImportError.
+type ImportError struct {
+	Error string `json:"error"`
+
+	// Line of the NDJSON body or row of the CSV body, the header row being 1
+	Line int `json:"line"`
+}
+
+// ImportReport defines model for ImportReport.
+type ImportReport struct {
+	DryRun bool          `json:"dryRun"`
+	Errors []ImportError `json:"errors"`
+	Failed int           `json:"failed"`
+
+	// Number of consents read
+	Lines    int `json:"lines"`
+	Recorded int `json:"recorded"`
+}
+
 // PageDefinition defines model for PageDefinition.
 type PageDefinition struct {
 	Limit  int `json:"limit"`
@@ -119,21 +239,144 @@ type PatientConsent struct {
 	Subject Identifier `json:"subject"`
 }
 
+// PolicyDirective defines model for PolicyDirective.
+type PolicyDirective struct {
+
+	// Attributes of a category by their ID
+	Attributes *Attributes `json:"attributes,omitempty"`
+	Id         string      `json:"id"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+
+	// stable identifier of the error
+	Code string `json:"code"`
+
+	// human readable explanation of this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// consent record corresponding with a single attachment in the distributed consent record.
+	Head   *ConsentRecord `json:"head,omitempty"`
+	Status int            `json:"status"`
+
+	// the HTTP status text
+	Title string `json:"title"`
+
+	// URI identifying the problem type, about:blank when absent
+	Type *string `json:"type,omitempty"`
+}
+
+// SignedDocument defines model for SignedDocument.
+type SignedDocument struct {
+
+	// The signature algorithm, ES256 or RS256
+	Algorithm string `json:"algorithm"`
+
+	// Base64 encoded DER certificate of the signing key
+	Certificate []byte `json:"certificate"`
+
+	// The signed document
+	Document map[string]interface{} `json:"document"`
+
+	// Base64 encoded signature
+	Signature []byte `json:"signature"`
+}
+
 // ValidFrom defines model for ValidFrom.
 type ValidFrom string
 
 // ValidTo defines model for ValidTo.
 type ValidTo string
 
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+
+	// Number of failed attempts, or the number of attempts it took when delivered
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// A single change to a consent record, sent as data of a Server-Sent Event
+	Event         Event                 `json:"event"`
+	Id            int                   `json:"id"`
+	LastAttemptAt *time.Time            `json:"lastAttemptAt,omitempty"`
+	LastError     *string               `json:"lastError,omitempty"`
+	NextAttemptAt time.Time             `json:"nextAttemptAt"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Url           string                `json:"url"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// List of WebhookDeliveryStatus
+const (
+	WebhookDeliveryStatus_dead      WebhookDeliveryStatus = "dead"
+	WebhookDeliveryStatus_delivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatus_pending   WebhookDeliveryStatus = "pending"
+)
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+
+	// only list deliveries with the given status
+	Status *WebhookDeliveryStatus `json:"status,omitempty"`
+}
+
 // CreateConsentJSONBody defines parameters for CreateConsent.
 type CreateConsentJSONBody PatientConsent
 
+// CreateConsentBatchJSONBody defines parameters for CreateConsentBatch.
+type CreateConsentBatchJSONBody []PatientConsent
+
+// BulkImportConsentParams defines parameters for BulkImportConsent.
+type BulkImportConsentParams struct {
+
+	// validate the lines against the store without writing anything
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// number of consents recorded in a single transaction, defaults to the configured importBatchSize
+	BatchSize *int `json:"batchSize,omitempty"`
+
+	// CSV column for every field that isn't in a column with the field name, e.g. subject=bsn;validFrom=start
+	Mapping *string `json:"mapping,omitempty"`
+}
+
 // CheckConsentJSONBody defines parameters for CheckConsent.
 type CheckConsentJSONBody ConsentCheckRequest
 
+// ConsentEventsParams defines parameters for ConsentEvents.
+type ConsentEventsParams struct {
+
+	// ID of the last received event, for clients that can't set the Last-Event-ID header
+	LastEventId *string `json:"lastEventId,omitempty"`
+
+	// ID of the last received event, sent by EventSource clients when reconnecting
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// ExpiringConsentParams defines parameters for ExpiringConsent.
+type ExpiringConsentParams struct {
+
+	// period from now, e.g. 30d or 72h. Defaults to the configured expiryHorizon
+	Within    *string     `json:"within,omitempty"`
+	Actor     *Identifier `json:"actor,omitempty"`
+	Custodian *Identifier `json:"custodian,omitempty"`
+}
+
 // QueryConsentJSONBody defines parameters for QueryConsent.
 type QueryConsentJSONBody ConsentQueryRequest
 
+// DeleteConsentParams defines parameters for DeleteConsent.
+type DeleteConsentParams struct {
+
+	// the reason for deleting the record, stored in the tombstone
+	Reason *string `json:"reason,omitempty"`
+
+	// who deleted the record, stored in the tombstone. Defaults to the CN of the client certificate when mTLS is used
+	DeletedBy *string `json:"deletedBy,omitempty"`
+}
+
 // FindConsentRecordParams defines parameters for FindConsentRecord.
 type FindConsentRecordParams struct {
 
@@ -141,15 +384,112 @@ type FindConsentRecordParams struct {
 	Latest *bool `json:"latest,omitempty"`
 }
 
+// SearchFHIRConsentParams defines parameters for SearchFHIRConsent.
+type SearchFHIRConsentParams struct {
+
+	// the patient identifier
+	Patient *string `json:"patient,omitempty"`
+
+	// the identifier of the actor
+	Actor *string `json:"actor,omitempty"`
+
+	// the identifier of the custodian
+	Organization *string `json:"organization,omitempty"`
+
+	// a data class of the record, the Patient Consent LOINC code (59284-0) matches all
+	Category *string `json:"category,omitempty"`
+
+	// matches the validity of the record, can be repeated, e.g. period=ge2020-01-01&period=lt2021
+	Period *[]string `json:"period,omitempty"`
+
+	// matches the moment the record was stored, can be repeated
+	LastUpdated *[]string `json:"_lastUpdated,omitempty"`
+
+	// number of resources per page, defaults to 50 with a maximum of 500. 0 only returns the total
+	Count *int `json:"_count,omitempty"`
+
+	// number of resources to skip, used by the paging links
+	Offset *int `json:"_offset,omitempty"`
+}
+
+// DecideJSONBody defines parameters for Decide.
+type DecideJSONBody AuthorizationRequest
+
+// EraseSubjectParams defines parameters for EraseSubject.
+type EraseSubjectParams struct {
+
+	// who requested the erasure, stored in the audit trail. Defaults to the CN of the client certificate
+	ErasedBy *string `json:"erasedBy,omitempty"`
+}
+
 // CreateConsentRequestBody defines body for CreateConsent for application/json ContentType.
 type CreateConsentJSONRequestBody CreateConsentJSONBody
 
+// CreateConsentBatchRequestBody defines body for CreateConsentBatch for application/json ContentType.
+type CreateConsentBatchJSONRequestBody CreateConsentBatchJSONBody
+
 // CheckConsentRequestBody defines body for CheckConsent for application/json ContentType.
 type CheckConsentJSONRequestBody CheckConsentJSONBody
 
 // QueryConsentRequestBody defines body for QueryConsent for application/json ContentType.
 type QueryConsentJSONRequestBody QueryConsentJSONBody
 
+// DecideRequestBody defines body for Decide for application/json ContentType.
+type DecideJSONRequestBody DecideJSONBody
+
+// Getter for additional properties for Attributes. Returns the specified
+// element and whether it was found
+func (a Attributes) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Attributes
+func (a *Attributes) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Attributes to handle AdditionalProperties
+func (a *Attributes) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Attributes to handle AdditionalProperties
+func (a Attributes) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -223,30 +563,70 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// CreateBackup request
+	CreateBackup(ctx context.Context) (*http.Response, error)
+
+	// ListWebhookDeliveries request
+	ListWebhookDeliveries(ctx context.Context, params *ListWebhookDeliveriesParams) (*http.Response, error)
+
+	// ReplayWebhookDelivery request
+	ReplayWebhookDelivery(ctx context.Context, id int) (*http.Response, error)
+
 	// CreateConsent request  with any body
 	CreateConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
 
 	CreateConsent(ctx context.Context, body CreateConsentJSONRequestBody) (*http.Response, error)
 
+	// CreateConsentBatch request  with any body
+	CreateConsentBatchWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+
+	CreateConsentBatch(ctx context.Context, body CreateConsentBatchJSONRequestBody) (*http.Response, error)
+
+	// BulkImportConsent request  with any body
+	BulkImportConsentWithBody(ctx context.Context, params *BulkImportConsentParams, contentType string, body io.Reader) (*http.Response, error)
+
 	// CheckConsent request  with any body
 	CheckConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
 
 	CheckConsent(ctx context.Context, body CheckConsentJSONRequestBody) (*http.Response, error)
 
+	// ConsentEvents request
+	ConsentEvents(ctx context.Context, params *ConsentEventsParams) (*http.Response, error)
+
+	// ExpiringConsent request
+	ExpiringConsent(ctx context.Context, params *ExpiringConsentParams) (*http.Response, error)
+
 	// QueryConsent request  with any body
 	QueryConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
 
 	QueryConsent(ctx context.Context, body QueryConsentJSONRequestBody) (*http.Response, error)
 
 	// DeleteConsent request
-	DeleteConsent(ctx context.Context, consentRecordHash string) (*http.Response, error)
+	DeleteConsent(ctx context.Context, consentRecordHash string, params *DeleteConsentParams) (*http.Response, error)
 
 	// FindConsentRecord request
 	FindConsentRecord(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*http.Response, error)
+
+	// SearchFHIRConsent request
+	SearchFHIRConsent(ctx context.Context, params *SearchFHIRConsentParams) (*http.Response, error)
+
+	// CreateFHIRConsent request  with any body
+	CreateFHIRConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+
+	// Decide request  with any body
+	DecideWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+
+	Decide(ctx context.Context, body DecideJSONRequestBody) (*http.Response, error)
+
+	// EraseSubject request
+	EraseSubject(ctx context.Context, subject Identifier, params *EraseSubjectParams) (*http.Response, error)
+
+	// ExportSubject request
+	ExportSubject(ctx context.Context, subject Identifier) (*http.Response, error)
 }
 
-func (c *Client) CreateConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
-	req, err := NewCreateConsentRequestWithBody(c.Server, contentType, body)
+func (c *Client) CreateBackup(ctx context.Context) (*http.Response, error) {
+	req, err := NewCreateBackupRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -260,8 +640,8 @@ func (c *Client) CreateConsentWithBody(ctx context.Context, contentType string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateConsent(ctx context.Context, body CreateConsentJSONRequestBody) (*http.Response, error) {
-	req, err := NewCreateConsentRequest(c.Server, body)
+func (c *Client) ListWebhookDeliveries(ctx context.Context, params *ListWebhookDeliveriesParams) (*http.Response, error) {
+	req, err := NewListWebhookDeliveriesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -275,8 +655,8 @@ func (c *Client) CreateConsent(ctx context.Context, body CreateConsentJSONReques
 	return c.Client.Do(req)
 }
 
-func (c *Client) CheckConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
-	req, err := NewCheckConsentRequestWithBody(c.Server, contentType, body)
+func (c *Client) ReplayWebhookDelivery(ctx context.Context, id int) (*http.Response, error) {
+	req, err := NewReplayWebhookDeliveryRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -290,8 +670,8 @@ func (c *Client) CheckConsentWithBody(ctx context.Context, contentType string, b
 	return c.Client.Do(req)
 }
 
-func (c *Client) CheckConsent(ctx context.Context, body CheckConsentJSONRequestBody) (*http.Response, error) {
-	req, err := NewCheckConsentRequest(c.Server, body)
+func (c *Client) CreateConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewCreateConsentRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -305,8 +685,8 @@ func (c *Client) CheckConsent(ctx context.Context, body CheckConsentJSONRequestB
 	return c.Client.Do(req)
 }
 
-func (c *Client) QueryConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
-	req, err := NewQueryConsentRequestWithBody(c.Server, contentType, body)
+func (c *Client) CreateConsent(ctx context.Context, body CreateConsentJSONRequestBody) (*http.Response, error) {
+	req, err := NewCreateConsentRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -320,8 +700,8 @@ func (c *Client) QueryConsentWithBody(ctx context.Context, contentType string, b
 	return c.Client.Do(req)
 }
 
-func (c *Client) QueryConsent(ctx context.Context, body QueryConsentJSONRequestBody) (*http.Response, error) {
-	req, err := NewQueryConsentRequest(c.Server, body)
+func (c *Client) CreateConsentBatchWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewCreateConsentBatchRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -335,8 +715,8 @@ func (c *Client) QueryConsent(ctx context.Context, body QueryConsentJSONRequestB
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteConsent(ctx context.Context, consentRecordHash string) (*http.Response, error) {
-	req, err := NewDeleteConsentRequest(c.Server, consentRecordHash)
+func (c *Client) CreateConsentBatch(ctx context.Context, body CreateConsentBatchJSONRequestBody) (*http.Response, error) {
+	req, err := NewCreateConsentBatchRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -350,8 +730,8 @@ func (c *Client) DeleteConsent(ctx context.Context, consentRecordHash string) (*
 	return c.Client.Do(req)
 }
 
-func (c *Client) FindConsentRecord(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*http.Response, error) {
-	req, err := NewFindConsentRecordRequest(c.Server, consentRecordHash, params)
+func (c *Client) BulkImportConsentWithBody(ctx context.Context, params *BulkImportConsentParams, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewBulkImportConsentRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -365,140 +745,226 @@ func (c *Client) FindConsentRecord(ctx context.Context, consentRecordHash string
 	return c.Client.Do(req)
 }
 
-// NewCreateConsentRequest calls the generic CreateConsent builder with application/json body
-func NewCreateConsentRequest(server string, body CreateConsentJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) CheckConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewCheckConsentRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateConsentRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
 }
 
-// NewCreateConsentRequestWithBody generates requests for CreateConsent with any type of body
-func NewCreateConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	queryUrl, err := url.Parse(server)
+func (c *Client) CheckConsent(ctx context.Context, body CheckConsentJSONRequestBody) (*http.Response, error) {
+	req, err := NewCheckConsentRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	basePath := fmt.Sprintf("/consent")
-	if basePath[0] == '/' {
-		basePath = basePath[1:]
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return c.Client.Do(req)
+}
 
-	queryUrl, err = queryUrl.Parse(basePath)
+func (c *Client) ConsentEvents(ctx context.Context, params *ConsentEventsParams) (*http.Response, error) {
+	req, err := NewConsentEventsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryUrl.String(), body)
-	if err != nil {
-		return nil, err
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	req.Header.Add("Content-Type", contentType)
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewCheckConsentRequest calls the generic CheckConsent builder with application/json body
-func NewCheckConsentRequest(server string, body CheckConsentJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) ExpiringConsent(ctx context.Context, params *ExpiringConsentParams) (*http.Response, error) {
+	req, err := NewExpiringConsentRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCheckConsentRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
 }
 
-// NewCheckConsentRequestWithBody generates requests for CheckConsent with any type of body
-func NewCheckConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	queryUrl, err := url.Parse(server)
+func (c *Client) QueryConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewQueryConsentRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	basePath := fmt.Sprintf("/consent/check")
-	if basePath[0] == '/' {
-		basePath = basePath[1:]
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return c.Client.Do(req)
+}
 
-	queryUrl, err = queryUrl.Parse(basePath)
+func (c *Client) QueryConsent(ctx context.Context, body QueryConsentJSONRequestBody) (*http.Response, error) {
+	req, err := NewQueryConsentRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryUrl.String(), body)
+func (c *Client) DeleteConsent(ctx context.Context, consentRecordHash string, params *DeleteConsentParams) (*http.Response, error) {
+	req, err := NewDeleteConsentRequest(c.Server, consentRecordHash, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-	return req, nil
-}
-
-// NewQueryConsentRequest calls the generic QueryConsent builder with application/json body
-func NewQueryConsentRequest(server string, body QueryConsentJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FindConsentRecord(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*http.Response, error) {
+	req, err := NewFindConsentRecordRequest(c.Server, consentRecordHash, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewQueryConsentRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
 }
 
-// NewQueryConsentRequestWithBody generates requests for QueryConsent with any type of body
-func NewQueryConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	queryUrl, err := url.Parse(server)
+func (c *Client) SearchFHIRConsent(ctx context.Context, params *SearchFHIRConsentParams) (*http.Response, error) {
+	req, err := NewSearchFHIRConsentRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	basePath := fmt.Sprintf("/consent/query")
-	if basePath[0] == '/' {
-		basePath = basePath[1:]
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return c.Client.Do(req)
+}
 
-	queryUrl, err = queryUrl.Parse(basePath)
+func (c *Client) CreateFHIRConsentWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewCreateFHIRConsentRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryUrl.String(), body)
+func (c *Client) DecideWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewDecideRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-	return req, nil
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
 }
 
-// NewDeleteConsentRequest generates requests for DeleteConsent
-func NewDeleteConsentRequest(server string, consentRecordHash string) (*http.Request, error) {
-	var err error
+func (c *Client) Decide(ctx context.Context, body DecideJSONRequestBody) (*http.Response, error) {
+	req, err := NewDecideRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam0 string
+func (c *Client) EraseSubject(ctx context.Context, subject Identifier, params *EraseSubjectParams) (*http.Response, error) {
+	req, err := NewEraseSubjectRequest(c.Server, subject, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParam("simple", false, "consentRecordHash", consentRecordHash)
+func (c *Client) ExportSubject(ctx context.Context, subject Identifier) (*http.Response, error) {
+	req, err := NewExportSubjectRequest(c.Server, subject)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewCreateBackupRequest generates requests for CreateBackup
+func NewCreateBackupRequest(server string) (*http.Request, error) {
+	var err error
 
 	queryUrl, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	basePath := fmt.Sprintf("/consent/%s", pathParam0)
+	basePath := fmt.Sprintf("/admin/backups")
 	if basePath[0] == '/' {
 		basePath = basePath[1:]
 	}
@@ -508,7 +974,7 @@ func NewDeleteConsentRequest(server string, consentRecordHash string) (*http.Req
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryUrl.String(), nil)
+	req, err := http.NewRequest("POST", queryUrl.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -516,23 +982,16 @@ func NewDeleteConsentRequest(server string, consentRecordHash string) (*http.Req
 	return req, nil
 }
 
-// NewFindConsentRecordRequest generates requests for FindConsentRecord
-func NewFindConsentRecordRequest(server string, consentRecordHash string, params *FindConsentRecordParams) (*http.Request, error) {
+// NewListWebhookDeliveriesRequest generates requests for ListWebhookDeliveries
+func NewListWebhookDeliveriesRequest(server string, params *ListWebhookDeliveriesParams) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParam("simple", false, "consentRecordHash", consentRecordHash)
-	if err != nil {
-		return nil, err
-	}
-
 	queryUrl, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	basePath := fmt.Sprintf("/consent/%s", pathParam0)
+	basePath := fmt.Sprintf("/admin/webhooks/deliveries")
 	if basePath[0] == '/' {
 		basePath = basePath[1:]
 	}
@@ -544,9 +1003,9 @@ func NewFindConsentRecordRequest(server string, consentRecordHash string, params
 
 	queryValues := queryUrl.Query()
 
-	if params.Latest != nil {
+	if params.Status != nil {
 
-		if queryFrag, err := runtime.StyleParam("form", true, "latest", *params.Latest); err != nil {
+		if queryFrag, err := runtime.StyleParam("form", true, "status", *params.Status); err != nil {
 			return nil, err
 		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
@@ -570,243 +1029,1796 @@ func NewFindConsentRecordRequest(server string, consentRecordHash string, params
 	return req, nil
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewReplayWebhookDeliveryRequest generates requests for ReplayWebhookDelivery
+func NewReplayWebhookDeliveryRequest(server string, id int) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", false, "id", id)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// CreateConsent request  with any body
-	CreateConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateConsentResponse, error)
-
-	CreateConsentWithResponse(ctx context.Context, body CreateConsentJSONRequestBody) (*CreateConsentResponse, error)
-
-	// CheckConsent request  with any body
-	CheckConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CheckConsentResponse, error)
 
-	CheckConsentWithResponse(ctx context.Context, body CheckConsentJSONRequestBody) (*CheckConsentResponse, error)
-
-	// QueryConsent request  with any body
-	QueryConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*QueryConsentResponse, error)
-
-	QueryConsentWithResponse(ctx context.Context, body QueryConsentJSONRequestBody) (*QueryConsentResponse, error)
+	basePath := fmt.Sprintf("/admin/webhooks/deliveries/%s/replay", pathParam0)
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
 
-	// DeleteConsent request
-	DeleteConsentWithResponse(ctx context.Context, consentRecordHash string) (*DeleteConsentResponse, error)
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
 
-	// FindConsentRecord request
-	FindConsentRecordWithResponse(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*FindConsentRecordResponse, error)
-}
+	req, err := http.NewRequest("POST", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-type CreateConsentResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r CreateConsentResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewCreateConsentRequest calls the generic CreateConsent builder with application/json body
+func NewCreateConsentRequest(server string, body CreateConsentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateConsentRequestWithBody(server, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateConsentResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewCreateConsentRequestWithBody generates requests for CreateConsent with any type of body
+func NewCreateConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type CheckConsentResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ConsentCheckResponse
-}
+	basePath := fmt.Sprintf("/consent")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
 
-// Status returns HTTPResponse.Status
-func (r CheckConsentResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CheckConsentResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type QueryConsentResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ConsentQueryResponse
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r QueryConsentResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewCreateConsentBatchRequest calls the generic CreateConsentBatch builder with application/json body
+func NewCreateConsentBatchRequest(server string, body CreateConsentBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateConsentBatchRequestWithBody(server, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r QueryConsentResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewCreateConsentBatchRequestWithBody generates requests for CreateConsentBatch with any type of body
+func NewCreateConsentBatchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
+
+	basePath := fmt.Sprintf("/consent/batch")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewBulkImportConsentRequestWithBody generates requests for BulkImportConsent with any type of body
+func NewBulkImportConsentRequestWithBody(server string, params *BulkImportConsentParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/bulk")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.DryRun != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "dryRun", *params.DryRun); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.BatchSize != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "batchSize", *params.BatchSize); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Mapping != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "mapping", *params.Mapping); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewCheckConsentRequest calls the generic CheckConsent builder with application/json body
+func NewCheckConsentRequest(server string, body CheckConsentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCheckConsentRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCheckConsentRequestWithBody generates requests for CheckConsent with any type of body
+func NewCheckConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/check")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewConsentEventsRequest generates requests for ConsentEvents
+func NewConsentEventsRequest(server string, params *ConsentEventsParams) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/events")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.LastEventId != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "lastEventId", *params.LastEventId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.LastEventID != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParam("simple", false, "Last-Event-ID", *params.LastEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Last-Event-ID", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewExpiringConsentRequest generates requests for ExpiringConsent
+func NewExpiringConsentRequest(server string, params *ExpiringConsentParams) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/expiring")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.Within != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "within", *params.Within); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Actor != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "actor", *params.Actor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Custodian != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "custodian", *params.Custodian); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewQueryConsentRequest calls the generic QueryConsent builder with application/json body
+func NewQueryConsentRequest(server string, body QueryConsentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewQueryConsentRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewQueryConsentRequestWithBody generates requests for QueryConsent with any type of body
+func NewQueryConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/query")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewDeleteConsentRequest generates requests for DeleteConsent
+func NewDeleteConsentRequest(server string, consentRecordHash string, params *DeleteConsentParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", false, "consentRecordHash", consentRecordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/%s", pathParam0)
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.Reason != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "reason", *params.Reason); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.DeletedBy != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "deletedBy", *params.DeletedBy); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("DELETE", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewFindConsentRecordRequest generates requests for FindConsentRecord
+func NewFindConsentRecordRequest(server string, consentRecordHash string, params *FindConsentRecordParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", false, "consentRecordHash", consentRecordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/consent/%s", pathParam0)
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.Latest != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "latest", *params.Latest); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSearchFHIRConsentRequest generates requests for SearchFHIRConsent
+func NewSearchFHIRConsentRequest(server string, params *SearchFHIRConsentParams) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/fhir/Consent")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.Patient != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "patient", *params.Patient); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Actor != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "actor", *params.Actor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Organization != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "organization", *params.Organization); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Category != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "category", *params.Category); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Period != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "period", *params.Period); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.LastUpdated != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "_lastUpdated", *params.LastUpdated); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Count != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "_count", *params.Count); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Offset != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "_offset", *params.Offset); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateFHIRConsentRequestWithBody generates requests for CreateFHIRConsent with any type of body
+func NewCreateFHIRConsentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/fhir/Consent")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewDecideRequest calls the generic Decide builder with application/json body
+func NewDecideRequest(server string, body DecideJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDecideRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewDecideRequestWithBody generates requests for Decide with any type of body
+func NewDecideRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/pdp/decision")
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewEraseSubjectRequest generates requests for EraseSubject
+func NewEraseSubjectRequest(server string, subject Identifier, params *EraseSubjectParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", false, "subject", subject)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/subject/%s", pathParam0)
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.ErasedBy != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "erasedBy", *params.ErasedBy); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("DELETE", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportSubjectRequest generates requests for ExportSubject
+func NewExportSubjectRequest(server string, subject Identifier) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", false, "subject", subject)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := fmt.Sprintf("/subject/%s/export", pathParam0)
+	if basePath[0] == '/' {
+		basePath = basePath[1:]
+	}
+
+	queryUrl, err = queryUrl.Parse(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// CreateBackup request
+	CreateBackupWithResponse(ctx context.Context) (*CreateBackupResponse, error)
+
+	// ListWebhookDeliveries request
+	ListWebhookDeliveriesWithResponse(ctx context.Context, params *ListWebhookDeliveriesParams) (*ListWebhookDeliveriesResponse, error)
+
+	// ReplayWebhookDelivery request
+	ReplayWebhookDeliveryWithResponse(ctx context.Context, id int) (*ReplayWebhookDeliveryResponse, error)
+
+	// CreateConsent request  with any body
+	CreateConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateConsentResponse, error)
+
+	CreateConsentWithResponse(ctx context.Context, body CreateConsentJSONRequestBody) (*CreateConsentResponse, error)
+
+	// CreateConsentBatch request  with any body
+	CreateConsentBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateConsentBatchResponse, error)
+
+	CreateConsentBatchWithResponse(ctx context.Context, body CreateConsentBatchJSONRequestBody) (*CreateConsentBatchResponse, error)
+
+	// BulkImportConsent request  with any body
+	BulkImportConsentWithBodyWithResponse(ctx context.Context, params *BulkImportConsentParams, contentType string, body io.Reader) (*BulkImportConsentResponse, error)
+
+	// CheckConsent request  with any body
+	CheckConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CheckConsentResponse, error)
+
+	CheckConsentWithResponse(ctx context.Context, body CheckConsentJSONRequestBody) (*CheckConsentResponse, error)
+
+	// ConsentEvents request
+	ConsentEventsWithResponse(ctx context.Context, params *ConsentEventsParams) (*ConsentEventsResponse, error)
+
+	// ExpiringConsent request
+	ExpiringConsentWithResponse(ctx context.Context, params *ExpiringConsentParams) (*ExpiringConsentResponse, error)
+
+	// QueryConsent request  with any body
+	QueryConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*QueryConsentResponse, error)
+
+	QueryConsentWithResponse(ctx context.Context, body QueryConsentJSONRequestBody) (*QueryConsentResponse, error)
+
+	// DeleteConsent request
+	DeleteConsentWithResponse(ctx context.Context, consentRecordHash string, params *DeleteConsentParams) (*DeleteConsentResponse, error)
+
+	// FindConsentRecord request
+	FindConsentRecordWithResponse(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*FindConsentRecordResponse, error)
+
+	// SearchFHIRConsent request
+	SearchFHIRConsentWithResponse(ctx context.Context, params *SearchFHIRConsentParams) (*SearchFHIRConsentResponse, error)
+
+	// CreateFHIRConsent request  with any body
+	CreateFHIRConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateFHIRConsentResponse, error)
+
+	// Decide request  with any body
+	DecideWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*DecideResponse, error)
+
+	DecideWithResponse(ctx context.Context, body DecideJSONRequestBody) (*DecideResponse, error)
+
+	// EraseSubject request
+	EraseSubjectWithResponse(ctx context.Context, subject Identifier, params *EraseSubjectParams) (*EraseSubjectResponse, error)
+
+	// ExportSubject request
+	ExportSubjectWithResponse(ctx context.Context, subject Identifier) (*ExportSubjectResponse, error)
+}
+
+type CreateBackupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Backup
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBackupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBackupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WebhookDelivery
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhookDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhookDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReplayWebhookDeliveryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookDelivery
+}
+
+// Status returns HTTPResponse.Status
+func (r ReplayWebhookDeliveryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReplayWebhookDeliveryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateConsentBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateConsentBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateConsentBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BulkImportConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportReport
+}
+
+// Status returns HTTPResponse.Status
+func (r BulkImportConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BulkImportConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConsentCheckResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConsentEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ConsentEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConsentEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExpiringConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConsentQueryResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExpiringConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExpiringConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConsentQueryResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
 type DeleteConsentResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteConsentResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// Status returns HTTPResponse.Status
+func (r DeleteConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FindConsentRecordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConsentRecord
+}
+
+// Status returns HTTPResponse.Status
+func (r FindConsentRecordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FindConsentRecordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SearchFHIRConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchFHIRConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchFHIRConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateFHIRConsentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateFHIRConsentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateFHIRConsentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DecideResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthorizationResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DecideResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DecideResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EraseSubjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ErasureCertificate
+}
+
+// Status returns HTTPResponse.Status
+func (r EraseSubjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EraseSubjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportSubjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SignedDocument
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportSubjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportSubjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// CreateBackupWithResponse request returning *CreateBackupResponse
+func (c *ClientWithResponses) CreateBackupWithResponse(ctx context.Context) (*CreateBackupResponse, error) {
+	rsp, err := c.CreateBackup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBackupResponse(rsp)
+}
+
+// ListWebhookDeliveriesWithResponse request returning *ListWebhookDeliveriesResponse
+func (c *ClientWithResponses) ListWebhookDeliveriesWithResponse(ctx context.Context, params *ListWebhookDeliveriesParams) (*ListWebhookDeliveriesResponse, error) {
+	rsp, err := c.ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhookDeliveriesResponse(rsp)
+}
+
+// ReplayWebhookDeliveryWithResponse request returning *ReplayWebhookDeliveryResponse
+func (c *ClientWithResponses) ReplayWebhookDeliveryWithResponse(ctx context.Context, id int) (*ReplayWebhookDeliveryResponse, error) {
+	rsp, err := c.ReplayWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplayWebhookDeliveryResponse(rsp)
+}
+
+// CreateConsentWithBodyWithResponse request with arbitrary body returning *CreateConsentResponse
+func (c *ClientWithResponses) CreateConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateConsentResponse, error) {
+	rsp, err := c.CreateConsentWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateConsentResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateConsentWithResponse(ctx context.Context, body CreateConsentJSONRequestBody) (*CreateConsentResponse, error) {
+	rsp, err := c.CreateConsent(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateConsentResponse(rsp)
+}
+
+// CreateConsentBatchWithBodyWithResponse request with arbitrary body returning *CreateConsentBatchResponse
+func (c *ClientWithResponses) CreateConsentBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateConsentBatchResponse, error) {
+	rsp, err := c.CreateConsentBatchWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateConsentBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateConsentBatchWithResponse(ctx context.Context, body CreateConsentBatchJSONRequestBody) (*CreateConsentBatchResponse, error) {
+	rsp, err := c.CreateConsentBatch(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateConsentBatchResponse(rsp)
+}
+
+// BulkImportConsentWithBodyWithResponse request with arbitrary body returning *BulkImportConsentResponse
+func (c *ClientWithResponses) BulkImportConsentWithBodyWithResponse(ctx context.Context, params *BulkImportConsentParams, contentType string, body io.Reader) (*BulkImportConsentResponse, error) {
+	rsp, err := c.BulkImportConsentWithBody(ctx, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBulkImportConsentResponse(rsp)
+}
+
+// CheckConsentWithBodyWithResponse request with arbitrary body returning *CheckConsentResponse
+func (c *ClientWithResponses) CheckConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CheckConsentResponse, error) {
+	rsp, err := c.CheckConsentWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckConsentResponse(rsp)
+}
+
+func (c *ClientWithResponses) CheckConsentWithResponse(ctx context.Context, body CheckConsentJSONRequestBody) (*CheckConsentResponse, error) {
+	rsp, err := c.CheckConsent(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckConsentResponse(rsp)
+}
+
+// ConsentEventsWithResponse request returning *ConsentEventsResponse
+func (c *ClientWithResponses) ConsentEventsWithResponse(ctx context.Context, params *ConsentEventsParams) (*ConsentEventsResponse, error) {
+	rsp, err := c.ConsentEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConsentEventsResponse(rsp)
+}
+
+// ExpiringConsentWithResponse request returning *ExpiringConsentResponse
+func (c *ClientWithResponses) ExpiringConsentWithResponse(ctx context.Context, params *ExpiringConsentParams) (*ExpiringConsentResponse, error) {
+	rsp, err := c.ExpiringConsent(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExpiringConsentResponse(rsp)
+}
+
+// QueryConsentWithBodyWithResponse request with arbitrary body returning *QueryConsentResponse
+func (c *ClientWithResponses) QueryConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*QueryConsentResponse, error) {
+	rsp, err := c.QueryConsentWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryConsentResponse(rsp)
+}
+
+func (c *ClientWithResponses) QueryConsentWithResponse(ctx context.Context, body QueryConsentJSONRequestBody) (*QueryConsentResponse, error) {
+	rsp, err := c.QueryConsent(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryConsentResponse(rsp)
+}
+
+// DeleteConsentWithResponse request returning *DeleteConsentResponse
+func (c *ClientWithResponses) DeleteConsentWithResponse(ctx context.Context, consentRecordHash string, params *DeleteConsentParams) (*DeleteConsentResponse, error) {
+	rsp, err := c.DeleteConsent(ctx, consentRecordHash, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteConsentResponse(rsp)
+}
+
+// FindConsentRecordWithResponse request returning *FindConsentRecordResponse
+func (c *ClientWithResponses) FindConsentRecordWithResponse(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*FindConsentRecordResponse, error) {
+	rsp, err := c.FindConsentRecord(ctx, consentRecordHash, params)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseFindConsentRecordResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteConsentResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// SearchFHIRConsentWithResponse request returning *SearchFHIRConsentResponse
+func (c *ClientWithResponses) SearchFHIRConsentWithResponse(ctx context.Context, params *SearchFHIRConsentParams) (*SearchFHIRConsentResponse, error) {
+	rsp, err := c.SearchFHIRConsent(ctx, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseSearchFHIRConsentResponse(rsp)
 }
 
-type FindConsentRecordResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ConsentRecord
+// CreateFHIRConsentWithBodyWithResponse request with arbitrary body returning *CreateFHIRConsentResponse
+func (c *ClientWithResponses) CreateFHIRConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateFHIRConsentResponse, error) {
+	rsp, err := c.CreateFHIRConsentWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateFHIRConsentResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r FindConsentRecordResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// DecideWithBodyWithResponse request with arbitrary body returning *DecideResponse
+func (c *ClientWithResponses) DecideWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*DecideResponse, error) {
+	rsp, err := c.DecideWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseDecideResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r FindConsentRecordResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *ClientWithResponses) DecideWithResponse(ctx context.Context, body DecideJSONRequestBody) (*DecideResponse, error) {
+	rsp, err := c.Decide(ctx, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseDecideResponse(rsp)
 }
 
-// CreateConsentWithBodyWithResponse request with arbitrary body returning *CreateConsentResponse
-func (c *ClientWithResponses) CreateConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CreateConsentResponse, error) {
-	rsp, err := c.CreateConsentWithBody(ctx, contentType, body)
+// EraseSubjectWithResponse request returning *EraseSubjectResponse
+func (c *ClientWithResponses) EraseSubjectWithResponse(ctx context.Context, subject Identifier, params *EraseSubjectParams) (*EraseSubjectResponse, error) {
+	rsp, err := c.EraseSubject(ctx, subject, params)
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateConsentResponse(rsp)
+	return ParseEraseSubjectResponse(rsp)
 }
 
-func (c *ClientWithResponses) CreateConsentWithResponse(ctx context.Context, body CreateConsentJSONRequestBody) (*CreateConsentResponse, error) {
-	rsp, err := c.CreateConsent(ctx, body)
+// ExportSubjectWithResponse request returning *ExportSubjectResponse
+func (c *ClientWithResponses) ExportSubjectWithResponse(ctx context.Context, subject Identifier) (*ExportSubjectResponse, error) {
+	rsp, err := c.ExportSubject(ctx, subject)
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateConsentResponse(rsp)
+	return ParseExportSubjectResponse(rsp)
 }
 
-// CheckConsentWithBodyWithResponse request with arbitrary body returning *CheckConsentResponse
-func (c *ClientWithResponses) CheckConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*CheckConsentResponse, error) {
-	rsp, err := c.CheckConsentWithBody(ctx, contentType, body)
+// ParseCreateBackupResponse parses an HTTP response from a CreateBackupWithResponse call
+func ParseCreateBackupResponse(rsp *http.Response) (*CreateBackupResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseCheckConsentResponse(rsp)
+
+	response := &CreateBackupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Backup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWebhookDeliveriesResponse parses an HTTP response from a ListWebhookDeliveriesWithResponse call
+func ParseListWebhookDeliveriesResponse(rsp *http.Response) (*ListWebhookDeliveriesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhookDeliveriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WebhookDelivery
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReplayWebhookDeliveryResponse parses an HTTP response from a ReplayWebhookDeliveryWithResponse call
+func ParseReplayWebhookDeliveryResponse(rsp *http.Response) (*ReplayWebhookDeliveryResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReplayWebhookDeliveryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookDelivery
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateConsentResponse parses an HTTP response from a CreateConsentWithResponse call
+func ParseCreateConsentResponse(rsp *http.Response) (*CreateConsentResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateConsentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseCreateConsentBatchResponse parses an HTTP response from a CreateConsentBatchWithResponse call
+func ParseCreateConsentBatchResponse(rsp *http.Response) (*CreateConsentBatchResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateConsentBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseBulkImportConsentResponse parses an HTTP response from a BulkImportConsentWithResponse call
+func ParseBulkImportConsentResponse(rsp *http.Response) (*BulkImportConsentResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BulkImportConsentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckConsentResponse parses an HTTP response from a CheckConsentWithResponse call
+func ParseCheckConsentResponse(rsp *http.Response) (*CheckConsentResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckConsentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConsentCheckResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseConsentEventsResponse parses an HTTP response from a ConsentEventsWithResponse call
+func ParseConsentEventsResponse(rsp *http.Response) (*ConsentEventsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ConsentEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseExpiringConsentResponse parses an HTTP response from a ExpiringConsentWithResponse call
+func ParseExpiringConsentResponse(rsp *http.Response) (*ExpiringConsentResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExpiringConsentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConsentQueryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryConsentResponse parses an HTTP response from a QueryConsentWithResponse call
+func ParseQueryConsentResponse(rsp *http.Response) (*QueryConsentResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryConsentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConsentQueryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) CheckConsentWithResponse(ctx context.Context, body CheckConsentJSONRequestBody) (*CheckConsentResponse, error) {
-	rsp, err := c.CheckConsent(ctx, body)
+// ParseDeleteConsentResponse parses an HTTP response from a DeleteConsentWithResponse call
+func ParseDeleteConsentResponse(rsp *http.Response) (*DeleteConsentResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseCheckConsentResponse(rsp)
-}
 
-// QueryConsentWithBodyWithResponse request with arbitrary body returning *QueryConsentResponse
-func (c *ClientWithResponses) QueryConsentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*QueryConsentResponse, error) {
-	rsp, err := c.QueryConsentWithBody(ctx, contentType, body)
-	if err != nil {
-		return nil, err
+	response := &DeleteConsentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseQueryConsentResponse(rsp)
-}
 
-func (c *ClientWithResponses) QueryConsentWithResponse(ctx context.Context, body QueryConsentJSONRequestBody) (*QueryConsentResponse, error) {
-	rsp, err := c.QueryConsent(ctx, body)
-	if err != nil {
-		return nil, err
+	switch {
 	}
-	return ParseQueryConsentResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteConsentWithResponse request returning *DeleteConsentResponse
-func (c *ClientWithResponses) DeleteConsentWithResponse(ctx context.Context, consentRecordHash string) (*DeleteConsentResponse, error) {
-	rsp, err := c.DeleteConsent(ctx, consentRecordHash)
+// ParseFindConsentRecordResponse parses an HTTP response from a FindConsentRecordWithResponse call
+func ParseFindConsentRecordResponse(rsp *http.Response) (*FindConsentRecordResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteConsentResponse(rsp)
-}
 
-// FindConsentRecordWithResponse request returning *FindConsentRecordResponse
-func (c *ClientWithResponses) FindConsentRecordWithResponse(ctx context.Context, consentRecordHash string, params *FindConsentRecordParams) (*FindConsentRecordResponse, error) {
-	rsp, err := c.FindConsentRecord(ctx, consentRecordHash, params)
-	if err != nil {
-		return nil, err
+	response := &FindConsentRecordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseFindConsentRecordResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConsentRecord
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseCreateConsentResponse parses an HTTP response from a CreateConsentWithResponse call
-func ParseCreateConsentResponse(rsp *http.Response) (*CreateConsentResponse, error) {
+// ParseSearchFHIRConsentResponse parses an HTTP response from a SearchFHIRConsentWithResponse call
+func ParseSearchFHIRConsentResponse(rsp *http.Response) (*SearchFHIRConsentResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateConsentResponse{
+	response := &SearchFHIRConsentResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -817,48 +2829,41 @@ func ParseCreateConsentResponse(rsp *http.Response) (*CreateConsentResponse, err
 	return response, nil
 }
 
-// ParseCheckConsentResponse parses an HTTP response from a CheckConsentWithResponse call
-func ParseCheckConsentResponse(rsp *http.Response) (*CheckConsentResponse, error) {
+// ParseCreateFHIRConsentResponse parses an HTTP response from a CreateFHIRConsentWithResponse call
+func ParseCreateFHIRConsentResponse(rsp *http.Response) (*CreateFHIRConsentResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CheckConsentResponse{
+	response := &CreateFHIRConsentResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ConsentCheckResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	}
 
 	return response, nil
 }
 
-// ParseQueryConsentResponse parses an HTTP response from a QueryConsentWithResponse call
-func ParseQueryConsentResponse(rsp *http.Response) (*QueryConsentResponse, error) {
+// ParseDecideResponse parses an HTTP response from a DecideWithResponse call
+func ParseDecideResponse(rsp *http.Response) (*DecideResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &QueryConsentResponse{
+	response := &DecideResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ConsentQueryResponse
+		var dest AuthorizationResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -869,41 +2874,48 @@ func ParseQueryConsentResponse(rsp *http.Response) (*QueryConsentResponse, error
 	return response, nil
 }
 
-// ParseDeleteConsentResponse parses an HTTP response from a DeleteConsentWithResponse call
-func ParseDeleteConsentResponse(rsp *http.Response) (*DeleteConsentResponse, error) {
+// ParseEraseSubjectResponse parses an HTTP response from a EraseSubjectWithResponse call
+func ParseEraseSubjectResponse(rsp *http.Response) (*EraseSubjectResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteConsentResponse{
+	response := &EraseSubjectResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ErasureCertificate
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseFindConsentRecordResponse parses an HTTP response from a FindConsentRecordWithResponse call
-func ParseFindConsentRecordResponse(rsp *http.Response) (*FindConsentRecordResponse, error) {
+// ParseExportSubjectResponse parses an HTTP response from a ExportSubjectWithResponse call
+func ParseExportSubjectResponse(rsp *http.Response) (*ExportSubjectResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &FindConsentRecordResponse{
+	response := &ExportSubjectResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ConsentRecord
+		var dest SignedDocument
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -916,21 +2928,57 @@ func ParseFindConsentRecordResponse(rsp *http.Response) (*FindConsentRecordRespo
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Create an online backup of the database
+	// (POST /admin/backups)
+	CreateBackup(ctx echo.Context) error
+	// List webhook deliveries, newest first
+	// (GET /admin/webhooks/deliveries)
+	ListWebhookDeliveries(ctx echo.Context, params ListWebhookDeliveriesParams) error
+	// Attempt a webhook delivery again
+	// (POST /admin/webhooks/deliveries/{id}/replay)
+	ReplayWebhookDelivery(ctx echo.Context, id int) error
 	// Create a new consent record for a C-S-A combination.
 	// (POST /consent)
 	CreateConsent(ctx echo.Context) error
+	// Create the consent records of multiple C-S-A combinations at once.
+	// (POST /consent/batch)
+	CreateConsentBatch(ctx echo.Context) error
+	// Import many consents at once
+	// (POST /consent/bulk)
+	BulkImportConsent(ctx echo.Context, params BulkImportConsentParams) error
 	// Send a request for checking if the given combination exists
 	// (POST /consent/check)
 	CheckConsent(ctx echo.Context) error
+	// Stream consent mutations as Server-Sent Events
+	// (GET /consent/events)
+	ConsentEvents(ctx echo.Context, params ConsentEventsParams) error
+	// Query consent that ends within the given period
+	// (GET /consent/expiring)
+	ExpiringConsent(ctx echo.Context, params ExpiringConsentParams) error
 	// Do a query for available consent
 	// (POST /consent/query)
 	QueryConsent(ctx echo.Context) error
 	// Remove a consent record for a C-S-A combination.
 	// (DELETE /consent/{consentRecordHash})
-	DeleteConsent(ctx echo.Context, consentRecordHash string) error
+	DeleteConsent(ctx echo.Context, consentRecordHash string, params DeleteConsentParams) error
 	// Retrieve a consent record by hash, use latest query param to only return a value if the given consent record is the latest in the chain.
 	// (GET /consent/{consentRecordHash})
 	FindConsentRecord(ctx echo.Context, consentRecordHash string, params FindConsentRecordParams) error
+	// Search consent as FHIR R4 Consent resources
+	// (GET /fhir/Consent)
+	SearchFHIRConsent(ctx echo.Context, params SearchFHIRConsentParams) error
+	// Record a FHIR R4 Consent resource
+	// (POST /fhir/Consent)
+	CreateFHIRConsent(ctx echo.Context) error
+	// Decide on an attribute based authorization request
+	// (POST /pdp/decision)
+	Decide(ctx echo.Context) error
+	// Erase all consent data held about a subject (GDPR right to erasure)
+	// (DELETE /subject/{subject})
+	EraseSubject(ctx echo.Context, subject Identifier, params EraseSubjectParams) error
+	// Export all consent data held about a subject, including inactive and deleted records and the audit trail (GDPR subject access)
+	// (GET /subject/{subject}/export)
+	ExportSubject(ctx echo.Context, subject Identifier) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -938,6 +2986,49 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// CreateBackup converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateBackup(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateBackup(ctx)
+	return err
+}
+
+// ListWebhookDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWebhookDeliveries(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListWebhookDeliveries(ctx, params)
+	return err
+}
+
+// ReplayWebhookDelivery converts echo context to params.
+func (w *ServerInterfaceWrapper) ReplayWebhookDelivery(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ReplayWebhookDelivery(ctx, id)
+	return err
+}
+
 // CreateConsent converts echo context to params.
 func (w *ServerInterfaceWrapper) CreateConsent(ctx echo.Context) error {
 	var err error
@@ -947,6 +3038,47 @@ func (w *ServerInterfaceWrapper) CreateConsent(ctx echo.Context) error {
 	return err
 }
 
+// CreateConsentBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateConsentBatch(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateConsentBatch(ctx)
+	return err
+}
+
+// BulkImportConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) BulkImportConsent(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BulkImportConsentParams
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", ctx.QueryParams(), &params.DryRun)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dryRun: %s", err))
+	}
+
+	// ------------- Optional query parameter "batchSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "batchSize", ctx.QueryParams(), &params.BatchSize)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter batchSize: %s", err))
+	}
+
+	// ------------- Optional query parameter "mapping" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mapping", ctx.QueryParams(), &params.Mapping)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mapping: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.BulkImportConsent(ctx, params)
+	return err
+}
+
 // CheckConsent converts echo context to params.
 func (w *ServerInterfaceWrapper) CheckConsent(ctx echo.Context) error {
 	var err error
@@ -956,6 +3088,73 @@ func (w *ServerInterfaceWrapper) CheckConsent(ctx echo.Context) error {
 	return err
 }
 
+// ConsentEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) ConsentEvents(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ConsentEventsParams
+	// ------------- Optional query parameter "lastEventId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastEventId", ctx.QueryParams(), &params.LastEventId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter lastEventId: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Last-Event-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "Last-Event-ID", valueList[0], &LastEventID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Last-Event-ID: %s", err))
+		}
+
+		params.LastEventID = &LastEventID
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ConsentEvents(ctx, params)
+	return err
+}
+
+// ExpiringConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) ExpiringConsent(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExpiringConsentParams
+	// ------------- Optional query parameter "within" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "within", ctx.QueryParams(), &params.Within)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter within: %s", err))
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", ctx.QueryParams(), &params.Actor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor: %s", err))
+	}
+
+	// ------------- Optional query parameter "custodian" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "custodian", ctx.QueryParams(), &params.Custodian)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter custodian: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ExpiringConsent(ctx, params)
+	return err
+}
+
 // QueryConsent converts echo context to params.
 func (w *ServerInterfaceWrapper) QueryConsent(ctx echo.Context) error {
 	var err error
@@ -976,8 +3175,24 @@ func (w *ServerInterfaceWrapper) DeleteConsent(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter consentRecordHash: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteConsentParams
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", ctx.QueryParams(), &params.Reason)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reason: %s", err))
+	}
+
+	// ------------- Optional query parameter "deletedBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deletedBy", ctx.QueryParams(), &params.DeletedBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deletedBy: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.DeleteConsent(ctx, consentRecordHash)
+	err = w.Handler.DeleteConsent(ctx, consentRecordHash, params)
 	return err
 }
 
@@ -1006,6 +3221,132 @@ func (w *ServerInterfaceWrapper) FindConsentRecord(ctx echo.Context) error {
 	return err
 }
 
+// SearchFHIRConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchFHIRConsent(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchFHIRConsentParams
+	// ------------- Optional query parameter "patient" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "patient", ctx.QueryParams(), &params.Patient)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter patient: %s", err))
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", ctx.QueryParams(), &params.Actor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor: %s", err))
+	}
+
+	// ------------- Optional query parameter "organization" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "organization", ctx.QueryParams(), &params.Organization)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organization: %s", err))
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
+	// ------------- Optional query parameter "period" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "period", ctx.QueryParams(), &params.Period)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter period: %s", err))
+	}
+
+	// ------------- Optional query parameter "_lastUpdated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "_lastUpdated", ctx.QueryParams(), &params.LastUpdated)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter _lastUpdated: %s", err))
+	}
+
+	// ------------- Optional query parameter "_count" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "_count", ctx.QueryParams(), &params.Count)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter _count: %s", err))
+	}
+
+	// ------------- Optional query parameter "_offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "_offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter _offset: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchFHIRConsent(ctx, params)
+	return err
+}
+
+// CreateFHIRConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateFHIRConsent(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateFHIRConsent(ctx)
+	return err
+}
+
+// Decide converts echo context to params.
+func (w *ServerInterfaceWrapper) Decide(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.Decide(ctx)
+	return err
+}
+
+// EraseSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) EraseSubject(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "subject" -------------
+	var subject Identifier
+
+	err = runtime.BindStyledParameter("simple", false, "subject", ctx.Param("subject"), &subject)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subject: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EraseSubjectParams
+	// ------------- Optional query parameter "erasedBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "erasedBy", ctx.QueryParams(), &params.ErasedBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter erasedBy: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.EraseSubject(ctx, subject, params)
+	return err
+}
+
+// ExportSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportSubject(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "subject" -------------
+	var subject Identifier
+
+	err = runtime.BindStyledParameter("simple", false, "subject", ctx.Param("subject"), &subject)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subject: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ExportSubject(ctx, subject)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1034,11 +3375,23 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.POST(baseURL+"/admin/backups", wrapper.CreateBackup)
+	router.GET(baseURL+"/admin/webhooks/deliveries", wrapper.ListWebhookDeliveries)
+	router.POST(baseURL+"/admin/webhooks/deliveries/:id/replay", wrapper.ReplayWebhookDelivery)
 	router.POST(baseURL+"/consent", wrapper.CreateConsent)
+	router.POST(baseURL+"/consent/batch", wrapper.CreateConsentBatch)
+	router.POST(baseURL+"/consent/bulk", wrapper.BulkImportConsent)
 	router.POST(baseURL+"/consent/check", wrapper.CheckConsent)
+	router.GET(baseURL+"/consent/events", wrapper.ConsentEvents)
+	router.GET(baseURL+"/consent/expiring", wrapper.ExpiringConsent)
 	router.POST(baseURL+"/consent/query", wrapper.QueryConsent)
 	router.DELETE(baseURL+"/consent/:consentRecordHash", wrapper.DeleteConsent)
 	router.GET(baseURL+"/consent/:consentRecordHash", wrapper.FindConsentRecord)
+	router.GET(baseURL+"/fhir/Consent", wrapper.SearchFHIRConsent)
+	router.POST(baseURL+"/fhir/Consent", wrapper.CreateFHIRConsent)
+	router.POST(baseURL+"/pdp/decision", wrapper.Decide)
+	router.DELETE(baseURL+"/subject/:subject", wrapper.EraseSubject)
+	router.GET(baseURL+"/subject/:subject/export", wrapper.ExportSubject)
 
 }
 
@@ -40,7 +40,7 @@ func (t *testServer) FindConsentRecord(ctx echo.Context, consentRecordHash strin
 	return t.err
 }
 
-func (t *testServer) DeleteConsent(ctx echo.Context, consentRecordHash string) error {
+func (t *testServer) DeleteConsent(ctx echo.Context, consentRecordHash string, params DeleteConsentParams) error {
 	return t.err
 }
 
@@ -48,6 +48,10 @@ func (t *testServer) CreateConsent(ctx echo.Context) error {
 	return t.err
 }
 
+func (t *testServer) CreateConsentBatch(ctx echo.Context) error {
+	return t.err
+}
+
 func (t *testServer) CheckConsent(ctx echo.Context) error {
 	return t.err
 }
@@ -56,6 +60,50 @@ func (t *testServer) QueryConsent(ctx echo.Context) error {
 	return t.err
 }
 
+func (t *testServer) BulkImportConsent(ctx echo.Context, params BulkImportConsentParams) error {
+	return t.err
+}
+
+func (t *testServer) CreateBackup(ctx echo.Context) error {
+	return t.err
+}
+
+func (t *testServer) SearchFHIRConsent(ctx echo.Context, params SearchFHIRConsentParams) error {
+	return t.err
+}
+
+func (t *testServer) CreateFHIRConsent(ctx echo.Context) error {
+	return t.err
+}
+
+func (t *testServer) Decide(ctx echo.Context) error {
+	return t.err
+}
+
+func (t *testServer) ConsentEvents(ctx echo.Context, params ConsentEventsParams) error {
+	return t.err
+}
+
+func (t *testServer) ExpiringConsent(ctx echo.Context, params ExpiringConsentParams) error {
+	return t.err
+}
+
+func (t *testServer) ExportSubject(ctx echo.Context, subject Identifier) error {
+	return t.err
+}
+
+func (t *testServer) EraseSubject(ctx echo.Context, subject Identifier, params EraseSubjectParams) error {
+	return t.err
+}
+
+func (t *testServer) ListWebhookDeliveries(ctx echo.Context, params ListWebhookDeliveriesParams) error {
+	return t.err
+}
+
+func (t *testServer) ReplayWebhookDelivery(ctx echo.Context, id int) error {
+	return t.err
+}
+
 func TestServerInterfaceWrapper_CheckConsent(t *testing.T) {
 	for _, siw := range siws {
 		t.Run("CheckConsent call returns expected error", func(t *testing.T) {
@@ -113,8 +161,20 @@ func TestRegisterHandlers(t *testing.T) {
 		echo.EXPECT().POST("/consent", gomock.Any())
 		echo.EXPECT().POST("/consent/check", gomock.Any())
 		echo.EXPECT().POST("/consent/query", gomock.Any())
+		echo.EXPECT().POST("/consent/batch", gomock.Any())
+		echo.EXPECT().POST("/consent/bulk", gomock.Any())
+		echo.EXPECT().GET("/consent/events", gomock.Any())
+		echo.EXPECT().GET("/consent/expiring", gomock.Any())
 		echo.EXPECT().GET("/consent/:consentRecordHash", gomock.Any())
 		echo.EXPECT().DELETE("/consent/:consentRecordHash", gomock.Any())
+		echo.EXPECT().GET("/fhir/Consent", gomock.Any())
+		echo.EXPECT().POST("/fhir/Consent", gomock.Any())
+		echo.EXPECT().POST("/pdp/decision", gomock.Any())
+		echo.EXPECT().DELETE("/subject/:subject", gomock.Any())
+		echo.EXPECT().GET("/subject/:subject/export", gomock.Any())
+		echo.EXPECT().GET("/admin/webhooks/deliveries", gomock.Any())
+		echo.EXPECT().POST("/admin/webhooks/deliveries/:id/replay", gomock.Any())
+		echo.EXPECT().POST("/admin/backups", gomock.Any())
 
 		RegisterHandlers(echo, &testServer{})
 	})
@@ -0,0 +1,125 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// IdempotencyKeyHeader holds the key a client chooses for a write request, a retry with the same key gets the original response
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses that are replayed for a retry
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength is the maximum length of a key, it's stored as is
+const maxIdempotencyKeyLength = 255
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context holding the key the HttpClient sends with its writes. A caller retrying a write, for instance
+// after a timeout, uses the same key so the consent store doesn't record it twice and replays the original response instead.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKey returns the idempotency key of the context, empty when it has none
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// idempotentRequest identifies a write request sent with an Idempotency-Key header
+type idempotentRequest struct {
+	caller      string
+	key         string
+	fingerprint string
+}
+
+// newIdempotentRequest returns the idempotent request for the operation, nil when no Idempotency-Key header was sent.
+// The fingerprint is taken over the operation and the given parts of the request, like its parameters and body.
+func newIdempotentRequest(ctx echo.Context, operation string, parts ...[]byte) (*idempotentRequest, error) {
+	key := ctx.Request().Header.Get(IdempotencyKeyHeader)
+	if key == "" {
+		return nil, nil
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s exceeds %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+	}
+
+	h := sha256.New()
+	h.Write([]byte(operation))
+	for _, p := range parts {
+		// the length prefix prevents parts from running into each other
+		fmt.Fprintf(h, "|%d|", len(p))
+		h.Write(p)
+	}
+
+	return &idempotentRequest{
+		// like rate limits, keys of callers without a client certificate are scoped to their address
+		caller:      callerIdentity(ctx),
+		key:         key,
+		fingerprint: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// context returns the context of the request for the write, it holds the response the store saves with the write.
+// It's the context of the request itself when no Idempotency-Key header was sent.
+func (ir *idempotentRequest) context(ctx echo.Context, status int) context.Context {
+	if ir == nil {
+		return ctx.Request().Context()
+	}
+
+	return pkg.WithIdempotentResponse(ctx.Request().Context(), pkg.IdempotentResponse{
+		Key:         ir.key,
+		Caller:      ir.caller,
+		Fingerprint: ir.fingerprint,
+		Status:      status,
+	})
+}
+
+// replay writes the response without content of an earlier request with the same key. It returns true when the response was replayed.
+// pkg.ErrorIdempotencyKeyReused is returned when the key was used for a different request.
+func (w *Wrapper) replay(ctx echo.Context, ir *idempotentRequest) (bool, error) {
+	return w.replayWith(ctx, ir, func(response pkg.IdempotentResponse) error {
+		return ctx.NoContent(response.Status)
+	})
+}
+
+// replayWith writes the response of an earlier request with the same key using the given func, for responses with content
+func (w *Wrapper) replayWith(ctx echo.Context, ir *idempotentRequest, write func(response pkg.IdempotentResponse) error) (bool, error) {
+	if ir == nil {
+		return false, nil
+	}
+
+	response, err := w.Cs.FindIdempotentResponse(ctx.Request().Context(), ir.caller, ir.key, ir.fingerprint)
+	if err != nil || response == nil {
+		return false, err
+	}
+
+	ctx.Response().Header().Set(IdempotentReplayedHeader, "true")
+	return true, write(*response)
+}
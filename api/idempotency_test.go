@@ -0,0 +1,216 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/fhir"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_Idempotency(t *testing.T) {
+	w := defaultConsentStore()
+	defer w.Cs.Shutdown()
+	e := echo.New()
+	RegisterHandlers(ProblemRouter(e), &w)
+
+	// send performs the request with the given Idempotency-Key header
+	send := func(method string, path string, key string, body interface{}) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewReader(b))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	consent := testConsent()
+
+	t.Run("retry gets the original response", func(t *testing.T) {
+		first := send(http.MethodPost, "/consent", "create", consent)
+		retry := send(http.MethodPost, "/consent", "create", consent)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("keys of other callers are separate", func(t *testing.T) {
+		other := testConsent()
+		other.Subject = "other caller"
+		b, _ := json.Marshal(other)
+		req := httptest.NewRequest(http.MethodPost, "/consent", bytes.NewReader(b))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(IdempotencyKeyHeader, "create")
+		req.RemoteAddr = "192.0.2.2:1234"
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("same key with a different body is rejected", func(t *testing.T) {
+		other := consent
+		other.Subject = "other"
+
+		rec := send(http.MethodPost, "/consent", "create", other)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		p, ok := readProblem(rec.Header(), rec.Body.Bytes())
+		if assert.True(t, ok) {
+			assert.Equal(t, ProblemCodeIdempotencyKeyReused, p.Code)
+		}
+	})
+
+	t.Run("key of another operation is rejected", func(t *testing.T) {
+		rec := send(http.MethodPost, "/consent/batch", "create", []PatientConsent{consent})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("failed request isn't kept", func(t *testing.T) {
+		invalid := testConsent()
+		invalid.Actor = ""
+
+		assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/consent/batch", "batch", []PatientConsent{invalid}).Code)
+		valid := testConsent()
+		valid.Subject = "batch"
+		assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/consent/batch", "batch", []PatientConsent{valid}).Code)
+	})
+
+	t.Run("retried delete", func(t *testing.T) {
+		path := "/consent/" + consent.Records[0].RecordHash + "?reason=revoked"
+
+		first := send(http.MethodDelete, path, "delete", nil)
+		retry := send(http.MethodDelete, path, "delete", nil)
+
+		assert.Equal(t, http.StatusAccepted, first.Code)
+		assert.Equal(t, http.StatusAccepted, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	})
+
+	// serve performs the request with the given Idempotency-Key header
+	serve := func(req *http.Request, key string) *httptest.ResponseRecorder {
+		req.Header.Set(IdempotencyKeyHeader, key)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("retried bulk import gets the original report", func(t *testing.T) {
+		pc := consentRuleForQuery()
+		pc.Subject = "bulk"
+		body := ndjsonImport(t, pc)
+		request := func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/consent/bulk", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, "application/x-ndjson")
+			return req
+		}
+
+		first := serve(request(), "bulk")
+		retry := serve(request(), "bulk")
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.JSONEq(t, first.Body.String(), retry.Body.String())
+		assert.Contains(t, retry.Body.String(), `"recorded":1`)
+	})
+
+	t.Run("retried FHIR create gets the stored resource", func(t *testing.T) {
+		pc := consentRuleForQuery()
+		pc.Subject = "fhir"
+		data, _ := json.Marshal(fhir.FromConsentRecord(pc, pc.Records[0], true, time.Now()))
+		request := func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/fhir/Consent", bytes.NewReader(data))
+			req.Header.Set(echo.HeaderContentType, fhir.ContentType)
+			return req
+		}
+
+		first := serve(request(), "fhir")
+		retry := serve(request(), "fhir")
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, "Consent/"+pc.Records[0].Hash, retry.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("retried erasure gets the original certificate", func(t *testing.T) {
+		request := func() *http.Request {
+			req := httptest.NewRequest(http.MethodDelete, "/subject/"+url.PathEscape(string(consent.Subject)), nil)
+			req.TLS = &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "gateway"}}}},
+			}
+			return req
+		}
+
+		first := serve(request(), "erase")
+		retry := serve(request(), "erase")
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	})
+
+	t.Run("key is too long", func(t *testing.T) {
+		rec := send(http.MethodPost, "/consent", strings.Repeat("k", maxIdempotencyKeyLength+1), testConsent())
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHttpClient_IdempotencyKey(t *testing.T) {
+	var keys []string
+	client := newTestClient(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+		return &http.Response{StatusCode: http.StatusCreated, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+	})
+
+	ctx := WithIdempotencyKey(context.Background(), "key")
+	assert.NoError(t, client.RecordConsent(ctx, []pkg.PatientConsent{{}}))
+	assert.NoError(t, client.RecordConsent(ctx, []pkg.PatientConsent{{}, {}}))
+	assert.NoError(t, client.RecordConsent(context.Background(), []pkg.PatientConsent{{}}))
+	_, err := client.DeleteConsentRecordByHash(ctx, "hash", "", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"key", "key", "", "key"}, keys)
+}
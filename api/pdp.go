@@ -0,0 +1,48 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// Decide answers an attribute based authorization request as policy decision point.
+// The decision is always returned with a 200, an invalid body results in a 400.
+func (w *Wrapper) Decide(ctx echo.Context) error {
+	if err := w.limit(ctx, pkg.OperationCheck); err != nil {
+		return err
+	}
+
+	buf, err := readBody(ctx)
+	if err != nil {
+		return err
+	}
+
+	var request pkg.AuthorizationRequest
+	if err := json.Unmarshal(buf, &request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid authorization request: %v", err))
+	}
+
+	return ctx.JSON(http.StatusOK, w.Cs.Decide(ctx.Request().Context(), request))
+}
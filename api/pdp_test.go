@@ -0,0 +1,85 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_Decide(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+	crq := consentRuleForQuery()
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+
+	newContext := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("Permit", func(t *testing.T) {
+		ctx, rec := newContext(`{
+			"subject": {"id": "actor"},
+			"resource": {"patient": "subject", "custodian": "custodian", "dataClass": "resource"},
+			"action": {"id": "read"}
+		}`)
+
+		err := client.Decide(ctx)
+
+		if assert.NoError(t, err) {
+			var response pkg.AuthorizationResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, pkg.DecisionPermit, response.Decision)
+			assert.Equal(t, pkg.ObligationAudit, response.Obligations[0].ID)
+		}
+	})
+
+	t.Run("Indeterminate is a 200", func(t *testing.T) {
+		ctx, rec := newContext(`{"subject": {}, "resource": {}, "action": {"id": "read"}}`)
+
+		err := client.Decide(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response pkg.AuthorizationResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, pkg.DecisionIndeterminate, response.Decision)
+		}
+	})
+
+	t.Run("400 on invalid body", func(t *testing.T) {
+		ctx, _ := newContext(`{"subject": "actor"}`)
+
+		err := client.Decide(ctx)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// ProblemContentType is the content type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// The stable codes of the problems returned by the api, they don't change between versions
+const (
+	ProblemCodeBadRequest           = "bad-request"
+	ProblemCodeUnauthenticated      = "unauthenticated"
+	ProblemCodeNotFound             = "not-found"
+	ProblemCodeUnknownPreviousHash  = "unknown-previous-hash"
+	ProblemCodeNotLatest            = "not-latest"
+	ProblemCodeInvalidValidTo       = "invalid-valid-to"
+	ProblemCodeMissingHash          = "missing-hash"
+	ProblemCodeUnsupportedMediaType = "unsupported-media-type"
+	ProblemCodeConflict             = "conflict"
+	ProblemCodeIdempotencyKeyReused = "idempotency-key-reused"
+	ProblemCodeIdempotencyKeyInUse  = "idempotency-key-in-use"
+	ProblemCodeRateLimited          = "rate-limited"
+	ProblemCodeUnavailable          = "unavailable"
+	ProblemCodeInternal             = "internal"
+)
+
+// problemErrors holds the sentinel error of every code that has one, with the status used when the handler didn't set one.
+// The HttpClient maps the codes back to these errors.
+var problemErrors = []struct {
+	code   string
+	status int
+	err    error
+}{
+	// before not-found, since it unwraps to pkg.ErrorNotFound
+	{ProblemCodeUnknownPreviousHash, http.StatusUnprocessableEntity, pkg.ErrorUnknownPreviousHash},
+	{ProblemCodeNotFound, http.StatusNotFound, pkg.ErrorNotFound},
+	{ProblemCodeNotLatest, http.StatusNotFound, pkg.ErrorConsentRecordNotLatest},
+	{ProblemCodeInvalidValidTo, http.StatusBadRequest, pkg.ErrorInvalidValidTo},
+	{ProblemCodeMissingHash, http.StatusBadRequest, ErrorMissingHash},
+	{ProblemCodeConflict, http.StatusConflict, pkg.ErrorConflict},
+	{ProblemCodeIdempotencyKeyReused, http.StatusUnprocessableEntity, pkg.ErrorIdempotencyKeyReused},
+	{ProblemCodeIdempotencyKeyInUse, http.StatusConflict, pkg.ErrorIdempotencyKeyInUse},
+	{ProblemCodeRateLimited, http.StatusTooManyRequests, pkg.ErrorRateLimited},
+}
+
+// statusCodes holds the code of the problems that aren't caused by a sentinel error
+var statusCodes = map[int]string{
+	http.StatusBadRequest:           ProblemCodeBadRequest,
+	http.StatusUnauthorized:         ProblemCodeUnauthenticated,
+	http.StatusNotFound:             ProblemCodeNotFound,
+	http.StatusUnsupportedMediaType: ProblemCodeUnsupportedMediaType,
+	http.StatusConflict:             ProblemCodeConflict,
+	http.StatusTooManyRequests:      ProblemCodeRateLimited,
+	http.StatusServiceUnavailable:   ProblemCodeUnavailable,
+}
+
+// NewProblem converts an error returned by a handler to problem details. The status of an echo.HTTPError is kept,
+// other errors are a 500 unless they're one of the sentinel errors. A conflict holds the current head of the chain.
+func NewProblem(err error) Problem {
+	status := 0
+	detail := err.Error()
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		status = he.Code
+		detail = fmt.Sprint(he.Message)
+		if e, ok := he.Message.(error); ok {
+			err = e
+		} else if he.Internal != nil {
+			err = he.Internal
+		}
+	}
+
+	code := ""
+	for _, pe := range problemErrors {
+		if errors.Is(err, pe.err) {
+			code = pe.code
+			if status == 0 {
+				status = pe.status
+			}
+			break
+		}
+	}
+
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	if code == "" {
+		code = statusCodes[status]
+	}
+	if code == "" {
+		code = ProblemCodeInternal
+		if status < http.StatusInternalServerError {
+			code = ProblemCodeBadRequest
+		}
+	}
+
+	problem := Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: &detail,
+		Code:   code,
+	}
+
+	var conflict *pkg.ConflictError
+	if errors.As(err, &conflict) {
+		head := FromConsentRecord(conflict.Head)
+		problem.Head = &head
+	}
+
+	return problem
+}
+
+// ProblemRouter wraps the router so the errors returned by the handlers are written as problem details
+func ProblemRouter(router EchoRouter) EchoRouter {
+	return problemRouter{router}
+}
+
+type problemRouter struct {
+	EchoRouter
+}
+
+func (r problemRouter) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.GET(path, problemHandler(h), m...)
+}
+
+func (r problemRouter) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.POST(path, problemHandler(h), m...)
+}
+
+func (r problemRouter) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.PUT(path, problemHandler(h), m...)
+}
+
+func (r problemRouter) DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.DELETE(path, problemHandler(h), m...)
+}
+
+// problemHandler writes the error of the handler as problem details, unless the handler already started the response
+func problemHandler(h echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		err := h(ctx)
+		if err == nil || ctx.Response().Committed {
+			return err
+		}
+
+		problem := NewProblem(err)
+		body, mErr := json.Marshal(problem)
+		if mErr != nil {
+			return err
+		}
+		if problem.Head != nil {
+			ctx.Response().Header().Set("ETag", recordETag(problem.Head.RecordHash))
+		}
+		return ctx.Blob(problem.Status, ProblemContentType, body)
+	}
+}
+
+// ProblemError is returned by the HttpClient when the server responds with problem details.
+// It unwraps to the sentinel error of its code, so errors.Is(err, pkg.ErrorNotFound) works like it does for the local store.
+type ProblemError struct {
+	Problem Problem
+}
+
+func (e *ProblemError) Error() string {
+	detail := e.Problem.Title
+	if e.Problem.Detail != nil {
+		detail = *e.Problem.Detail
+	}
+	return fmt.Sprintf("consent store returned %d, reason: %s", e.Problem.Status, detail)
+}
+
+// Unwrap returns the sentinel error of the code, nil when the code has none. A conflict with a head is returned as pkg.ConflictError.
+func (e *ProblemError) Unwrap() error {
+	if e.Problem.Code == ProblemCodeConflict && e.Problem.Head != nil {
+		if head, err := e.Problem.Head.ToConsentRecord(); err == nil {
+			return &pkg.ConflictError{Head: head}
+		}
+	}
+
+	for _, pe := range problemErrors {
+		if pe.code == e.Problem.Code {
+			return pe.err
+		}
+	}
+	return nil
+}
+
+// readProblem returns the problem details of the response, false when the body doesn't hold any
+func readProblem(header http.Header, body []byte) (Problem, bool) {
+	var p Problem
+	contentType := strings.TrimSpace(strings.Split(header.Get(echo.HeaderContentType), ";")[0])
+	if contentType != ProblemContentType || json.Unmarshal(body, &p) != nil || p.Code == "" {
+		return p, false
+	}
+	return p, true
+}
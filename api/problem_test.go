@@ -0,0 +1,172 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProblem(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{"sentinel error", pkg.ErrorNotFound, http.StatusNotFound, ProblemCodeNotFound, "record not found"},
+		{"wrapped sentinel error", fmt.Errorf("recording: %w", pkg.ErrorInvalidValidTo), http.StatusBadRequest, ProblemCodeInvalidValidTo, "recording: " + pkg.ErrorInvalidValidTo.Error()},
+		{"HTTPError with sentinel error", echo.NewHTTPError(http.StatusNotFound, pkg.ErrorConsentRecordNotLatest), http.StatusNotFound, ProblemCodeNotLatest, pkg.ErrorConsentRecordNotLatest.Error()},
+		{"HTTPError with message", echo.NewHTTPError(http.StatusBadRequest, "missing actor"), http.StatusBadRequest, ProblemCodeBadRequest, "missing actor"},
+		{"HTTPError without code for its status", echo.NewHTTPError(http.StatusUnprocessableEntity, "unprocessable"), http.StatusUnprocessableEntity, ProblemCodeBadRequest, "unprocessable"},
+		{"conflict", &pkg.ConflictError{Head: pkg.ConsentRecord{Hash: "head", Version: 2}}, http.StatusConflict, ProblemCodeConflict, "consent record chain has been changed, current head is head (version 2)"},
+		{"rate limit", echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded for check operations"), http.StatusTooManyRequests, ProblemCodeRateLimited, "rate limit exceeded for check operations"},
+		{"unknown previous hash", pkg.ErrorUnknownPreviousHash, http.StatusUnprocessableEntity, ProblemCodeUnknownPreviousHash, pkg.ErrorUnknownPreviousHash.Error()},
+		{"other error", errors.New("b00m!"), http.StatusInternalServerError, ProblemCodeInternal, "b00m!"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProblem(tc.err)
+
+			assert.Equal(t, tc.status, p.Status)
+			assert.Equal(t, http.StatusText(tc.status), p.Title)
+			assert.Equal(t, tc.code, p.Code)
+			if assert.NotNil(t, p.Detail) {
+				assert.Equal(t, tc.detail, *p.Detail)
+			}
+		})
+	}
+}
+
+func TestProblemRouter(t *testing.T) {
+	e := echo.New()
+	router := ProblemRouter(e)
+	router.GET("/error", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, pkg.ErrorNotFound)
+	})
+	router.PUT("/conflict", func(ctx echo.Context) error {
+		return &pkg.ConflictError{Head: pkg.ConsentRecord{Hash: "head", Version: 2}}
+	})
+	router.POST("/ok", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusNoContent)
+	})
+	router.DELETE("/committed", func(ctx echo.Context) error {
+		ctx.NoContent(http.StatusAccepted)
+		return errors.New("after response")
+	})
+
+	t.Run("error is written as problem details", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/error", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, ProblemContentType, rec.Header().Get(echo.HeaderContentType))
+		var p Problem
+		if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p)) {
+			assert.Equal(t, ProblemCodeNotFound, p.Code)
+			assert.Equal(t, http.StatusNotFound, p.Status)
+		}
+	})
+
+	t.Run("conflict holds the head and its ETag", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/conflict", nil))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, `"head"`, rec.Header().Get("ETag"))
+		var p Problem
+		if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p)) && assert.NotNil(t, p.Head) {
+			assert.Equal(t, "head", p.Head.RecordHash)
+		}
+	})
+
+	t.Run("response without error is untouched", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ok", nil))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("error after the response started is not written", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/committed", nil))
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
+
+func TestProblemError(t *testing.T) {
+	t.Run("unwraps to the sentinel error of the code", func(t *testing.T) {
+		err := &ProblemError{Problem: NewProblem(pkg.ErrorInvalidValidTo)}
+
+		assert.True(t, errors.Is(err, pkg.ErrorInvalidValidTo))
+		assert.EqualError(t, err, "consent store returned 400, reason: "+pkg.ErrorInvalidValidTo.Error())
+	})
+
+	t.Run("conflict unwraps to the head", func(t *testing.T) {
+		err := &ProblemError{Problem: NewProblem(&pkg.ConflictError{Head: pkg.ConsentRecord{Hash: "head", Version: 2, ValidFrom: time.Now()}})}
+
+		var conflict *pkg.ConflictError
+		assert.True(t, errors.Is(err, pkg.ErrorConflict))
+		if assert.True(t, errors.As(err, &conflict)) {
+			assert.Equal(t, "head", conflict.Head.Hash)
+			assert.Equal(t, uint(2), conflict.Head.Version)
+		}
+	})
+
+	t.Run("code without sentinel error", func(t *testing.T) {
+		err := &ProblemError{Problem: Problem{Status: 503, Title: "Service Unavailable", Code: ProblemCodeUnavailable}}
+
+		assert.Nil(t, err.Unwrap())
+		assert.EqualError(t, err, "consent store returned 503, reason: Service Unavailable")
+	})
+}
+
+func TestReadProblem(t *testing.T) {
+	body, _ := json.Marshal(NewProblem(pkg.ErrorNotFound))
+
+	t.Run("problem details", func(t *testing.T) {
+		p, ok := readProblem(http.Header{"Content-Type": []string{ProblemContentType + "; charset=UTF-8"}}, body)
+
+		assert.True(t, ok)
+		assert.Equal(t, ProblemCodeNotFound, p.Code)
+	})
+
+	t.Run("other content type", func(t *testing.T) {
+		_, ok := readProblem(http.Header{"Content-Type": []string{"text/plain"}}, body)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, ok := readProblem(http.Header{"Content-Type": []string{ProblemContentType}}, []byte("not found"))
+
+		assert.False(t, ok)
+	})
+}
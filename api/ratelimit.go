@@ -0,0 +1,70 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// callerIdentity returns the common name of the verified client certificate or the host of the remote address when no client certificate is used.
+// Forwarding headers like X-Forwarded-For are ignored, since the caller can send a different value with every request to evade its limits.
+func callerIdentity(ctx echo.Context) string {
+	if cn := authenticatedCaller(ctx); cn != "" {
+		return cn
+	}
+
+	addr := ctx.Request().RemoteAddr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// authenticatedCaller returns the common name of the verified client certificate, empty when the caller is not authenticated
+func authenticatedCaller(ctx echo.Context) string {
+	req := ctx.Request()
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 && len(req.TLS.VerifiedChains[0]) > 0 {
+		return req.TLS.VerifiedChains[0][0].Subject.CommonName
+	}
+
+	return ""
+}
+
+// limit counts the request against the budget of the caller for the given operation.
+// It returns a 429 error with a Retry-After header when the budget is exhausted.
+func (w *Wrapper) limit(ctx echo.Context, op pkg.Operation) error {
+	if w.Cs.RateLimiter == nil || !w.Cs.RateLimiter.Enabled() {
+		return nil
+	}
+
+	caller := callerIdentity(ctx)
+	if ok, retryAfter := w.Cs.RateLimiter.Allow(caller, op); !ok {
+		ctx.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return echo.NewHTTPError(http.StatusTooManyRequests, fmt.Sprintf("rate limit exceeded for %s operations", op))
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_limit(t *testing.T) {
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/consent/check", strings.NewReader("{}"))
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("no limiter allows request", func(t *testing.T) {
+		w := Wrapper{Cs: &pkg.ConsentStore{}}
+		ctx, _ := newContext()
+
+		assert.NoError(t, w.limit(ctx, pkg.OperationCheck))
+	})
+
+	t.Run("exceeded budget returns 429 with Retry-After", func(t *testing.T) {
+		w := Wrapper{Cs: &pkg.ConsentStore{RateLimiter: pkg.NewRateLimiter(pkg.RateLimit{Check: 1}, nil)}}
+
+		ctx, _ := newContext()
+		assert.NoError(t, w.limit(ctx, pkg.OperationCheck))
+
+		ctx, rec := newContext()
+		err := w.limit(ctx, pkg.OperationCheck)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusTooManyRequests, err.(*echo.HTTPError).Code)
+			assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+		}
+	})
+
+	t.Run("CheckConsent is limited", func(t *testing.T) {
+		w := Wrapper{Cs: &pkg.ConsentStore{RateLimiter: pkg.NewRateLimiter(pkg.RateLimit{Check: 1}, nil)}}
+		w.Cs.RateLimiter.Allow("10.0.0.1", pkg.OperationCheck)
+		ctx, _ := newContext()
+
+		err := w.CheckConsent(ctx)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusTooManyRequests, err.(*echo.HTTPError).Code)
+		}
+	})
+}
+
+func TestCallerIdentity(t *testing.T) {
+	t.Run("uses remote IP without client certificate", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+		assert.Equal(t, "10.0.0.1", callerIdentity(ctx))
+		assert.Equal(t, "", authenticatedCaller(ctx))
+	})
+
+	t.Run("ignores forwarding headers", func(t *testing.T) {
+		w := Wrapper{Cs: &pkg.ConsentStore{RateLimiter: pkg.NewRateLimiter(pkg.RateLimit{Check: 1}, nil)}}
+		newContext := func(forwardedFor string) echo.Context {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+			req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+			return echo.New().NewContext(req, httptest.NewRecorder())
+		}
+
+		assert.NoError(t, w.limit(newContext("192.168.0.1"), pkg.OperationCheck))
+		ctx := newContext("192.168.0.2")
+		err := w.limit(ctx, pkg.OperationCheck)
+
+		assert.Equal(t, "10.0.0.1", callerIdentity(ctx))
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusTooManyRequests, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("uses common name of verified client certificate", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "gateway"}}}},
+		}
+		ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+		assert.Equal(t, "gateway", callerIdentity(ctx))
+	})
+}
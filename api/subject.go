@@ -0,0 +1,93 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// ExportSubject returns a signed document with all consent data held about the subject.
+// It's only available for callers authenticated with a client certificate, the caller is recorded in the audit trail.
+func (w *Wrapper) ExportSubject(ctx echo.Context, subject Identifier) error {
+	caller := authenticatedCaller(ctx)
+	if caller == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "client certificate required")
+	}
+
+	if err := w.limit(ctx, pkg.OperationQuery); err != nil {
+		return err
+	}
+
+	export, err := w.Cs.ExportSubject(ctx.Request().Context(), string(subject), caller)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	signed, err := w.Cs.Config.SignDocument(export)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return ctx.JSON(http.StatusOK, signed)
+}
+
+// EraseSubject removes all consent data of the subject and returns the erasure certificate.
+// It's only available for callers authenticated with a client certificate. When erasedBy is not given, the caller is recorded in the audit trail.
+func (w *Wrapper) EraseSubject(ctx echo.Context, subject Identifier, params EraseSubjectParams) error {
+	caller := authenticatedCaller(ctx)
+	if caller == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "client certificate required")
+	}
+
+	if err := w.limit(ctx, pkg.OperationWrite); err != nil {
+		return err
+	}
+
+	erasedBy := caller
+	if params.ErasedBy != nil && *params.ErasedBy != "" {
+		erasedBy = *params.ErasedBy
+	}
+
+	ir, err := newIdempotentRequest(ctx, "eraseSubject", []byte(subject), []byte(erasedBy))
+	if err != nil {
+		return err
+	}
+	replayed, err := w.replayWith(ctx, ir, func(response pkg.IdempotentResponse) error {
+		var certificate pkg.ErasureCertificate
+		if err := json.Unmarshal([]byte(response.Body), &certificate); err != nil {
+			return err
+		}
+		return ctx.JSON(response.Status, FromErasureCertificate(certificate))
+	})
+	if replayed || err != nil {
+		return err
+	}
+
+	certificate, err := w.Cs.EraseSubject(ir.context(ctx, http.StatusOK), string(subject), erasedBy)
+	if err != nil {
+		// a 500 unless it's an error of the idempotency key
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, FromErasureCertificate(certificate))
+}
@@ -0,0 +1,176 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_ExportSubject(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+	crq := consentRuleForQuery()
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+
+	dir, err := ioutil.TempDir("", "consent-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	client.Cs.Config.TlsCertFile, client.Cs.Config.TlsKeyFile = writeSigningKey(t, dir)
+
+	newContext := func(caller string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if caller != "" {
+			req.TLS = &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: caller}}}},
+			}
+		}
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("unauthenticated caller returns 401", func(t *testing.T) {
+		ctx, _ := newContext("")
+
+		err := client.ExportSubject(ctx, Identifier(crq.Subject))
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("returns signed export", func(t *testing.T) {
+		ctx, rec := newContext("dpo")
+
+		err := client.ExportSubject(ctx, Identifier(crq.Subject))
+
+		if assert.NoError(t, err) {
+			var signed pkg.SignedDocument
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &signed))
+			assert.NoError(t, signed.Verify())
+
+			var export pkg.SubjectExport
+			assert.NoError(t, json.Unmarshal(signed.Document, &export))
+			assert.Len(t, export.Consents, 1)
+		}
+
+		entries, _ := client.Cs.AuditEntries(context.Background(), crq.Subject)
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "dpo", entries[0].Actor)
+		}
+	})
+}
+
+func TestWrapper_EraseSubject(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Cs.Shutdown()
+	crq := consentRuleForQuery()
+	client.Cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq})
+
+	newContext := func(caller string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		if caller != "" {
+			req.TLS = &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: caller}}}},
+			}
+		}
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("unauthenticated caller returns 401", func(t *testing.T) {
+		ctx, _ := newContext("")
+
+		err := client.EraseSubject(ctx, Identifier(crq.Subject), EraseSubjectParams{})
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("returns erasure certificate", func(t *testing.T) {
+		ctx, rec := newContext("gateway")
+		erasedBy := "dpo"
+
+		err := client.EraseSubject(ctx, Identifier(crq.Subject), EraseSubjectParams{ErasedBy: &erasedBy})
+
+		if assert.NoError(t, err) {
+			var certificate ErasureCertificate
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &certificate))
+			assert.Equal(t, []string{crq.Records[0].Hash}, certificate.RecordHashes)
+			assert.Equal(t, "dpo", certificate.ErasedBy)
+		}
+	})
+}
+
+// writeSigningKey writes a self-signed certificate and its key to the given directory
+func writeSigningKey(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "consent-store"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
@@ -0,0 +1,109 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceContext propagates the trace between the HttpClient and the server using the W3C traceparent and tracestate headers
+var traceContext = propagation.TraceContext{}
+
+// TracingRouter wraps the router so every request is traced, continuing the trace of the caller when the request holds a traceparent header
+func TracingRouter(router EchoRouter) EchoRouter {
+	return tracingRouter{router}
+}
+
+type tracingRouter struct {
+	EchoRouter
+}
+
+func (r tracingRouter) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.GET(path, h, append([]echo.MiddlewareFunc{traceRequest}, m...)...)
+}
+
+func (r tracingRouter) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.POST(path, h, append([]echo.MiddlewareFunc{traceRequest}, m...)...)
+}
+
+func (r tracingRouter) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.PUT(path, h, append([]echo.MiddlewareFunc{traceRequest}, m...)...)
+}
+
+func (r tracingRouter) DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.EchoRouter.DELETE(path, h, append([]echo.MiddlewareFunc{traceRequest}, m...)...)
+}
+
+// traceRequest starts the server span of the request, the handler gets the span through the context of the request.
+// The span is named after the route rather than the path, since the path holds patient identifiers.
+func traceRequest(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		req := ctx.Request()
+		parent := traceContext.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		spanCtx, span := pkg.Tracer().Start(parent, req.Method+" "+ctx.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPMethodKey.String(req.Method), semconv.HTTPRouteKey.String(ctx.Path())),
+		)
+		defer span.End()
+		ctx.SetRequest(req.WithContext(spanCtx))
+
+		err := next(ctx)
+
+		status := ctx.Response().Status
+		if err != nil {
+			span.RecordError(err)
+			status = http.StatusInternalServerError
+			if he, ok := err.(*echo.HTTPError); ok {
+				status = he.Code
+			}
+		}
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+
+		return err
+	}
+}
+
+// startSpan starts the span of an HttpClient call, the returned func ends it and is meant to be deferred with the error of the call
+func startSpan(ctx context.Context, method string) (context.Context, func(*error)) {
+	ctx, span := pkg.Tracer().Start(ctx, "HttpClient."+method, trace.WithSpanKind(trace.SpanKindClient))
+
+	return ctx, func(err *error) {
+		if *err != nil {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+		span.End()
+	}
+}
+
+// injectTraceContext adds the traceparent header of the span in the context to the request
+func injectTraceContext(ctx context.Context, req *http.Request) error {
+	traceContext.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	w := defaultConsentStore()
+	defer w.Cs.Shutdown()
+	e := echo.New()
+	RegisterHandlers(TracingRouter(ProblemRouter(e)), &w)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	client := HttpClient{
+		ServerAddress: strings.TrimPrefix(server.URL, "http://"),
+		Timeout:       time.Second,
+		Logger:        logrus.StandardLogger().WithField("component", "API-client"),
+	}
+
+	ctx, root := pkg.Tracer().Start(context.Background(), "test")
+	consent, _ := testConsent().ToPatientConsent()
+	if err := client.RecordConsent(ctx, []pkg.PatientConsent{consent}); err != nil {
+		t.Fatal(err)
+	}
+	client.FindConsentRecordByHash(ctx, "unknown", false)
+	root.End()
+
+	spans := recorder.Ended()
+	// span returns the first span with the given name and parent
+	span := func(name string, parent sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+		for _, s := range spans {
+			if s.Name() == name && s.Parent().SpanID() == parent.SpanContext().SpanID() {
+				return s
+			}
+		}
+		t.Fatalf("no span %s with parent %s", name, parent.Name())
+		return nil
+	}
+
+	t.Run("trace continues from the client to the database", func(t *testing.T) {
+		clientSpan := span("HttpClient.RecordConsent", root.(sdktrace.ReadOnlySpan))
+		serverSpan := span("POST /consent", clientSpan)
+		operation := span("ConsentStore.RecordConsent", serverSpan)
+		span("INSERT consent_record", operation)
+
+		assert.Equal(t, root.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+		assert.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusCreated))
+	})
+
+	t.Run("failed call", func(t *testing.T) {
+		clientSpan := span("HttpClient.FindConsentRecordByHash", root.(sdktrace.ReadOnlySpan))
+		serverSpan := span("GET /consent/:consentRecordHash", clientSpan)
+
+		assert.Equal(t, codes.Error, clientSpan.Status().Code)
+		assert.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusNotFound))
+		// a client error isn't an error of the server
+		assert.Equal(t, codes.Unset, serverSpan.Status().Code)
+	})
+}
+
+func TestHttpClient_traceContext(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	var requests []*http.Request
+	client := newTestClient(func(req *http.Request) *http.Response {
+		requests = append(requests, req)
+		return &http.Response{StatusCode: http.StatusCreated, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+	})
+
+	ctx, span := pkg.Tracer().Start(context.Background(), "test")
+	defer span.End()
+	client.RecordConsent(WithIdempotencyKey(ctx, "key"), []pkg.PatientConsent{{}})
+	client.RecordConsent(context.Background(), []pkg.PatientConsent{{}})
+
+	if assert.Len(t, requests, 2) {
+		traceparent := requests[0].Header.Get("traceparent")
+		assert.True(t, strings.Contains(traceparent, span.SpanContext().TraceID().String()), traceparent)
+		assert.Equal(t, "key", requests[0].Header.Get(IdempotencyKeyHeader))
+
+		// without a span in the context, the client starts a new trace
+		assert.NotEmpty(t, requests[1].Header.Get("traceparent"))
+		assert.False(t, strings.Contains(requests[1].Header.Get("traceparent"), span.SpanContext().TraceID().String()))
+	}
+}
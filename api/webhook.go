@@ -0,0 +1,81 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// ListWebhookDeliveries lists the webhook deliveries, optionally filtered by status.
+// It's only available for callers authenticated with a client certificate.
+func (w *Wrapper) ListWebhookDeliveries(ctx echo.Context, params ListWebhookDeliveriesParams) error {
+	if authenticatedCaller(ctx) == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "client certificate required")
+	}
+
+	var status pkg.WebhookDeliveryStatus
+	if params.Status != nil {
+		status = pkg.WebhookDeliveryStatus(*params.Status)
+	}
+
+	deliveries, err := w.Cs.WebhookDeliveries(ctx.Request().Context(), status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	result := []WebhookDelivery{}
+	for _, d := range deliveries {
+		wd, err := FromWebhookDelivery(d)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err)
+		}
+		result = append(result, wd)
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// ReplayWebhookDelivery resets the webhook delivery, so it's attempted again.
+// It's only available for callers authenticated with a client certificate.
+func (w *Wrapper) ReplayWebhookDelivery(ctx echo.Context, id int) error {
+	caller := authenticatedCaller(ctx)
+	if caller == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "client certificate required")
+	}
+
+	delivery, err := w.Cs.ReplayWebhookDelivery(ctx.Request().Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, pkg.ErrorNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	pkg.Logger().Infof("webhook delivery %d replayed by %s", id, caller)
+
+	wd, err := FromWebhookDelivery(delivery)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return ctx.JSON(http.StatusOK, wd)
+}
@@ -0,0 +1,141 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	core "github.com/nuts-foundation/nuts-go-core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapper_WebhookDeliveries(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	cs := pkg.ConsentStore{
+		Config: pkg.ConsentStoreConfig{
+			Connectionstring:   ":memory:",
+			Mode:               core.ServerEngineMode,
+			Webhooks:           "url=" + receiver.URL + ";secret=secret",
+			WebhookMaxAttempts: 1,
+		},
+	}
+	if err := cs.Configure(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Shutdown()
+	client := Wrapper{Cs: &cs}
+
+	crq := consentRuleForQuery()
+	if err := cs.RecordConsent(context.Background(), []pkg.PatientConsent{crq}); err != nil {
+		t.Fatal(err)
+	}
+
+	newContext := func(method string, caller string, query string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, "/"+query, nil)
+		if caller != "" {
+			req.TLS = &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: caller}}}},
+			}
+		}
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	// a single failed attempt makes the delivery dead
+	var dead []pkg.WebhookDelivery
+	assert.Eventually(t, func() bool {
+		dead, _ = cs.WebhookDeliveries(context.Background(), pkg.WebhookDeliveryDead)
+		return len(dead) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("unauthenticated caller returns 401", func(t *testing.T) {
+		ctx, _ := newContext(http.MethodGet, "", "")
+
+		err := client.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{})
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		}
+
+		ctx, _ = newContext(http.MethodPost, "", "")
+		err = client.ReplayWebhookDelivery(ctx, 1)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		}
+	})
+
+	t.Run("lists dead deliveries with their event", func(t *testing.T) {
+		ctx, rec := newContext(http.MethodGet, "admin", "?status=dead")
+		status := WebhookDeliveryStatus_dead
+
+		err := client.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{Status: &status})
+
+		if assert.NoError(t, err) {
+			var deliveries []WebhookDelivery
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &deliveries))
+			if assert.Len(t, deliveries, 1) {
+				assert.Equal(t, receiver.URL, deliveries[0].Url)
+				assert.Equal(t, WebhookDeliveryStatus_dead, deliveries[0].Status)
+				assert.Equal(t, crq.Records[0].Hash, deliveries[0].Event.RecordHash)
+				assert.Contains(t, *deliveries[0].LastError, "503")
+			}
+		}
+	})
+
+	t.Run("replays a delivery", func(t *testing.T) {
+		ctx, rec := newContext(http.MethodPost, "admin", "")
+
+		err := client.ReplayWebhookDelivery(ctx, int(dead[0].ID))
+
+		if assert.NoError(t, err) {
+			var delivery WebhookDelivery
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &delivery))
+			assert.Equal(t, WebhookDeliveryStatus_pending, delivery.Status)
+			assert.Equal(t, 0, delivery.Attempts)
+		}
+	})
+
+	t.Run("replaying an unknown delivery returns 404", func(t *testing.T) {
+		ctx, _ := newContext(http.MethodPost, "admin", "")
+
+		err := client.ReplayWebhookDelivery(ctx, 999)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+		}
+	})
+}
@@ -19,14 +19,18 @@
 package client
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/nuts-foundation/nuts-consent-store/api"
 	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/nuts-foundation/nuts-consent-store/rpc"
 	core "github.com/nuts-foundation/nuts-go-core"
 	"github.com/sirupsen/logrus"
-	"time"
 )
 
-// NewConsentStoreClient creates a new Local- or RemoteClient for the nuts consent-store
+// NewConsentStoreClient creates a new Local- or RemoteClient for the nuts consent-store.
+// A RemoteClient implements io.Closer, it must be closed to release its connection to the server.
 func NewConsentStoreClient() pkg.ConsentStoreClient {
 	consentStore := pkg.ConsentStoreInstance()
 
@@ -37,13 +41,61 @@ func NewConsentStoreClient() pkg.ConsentStoreClient {
 
 		return consentStore
 	} else {
-		return api.HttpClient{
+		tlsConfig, err := consentStore.Config.ClientTLSConfig()
+		if err != nil {
+			logrus.Panic(err)
+		}
+
+		timeout, err := consentStore.Config.ClientTimeoutPeriod()
+		if err != nil {
+			logrus.Panic(err)
+		}
+
+		logger := logrus.WithFields(logrus.Fields{
+			"engine":    "consent-store",
+			"component": "API-client",
+		})
+
+		if strings.HasPrefix(consentStore.Config.Address, rpc.AddressScheme) {
+			gc, err := rpc.NewGrpcClient(consentStore.Config.Address, tlsConfig, timeout, logger)
+			if err != nil {
+				logrus.Panic(err)
+			}
+			return gc
+		}
+
+		retry, err := consentStore.Config.ClientRetryPolicy()
+		if err != nil {
+			logrus.Panic(err)
+		}
+
+		// the breaker is shared with the engine, so its state is part of the diagnostics
+		breaker, err := consentStore.Config.ClientCircuitBreaker()
+		if err != nil {
+			logrus.Panic(err)
+		}
+		consentStore.Breaker = breaker
+
+		var transport http.RoundTripper
+		if tlsConfig != nil {
+			// shared by all calls, so connections and TLS sessions are reused
+			transport = api.NewTransport(tlsConfig)
+		}
+
+		hc := api.HttpClient{
 			ServerAddress: consentStore.Config.Address,
-			Timeout:       time.Second,
-			Logger: logrus.WithFields(logrus.Fields{
-				"engine":    "consent-store",
-				"component": "API-client",
-			}),
+			Timeout:       timeout,
+			TLSConfig:     tlsConfig,
+			Transport:     transport,
+			Retry:         retry,
+			Breaker:       breaker,
+			Cache:         api.NewAuthCache(consentStore.Config.ClientCacheSize),
+			Logger:        logger,
 		}
+
+		// cached outcomes are removed as soon as the consent changes on the server, until the client is closed
+		hc.StartWatchingEvents()
+
+		return hc
 	}
 }
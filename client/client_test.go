@@ -19,11 +19,14 @@
 package client
 
 import (
+	"github.com/nuts-foundation/nuts-consent-store/api"
 	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/nuts-foundation/nuts-consent-store/rpc"
 	core "github.com/nuts-foundation/nuts-go-core"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewConsentStoreClient(t *testing.T) {
@@ -67,4 +70,62 @@ func TestNewConsentStoreClient(t *testing.T) {
 			t.Errorf("Expected Client to be of type %s, got %s", expected, reflect.TypeOf(cc))
 		}
 	})
+
+	t.Run("configures timeout, retries and circuit breaker in client mode", func(t *testing.T) {
+		i := pkg.ConsentStoreInstance()
+		i.Config.Mode = "client"
+		i.Config.ClientTimeout = "2s"
+		i.Config.ClientMaxAttempts = 4
+		defer func() {
+			i.Config.ClientTimeout = pkg.ConfigClientTimeoutDefault
+			i.Config.ClientMaxAttempts = pkg.ConfigClientMaxAttemptsDefault
+			i.ConfigOnce = sync.Once{}
+		}()
+
+		hc := NewConsentStoreClient().(api.HttpClient)
+
+		if hc.Timeout != 2*time.Second {
+			t.Errorf("Expected timeout of 2s, got %s", hc.Timeout)
+		}
+		if hc.Retry.MaxAttempts != 4 {
+			t.Errorf("Expected 4 attempts, got %d", hc.Retry.MaxAttempts)
+		}
+		if hc.Breaker == nil || hc.Breaker != i.Breaker {
+			t.Error("Expected circuit breaker to be shared with the engine")
+		}
+	})
+
+	t.Run("invalid client timeout panics", func(t *testing.T) {
+		i := pkg.ConsentStoreInstance()
+		i.Config.Mode = "client"
+		i.Config.ClientTimeout = "soon"
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected panic")
+			}
+			i.Config.ClientTimeout = pkg.ConfigClientTimeoutDefault
+			i.ConfigOnce = sync.Once{}
+		}()
+
+		NewConsentStoreClient()
+	})
+
+	t.Run("returns GrpcClient for a grpc address in client mode", func(t *testing.T) {
+		i := pkg.ConsentStoreInstance()
+		i.Config.Mode = "client"
+		i.Config.Address = "grpc://localhost:1324"
+		defer func() {
+			i.Config.Address = ""
+			i.ConfigOnce = sync.Once{}
+		}()
+
+		cc := NewConsentStoreClient()
+
+		expected := "*rpc.GrpcClient"
+		if reflect.TypeOf(cc).String() != expected {
+			t.Errorf("Expected Client to be of type %s, got %s", expected, reflect.TypeOf(cc))
+		}
+		cc.(*rpc.GrpcClient).Close()
+	})
 }
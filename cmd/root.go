@@ -19,19 +19,33 @@
 package cmd
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/nuts-foundation/nuts-consent-store/api"
 	"github.com/nuts-foundation/nuts-consent-store/engine"
 	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/nuts-foundation/nuts-consent-store/rpc"
 	cfg "github.com/nuts-foundation/nuts-go-core"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 )
 
 var e = engine.NewConsentStoreEngine()
 var rootCmd = e.Cmd
 
+// shutdownTimeout is the time in-flight requests get to complete when the server is stopped
+const shutdownTimeout = 10 * time.Second
+
 func Execute() {
 	c := cfg.NutsConfig()
 	c.IgnoredPrefixes = append(c.IgnoredPrefixes, e.ConfigKey)
@@ -54,13 +68,62 @@ func Execute() {
 		Use:   "server",
 		Short: "run the store as standalone web server",
 		Run: func(cmd *cobra.Command, args []string) {
+			cs := pkg.ConsentStoreInstance()
+
+			tlsConfig, err := cs.Config.ServerTLSConfig()
+			if err != nil {
+				logrus.Fatal(err)
+			}
 
 			// start webserver
 			e := echo.New()
 			e.HideBanner = true
+			// the server is reached directly, forwarding headers sent by the caller aren't trusted
+			e.IPExtractor = echo.ExtractIPDirect()
 			e.Use(middleware.Logger())
-			api.RegisterHandlers(e, &api.Wrapper{Cs: pkg.ConsentStoreInstance()})
-			logrus.Fatal(e.Start(":1323"))
+			api.RegisterHandlers(api.TracingRouter(api.ProblemRouter(e)), &api.Wrapper{Cs: cs})
+			e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+
+			// serve the gRPC api alongside, when configured
+			var grpcServer *grpc.Server
+			if cs.Config.GrpcListenAddress != "" {
+				lis, err := net.Listen("tcp", cs.Config.GrpcListenAddress)
+				if err != nil {
+					logrus.Fatal(err)
+				}
+				if grpcServer, err = rpc.Serve(cs, lis); err != nil {
+					logrus.Fatal(err)
+				}
+			}
+
+			go func() {
+				var err error
+				if tlsConfig != nil {
+					e.TLSServer.Addr = cs.Config.ListenAddress
+					e.TLSServer.TLSConfig = tlsConfig
+					err = e.StartServer(e.TLSServer)
+				} else {
+					err = e.Start(cs.Config.ListenAddress)
+				}
+				if err != nil && err != http.ErrServerClosed {
+					logrus.Fatal(err)
+				}
+			}()
+
+			// wait for a signal and drain in-flight requests
+			quit := make(chan os.Signal, 1)
+			signal.Notify(quit, syscall.SIGTERM, os.Interrupt)
+			<-quit
+
+			logrus.Info("Shutting down server")
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			if err := e.Shutdown(ctx); err != nil {
+				logrus.Errorf("Error shutting down server: %v", err)
+			}
+			if grpcServer != nil {
+				grpcServer.GracefulStop()
+			}
 		},
 	})
 
@@ -0,0 +1,308 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package conformance holds the scenarios every pkg.ConsentStoreClient implementation must pass,
+// so the local store and the remote clients behave the same.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+// Factory returns a client backed by an empty consent store, it's called for every scenario
+type Factory func(t *testing.T) pkg.ConsentStoreClient
+
+const day = 24 * time.Hour
+
+// Run runs all scenarios against the clients returned by the factory.
+// EraseSubject isn't covered, since the remote clients need a client certificate for it.
+func Run(t *testing.T, factory Factory) {
+	t.Run("validity window", func(t *testing.T) {
+		testValidity(t, factory(t))
+	})
+	t.Run("versioned chain", func(t *testing.T) {
+		testVersionedChain(t, factory(t))
+	})
+	t.Run("delete", func(t *testing.T) {
+		testDelete(t, factory(t))
+	})
+	t.Run("not found", func(t *testing.T) {
+		testNotFound(t, factory(t))
+	})
+	t.Run("record multiple consents", func(t *testing.T) {
+		testRecordConsent(t, factory(t))
+	})
+	t.Run("conflicting append", func(t *testing.T) {
+		testConflict(t, factory(t))
+	})
+}
+
+// now is truncated to seconds, since the remote clients transfer time in RFC3339
+func now() time.Time {
+	return time.Now().Truncate(time.Second)
+}
+
+// patientConsent returns a consent with a single record for the given actor
+func patientConsent(id string, actor string, hash string, validFrom time.Time, validTo *time.Time, dataClasses ...string) pkg.PatientConsent {
+	record := pkg.ConsentRecord{
+		Hash:      hash,
+		ValidFrom: validFrom,
+		ValidTo:   validTo,
+	}
+	for _, dc := range dataClasses {
+		record.DataClasses = append(record.DataClasses, pkg.DataClass{Code: dc})
+	}
+
+	return pkg.PatientConsent{
+		ID:        id,
+		Subject:   "subject",
+		Custodian: "custodian",
+		Actor:     actor,
+		Records:   []pkg.ConsentRecord{record},
+	}
+}
+
+func record(t *testing.T, client pkg.ConsentStoreClient, consent ...pkg.PatientConsent) {
+	if err := client.RecordConsent(context.Background(), consent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func auth(t *testing.T, client pkg.ConsentStoreClient, actor string, dataClass string, checkpoint *time.Time) bool {
+	granted, err := client.ConsentAuth(context.Background(), "custodian", "subject", actor, dataClass, checkpoint)
+	assert.NoError(t, err)
+	return granted
+}
+
+func testValidity(t *testing.T, client pkg.ConsentStoreClient) {
+	n := now()
+	validTo := n.Add(day)
+	record(t, client,
+		patientConsent("ended", "actor", "ended", n.Add(-day), &validTo, "observation"),
+		patientConsent("open", "open", "open", n.Add(-day), nil, "observation"),
+	)
+
+	before := n.Add(-2 * day)
+	after := n.Add(2 * day)
+	later := n.Add(365 * day)
+
+	assert.True(t, auth(t, client, "actor", "observation", nil), "granted now")
+	assert.True(t, auth(t, client, "actor", "observation", &n), "granted at checkpoint within the window")
+	assert.False(t, auth(t, client, "actor", "observation", &before), "not granted before validFrom")
+	assert.False(t, auth(t, client, "actor", "observation", &after), "not granted after validTo")
+	assert.False(t, auth(t, client, "actor", "medication", nil), "not granted for other data class")
+	assert.True(t, auth(t, client, "open", "observation", &later), "granted without validTo")
+
+	custodian := "custodian"
+	consent, err := client.QueryConsent(context.Background(), nil, &custodian, nil, &after, false)
+	if assert.NoError(t, err) && assert.Len(t, consent, 1) {
+		assert.Equal(t, "open", consent[0].Actor)
+	}
+}
+
+func testVersionedChain(t *testing.T, client pkg.ConsentStoreClient) {
+	n := now()
+	v1 := patientConsent("chain", "actor", "v1", n.Add(-day), nil, "observation")
+	record(t, client, v1)
+
+	v2 := patientConsent("chain", "actor", "v2", n.Add(-day), nil, "medication")
+	v2.Records[0].PreviousHash = &v1.Records[0].Hash
+	record(t, client, v2)
+
+	assert.True(t, auth(t, client, "actor", "medication", nil), "latest version is granted")
+	assert.False(t, auth(t, client, "actor", "observation", nil), "previous version is no longer granted")
+
+	r, err := client.FindConsentRecordByHash(context.Background(), "v1", false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(1), r.Version)
+	}
+
+	r, err = client.FindConsentRecordByHash(context.Background(), "v2", true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(2), r.Version)
+		if assert.NotNil(t, r.PreviousHash) {
+			assert.Equal(t, "v1", *r.PreviousHash)
+		}
+	}
+
+	_, err = client.FindConsentRecordByHash(context.Background(), "v1", true)
+	assert.True(t, errors.Is(err, pkg.ErrorConsentRecordNotLatest), "expected ErrorConsentRecordNotLatest, got %v", err)
+
+	actor := "actor"
+	consent, err := client.QueryConsent(context.Background(), &actor, nil, nil, nil, false)
+	if assert.NoError(t, err) && assert.Len(t, consent, 1) && assert.Len(t, consent[0].Records, 1) {
+		assert.Equal(t, "chain", consent[0].ID)
+		assert.Equal(t, "v2", consent[0].Records[0].Hash)
+	}
+
+	unknown := "unknown"
+	v3 := patientConsent("chain", "actor", "v3", n, nil, "observation")
+	v3.Records[0].PreviousHash = &unknown
+	assert.Error(t, client.RecordConsent(context.Background(), []pkg.PatientConsent{v3}), "previous record must exist")
+}
+
+func testDelete(t *testing.T, client pkg.ConsentStoreClient) {
+	record(t, client, patientConsent("deleted", "actor", "deleted", now().Add(-day), nil, "observation"))
+
+	deleted, err := client.DeleteConsentRecordByHash(context.Background(), "deleted", "revoked", "custodian")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	assert.False(t, auth(t, client, "actor", "observation", nil), "deleted record is not granted")
+
+	_, err = client.FindConsentRecordByHash(context.Background(), "deleted", false)
+	assert.True(t, errors.Is(err, pkg.ErrorNotFound), "expected ErrorNotFound, got %v", err)
+
+	actor := "actor"
+	consent, err := client.QueryConsent(context.Background(), &actor, nil, nil, nil, false)
+	assert.NoError(t, err)
+	assert.Empty(t, consent)
+
+	consent, err = client.QueryConsent(context.Background(), &actor, nil, nil, nil, true)
+	if assert.NoError(t, err) && assert.Len(t, consent, 1) && assert.Len(t, consent[0].Records, 1) {
+		r := consent[0].Records[0]
+		assert.NotNil(t, r.DeletedAt)
+		if assert.NotNil(t, r.DeletedReason) && assert.NotNil(t, r.DeletedBy) {
+			assert.Equal(t, "revoked", *r.DeletedReason)
+			assert.Equal(t, "custodian", *r.DeletedBy)
+		}
+	}
+
+	deleted, err = client.DeleteConsentRecordByHash(context.Background(), "deleted", "", "")
+	assert.False(t, deleted)
+	assert.True(t, errors.Is(err, pkg.ErrorNotFound), "expected ErrorNotFound when deleting twice, got %v", err)
+}
+
+func testNotFound(t *testing.T, client pkg.ConsentStoreClient) {
+	_, err := client.FindConsentRecordByHash(context.Background(), "unknown", false)
+	assert.True(t, errors.Is(err, pkg.ErrorNotFound), "expected ErrorNotFound, got %v", err)
+
+	_, err = client.FindConsentRecordByHash(context.Background(), "unknown", true)
+	assert.True(t, errors.Is(err, pkg.ErrorNotFound), "expected ErrorNotFound for latest, got %v", err)
+
+	deleted, err := client.DeleteConsentRecordByHash(context.Background(), "unknown", "", "")
+	assert.False(t, deleted)
+	assert.True(t, errors.Is(err, pkg.ErrorNotFound), "expected ErrorNotFound, got %v", err)
+
+	assert.False(t, auth(t, client, "unknown", "observation", nil), "unknown consent is not granted")
+
+	actor := "unknown"
+	consent, err := client.QueryConsent(context.Background(), &actor, nil, nil, nil, false)
+	assert.NoError(t, err)
+	assert.Empty(t, consent)
+}
+
+func testRecordConsent(t *testing.T, client pkg.ConsentStoreClient) {
+	n := now()
+	record(t, client,
+		patientConsent("first", "first", "first", n.Add(-day), nil, "observation"),
+		patientConsent("second", "second", "second", n.Add(-day), nil, "observation"),
+	)
+
+	assert.True(t, auth(t, client, "first", "observation", nil))
+	assert.True(t, auth(t, client, "second", "observation", nil))
+
+	t.Run("nothing is recorded when one of the consents fails", func(t *testing.T) {
+		unknown := "unknown"
+		failing := patientConsent("failing", "failing", "failing", n.Add(-day), nil, "observation")
+		failing.Records[0].PreviousHash = &unknown
+
+		err := client.RecordConsent(context.Background(), []pkg.PatientConsent{
+			patientConsent("third", "third", "third", n.Add(-day), nil, "observation"),
+			failing,
+		})
+
+		assert.Error(t, err)
+		_, err = client.FindConsentRecordByHash(context.Background(), "third", false)
+		assert.True(t, errors.Is(err, pkg.ErrorNotFound), "expected ErrorNotFound, got %v", err)
+	})
+
+	t.Run("unknown previous record is rejected", func(t *testing.T) {
+		unknown := "unknown"
+		appended := patientConsent("appended", "appended", "appended", n.Add(-day), nil, "observation")
+		appended.Records[0].PreviousHash = &unknown
+
+		err := client.RecordConsent(context.Background(), []pkg.PatientConsent{appended})
+
+		assert.True(t, errors.Is(err, pkg.ErrorUnknownPreviousHash), "expected ErrorUnknownPreviousHash, got %v", err)
+	})
+
+	t.Run("recording the same consent again is ignored", func(t *testing.T) {
+		err := client.RecordConsent(context.Background(), []pkg.PatientConsent{
+			patientConsent("first", "first", "first", n.Add(-day), nil, "observation"),
+		})
+
+		assert.NoError(t, err)
+		actor := "first"
+		consent, _ := client.QueryConsent(context.Background(), &actor, nil, nil, nil, false)
+		assert.Len(t, consent, 1)
+	})
+
+	t.Run("validTo before validFrom is rejected", func(t *testing.T) {
+		validTo := n.Add(-2 * day)
+
+		err := client.RecordConsent(context.Background(), []pkg.PatientConsent{
+			patientConsent("invalid", "invalid", "invalid", n.Add(-day), &validTo, "observation"),
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func testConflict(t *testing.T, client pkg.ConsentStoreClient) {
+	n := now()
+	v1 := patientConsent("chain", "actor", "v1", n.Add(-day), nil, "observation")
+	record(t, client, v1)
+
+	v2 := patientConsent("chain", "actor", "v2", n.Add(-day), nil, "medication")
+	v2.Records[0].PreviousHash = &v1.Records[0].Hash
+	record(t, client, v2)
+
+	t.Run("append to previous head returns the current head", func(t *testing.T) {
+		other := patientConsent("chain", "actor", "other", n.Add(-day), nil, "observation")
+		other.Records[0].PreviousHash = &v1.Records[0].Hash
+
+		err := client.RecordConsent(context.Background(), []pkg.PatientConsent{other})
+
+		assert.True(t, errors.Is(err, pkg.ErrorConflict), "expected ErrorConflict, got %v", err)
+		var conflict *pkg.ConflictError
+		if assert.True(t, errors.As(err, &conflict), "expected ConflictError, got %v", err) {
+			assert.Equal(t, "v2", conflict.Head.Hash)
+			assert.Equal(t, uint(2), conflict.Head.Version)
+		}
+		assert.True(t, auth(t, client, "actor", "medication", nil), "chain is unchanged")
+	})
+
+	t.Run("append with expected version", func(t *testing.T) {
+		v3 := patientConsent("chain", "actor", "v3", n.Add(-day), nil, "observation")
+		v3.Records[0].PreviousHash = &v2.Records[0].Hash
+		v3.Records[0].Version = 4
+
+		err := client.RecordConsent(context.Background(), []pkg.PatientConsent{v3})
+		assert.True(t, errors.Is(err, pkg.ErrorConflict), "expected ErrorConflict, got %v", err)
+
+		v3.Records[0].Version = 3
+		assert.NoError(t, client.RecordConsent(context.Background(), []pkg.PatientConsent{v3}))
+	})
+}
@@ -0,0 +1,51 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package conformance
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	core "github.com/nuts-foundation/nuts-go-core"
+)
+
+func TestConsentStore(t *testing.T) {
+	Run(t, func(t *testing.T) pkg.ConsentStoreClient {
+		cs := &pkg.ConsentStore{
+			Config: pkg.ConsentStoreConfig{
+				Connectionstring: ":memory:",
+				Mode:             core.ServerEngineMode,
+			},
+		}
+		if err := cs.Configure(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cs.Start(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cs.RunMigrations(cs.Db.DB()); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			cs.Shutdown()
+		})
+
+		return cs
+	})
+}
@@ -20,16 +20,25 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/labstack/echo/v4"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/nuts-foundation/nuts-consent-store/api"
 	"github.com/nuts-foundation/nuts-consent-store/client"
 	"github.com/nuts-foundation/nuts-consent-store/pkg"
 	engine "github.com/nuts-foundation/nuts-go-core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -39,15 +48,22 @@ func NewConsentStoreEngine() *engine.Engine {
 	cs := pkg.ConsentStoreInstance()
 
 	return &engine.Engine{
-		Name:        "ConsentStore",
-		Cmd:         cmd(),
-		Configure:   cs.Configure,
+		Name: "ConsentStore",
+		Cmd:  cmd(),
+		Configure: func() error {
+			if err := cs.Configure(); err != nil {
+				return err
+			}
+			// the metrics are served by the default prometheus handler, like those of the other engines
+			return cs.Metrics.Register(prometheus.DefaultRegisterer)
+		},
 		Config:      &cs.Config,
 		ConfigKey:   "cstore",
 		Diagnostics: cs.Diagnostics,
 		FlagSet:     flagSet(),
 		Routes: func(router engine.EchoRouter) {
-			api.RegisterHandlers(router, &api.Wrapper{Cs: cs})
+			api.RegisterHandlers(api.TracingRouter(api.ProblemRouter(router)), &api.Wrapper{Cs: cs})
+			router.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
 		},
 		Start:    cs.Start,
 		Shutdown: cs.Shutdown,
@@ -58,8 +74,42 @@ func flagSet() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("cstore", pflag.ContinueOnError)
 
 	flags.String(pkg.ConfigConnectionString, pkg.ConfigConnectionStringDefault, "Db connectionString")
-	flags.String(pkg.ConfigAddress, "localhost:1323", "Address of the server when in client mode")
+	flags.String(pkg.ConfigAddress, "localhost:1323", "Address of the server when in client mode, prefix with grpc:// to use the gRPC api")
 	flags.String(pkg.ConfigMode, "", "server or client, when client it uses the HttpClient")
+	flags.String(pkg.ConfigListenAddress, pkg.ConfigListenAddressDefault, "Address the standalone server listens on")
+	flags.String(pkg.ConfigGrpcListenAddress, "", "Address the standalone server listens on for gRPC calls, e.g. :1324. Empty disables the gRPC api")
+	flags.String(pkg.ConfigTlsCertFile, "", "PEM certificate file, server certificate in server mode, client certificate in client mode. Enables TLS")
+	flags.String(pkg.ConfigTlsKeyFile, "", "PEM private key file for the configured certificate")
+	flags.String(pkg.ConfigTlsCAFile, "", "PEM CA bundle, used to verify client certificates (mTLS) in server mode and the server certificate in client mode")
+	flags.Int(pkg.ConfigRateLimitCheck, 0, "Number of consent checks per minute per caller, 0 is unlimited")
+	flags.Int(pkg.ConfigRateLimitQuery, 0, "Number of consent queries per minute per caller, 0 is unlimited")
+	flags.Int(pkg.ConfigRateLimitWrite, 0, "Number of consent writes per minute per caller, 0 is unlimited")
+	flags.String(pkg.ConfigRateLimits, "", "Per caller rate limits, e.g. caller=gateway;check=600;query=60,caller=10.0.0.1;write=10")
+	flags.String(pkg.ConfigTombstoneRetention, pkg.ConfigTombstoneRetentionDefault, "Period deleted consent records are kept before they are purged, e.g. 365d or 720h")
+	flags.String(pkg.ConfigRetentionPeriod, "", "Period consent chains are kept after their latest record expired, e.g. 3650d. Empty keeps them forever")
+	flags.String(pkg.ConfigRetentionPolicies, "", "Retention periods per data class or custodian, e.g. dataClass=urn:oid:1.3.6.1.4.1.54851.1:MEDICAL;period=3650d,custodian=urn:oid:2.16.840.1.113883.2.4.6.1:00000007;period=1825d")
+	flags.String(pkg.ConfigRetentionMode, string(pkg.RetentionModePurge), "What happens with consent chains past their retention period: purge or archive")
+	flags.String(pkg.ConfigRetentionArchiveDir, "", "Directory expired consent chains are written to in the archive retention mode")
+	flags.String(pkg.ConfigRetentionInterval, pkg.ConfigRetentionIntervalDefault, "Interval at which the retention policies are applied, 0 disables the background job")
+	flags.Int(pkg.ConfigEventBufferSize, pkg.ConfigEventBufferSizeDefault, "Number of recent consent events kept for resuming event stream subscribers")
+	flags.String(pkg.ConfigWebhooks, "", "Webhooks that receive consent events, optionally filtered by custodian and actor, e.g. url=https://example.com/hook;secret=s3cr3t;custodian=urn:oid:2.16.840.1.113883.2.4.6.1:00000007")
+	flags.Int(pkg.ConfigWebhookMaxAttempts, pkg.ConfigWebhookMaxAttemptsDefault, "Number of failed attempts after which a webhook delivery is dead and has to be replayed")
+	flags.String(pkg.ConfigExpiryHorizon, pkg.ConfigExpiryHorizonDefault, "Period before the end of a consent record in which a ConsentExpiring event is emitted, e.g. 30d")
+	flags.String(pkg.ConfigExpiryInterval, pkg.ConfigExpiryIntervalDefault, "Interval at which expiring and expired consent records are looked for, 0 disables the background job")
+	flags.Int(pkg.ConfigImportBatchSize, pkg.ConfigImportBatchSizeDefault, "Number of consents of a bulk import that are recorded in a single transaction")
+	flags.String(pkg.ConfigBackupDir, "", "Directory online backups of the database are written to")
+	flags.Int(pkg.ConfigBackupRetain, pkg.ConfigBackupRetainDefault, "Number of online backups that are kept, older backups are removed")
+	flags.String(pkg.ConfigCheckMaxAge, pkg.ConfigCheckMaxAgeDefault, "Maximum period clients may cache the outcome of a consent check, 0 disables caching")
+	flags.String(pkg.ConfigIdempotencyKeyRetention, pkg.ConfigIdempotencyKeyRetentionDefault, "Period the response to a write request is kept for retries with the same Idempotency-Key")
+	flags.String(pkg.ConfigTracingExporter, string(pkg.TracingExporterNone), "Where the OpenTelemetry spans are exported to: none, otlp, stdout or file")
+	flags.String(pkg.ConfigTracingEndpoint, "", "URL of the OTLP/HTTP collector for the otlp exporter, e.g. http://localhost:4318. Empty uses OTEL_EXPORTER_OTLP_ENDPOINT or https://localhost:4318")
+	flags.String(pkg.ConfigTracingFile, "", "File the spans are appended to as JSON by the file exporter")
+	flags.String(pkg.ConfigClientTimeout, pkg.ConfigClientTimeoutDefault, "Timeout of a single call to the server in client mode")
+	flags.Int(pkg.ConfigClientMaxAttempts, pkg.ConfigClientMaxAttemptsDefault, "Number of attempts of an idempotent call (check, query, find) to the server in client mode")
+	flags.String(pkg.ConfigClientRetryBackoff, pkg.ConfigClientRetryBackoffDefault, "Base wait time between attempts in client mode, doubles after every attempt and is jittered")
+	flags.Int(pkg.ConfigClientBreakerThreshold, pkg.ConfigClientBreakerThresholdDefault, "Number of consecutive failed calls after which the client fails fast, 0 disables the circuit breaker")
+	flags.String(pkg.ConfigClientBreakerCooldown, pkg.ConfigClientBreakerCooldownDefault, "Period the client fails fast before the server is tried again")
+	flags.Int(pkg.ConfigClientCacheSize, 0, "Number of consent check outcomes cached in client mode, 0 disables the cache")
 
 	return flags
 }
@@ -70,7 +120,7 @@ func cmd() *cobra.Command {
 		Short: "consent store commands",
 	}
 
-	cmd.AddCommand(&cobra.Command{
+	listCmd := &cobra.Command{
 		Use:     "list [actor] [subject]?",
 		Example: "list urn:oid:2.16.840.1.113883.2.4.6.1:00000007",
 		Short:   "lists all consent records for the given actor and optional subject",
@@ -90,10 +140,12 @@ func cmd() *cobra.Command {
 				err         error
 			)
 
+			includeDeleted, _ := cmd.Flags().GetBool("include-deleted")
+
 			if len(args) > 1 {
-				consentList, err = csc.QueryConsent(context.TODO(), &args[0], nil, &args[1], nil)
+				consentList, err = csc.QueryConsent(context.TODO(), &args[0], nil, &args[1], nil, includeDeleted)
 			} else {
-				consentList, err = csc.QueryConsent(context.TODO(), &args[0], nil, nil, nil)
+				consentList, err = csc.QueryConsent(context.TODO(), &args[0], nil, nil, nil, includeDeleted)
 			}
 
 			if err != nil {
@@ -107,7 +159,53 @@ func cmd() *cobra.Command {
 				logrus.Errorln(c.String())
 			}
 		},
-	})
+	}
+	listCmd.Flags().Bool("include-deleted", false, "also list deleted consent records")
+	cmd.AddCommand(listCmd)
+
+	expiringCmd := &cobra.Command{
+		Use:     "expiring [actor]",
+		Example: "expiring urn:oid:2.16.840.1.113883.2.4.6.1:00000007 --within 30d",
+		Short:   "lists the consent records of the actor that end within the given period",
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires an actor argument")
+			}
+
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error finding expiring consent records: %s\n", err.Error())
+				return
+			}
+
+			within, err := cs.Config.ExpiryHorizonPeriod()
+			if w, _ := cmd.Flags().GetString("within"); w != "" {
+				within, err = pkg.ParseDuration(w)
+			}
+			if err != nil {
+				logrus.Errorf("Invalid period: %s\n", err.Error())
+				return
+			}
+
+			consentList, err := cs.ExpiringConsent(context.TODO(), &args[0], nil, time.Now(), within)
+			if err != nil {
+				logrus.Errorf("Error finding expiring consent records: %s\n", err.Error())
+				return
+			}
+
+			logrus.Errorf("Found %d records ending within %s\n\n", len(consentList), within)
+
+			for _, c := range consentList {
+				logrus.Errorln(c.String())
+			}
+		},
+	}
+	expiringCmd.Flags().String("within", "", "period from now, e.g. 30d or 72h, defaults to the configured expiryHorizon")
+	cmd.AddCommand(expiringCmd)
 
 	cmd.AddCommand(&cobra.Command{
 		Use:     "record [subject] [custodian] [actor] [dataClasses]",
@@ -184,5 +282,345 @@ func cmd() *cobra.Command {
 		},
 	})
 
+	purgeCmd := &cobra.Command{
+		Use:     "purge",
+		Example: "purge --retention 30d",
+		Short:   "physically removes deleted consent records that are older than the retention period",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error purging consent records: %s\n", err.Error())
+				return
+			}
+
+			period, err := cs.Config.TombstoneRetentionPeriod()
+			if retention, _ := cmd.Flags().GetString("retention"); retention != "" {
+				period, err = pkg.ParseDuration(retention)
+			}
+			if err != nil {
+				logrus.Errorf("Invalid retention: %s\n", err.Error())
+				return
+			}
+
+			hashes, err := cs.PurgeTombstones(context.TODO(), time.Now().Add(-period))
+			if err != nil {
+				logrus.Errorf("Error purging consent records: %s\n", err.Error())
+				return
+			}
+
+			logrus.Errorf("Purged %d records\n", len(hashes))
+			for _, h := range hashes {
+				logrus.Errorln(h)
+			}
+		},
+	}
+	purgeCmd.Flags().String("retention", "", "retention period, defaults to the configured tombstoneRetention")
+	cmd.AddCommand(purgeCmd)
+
+	retentionCmd := &cobra.Command{
+		Use:     "retention",
+		Example: "retention --dry-run",
+		Short:   "applies the retention policies, removing consent chains that expired longer than the retention period ago",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error applying retention policies: %s\n", err.Error())
+				return
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			report, err := cs.ApplyRetention(context.TODO(), time.Now(), dryRun)
+			if err != nil {
+				logrus.Errorf("Error applying retention policies: %s\n", err.Error())
+				return
+			}
+
+			if dryRun {
+				logrus.Errorf("%d consent chains would be removed (%s)\n\n", len(report.Candidates), report.Mode)
+			} else {
+				logrus.Errorf("Removed %d consent chains (%s)\n\n", len(report.Candidates), report.Mode)
+			}
+			for _, c := range report.Candidates {
+				logrus.Errorf("%s@%s for %s, expired %s, policy %s: %s\n", c.Subject, c.Custodian, c.Actor, c.ValidTo.Format(time.RFC3339), c.Policy, strings.Join(c.Hashes, ","))
+			}
+		},
+	}
+	retentionCmd.Flags().Bool("dry-run", false, "only list the consent chains that would be removed")
+	cmd.AddCommand(retentionCmd)
+
+	exportCmd := &cobra.Command{
+		Use:     "export-subject [subject]",
+		Example: "export-subject urn:oid:2.16.840.1.113883.2.4.6.3:999999990 --out export.json",
+		Short:   "exports all consent data held about the subject as signed JSON document",
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires a subject argument")
+			}
+
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error exporting subject: %s\n", err.Error())
+				return
+			}
+
+			export, err := cs.ExportSubject(context.TODO(), args[0], "cli")
+			if err != nil {
+				logrus.Errorf("Error exporting subject: %s\n", err.Error())
+				return
+			}
+
+			signed, err := cs.Config.SignDocument(export)
+			if err != nil {
+				logrus.Errorf("Error signing export: %s\n", err.Error())
+				return
+			}
+
+			data, _ := json.MarshalIndent(signed, "", "  ")
+
+			out, _ := cmd.Flags().GetString("out")
+			if out == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return
+			}
+
+			if err := ioutil.WriteFile(out, data, 0600); err != nil {
+				logrus.Errorf("Error writing export: %s\n", err.Error())
+				return
+			}
+
+			logrus.Errorf("Exported %d consents to %s\n", len(export.Consents), out)
+		},
+	}
+	exportCmd.Flags().String("out", "", "file to write the export to, defaults to stdout")
+	cmd.AddCommand(exportCmd)
+
+	dumpCmd := &cobra.Command{
+		Use:     "export",
+		Example: "export --out consent-store.json",
+		Short:   "exports every consent, every version of every chain and its data classes to a JSON dump that can be restored into an empty store",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error exporting store: %s\n", err.Error())
+				return
+			}
+
+			dump, err := cs.Dump(context.TODO())
+			if err != nil {
+				logrus.Errorf("Error exporting store: %s\n", err.Error())
+				return
+			}
+
+			data, _ := json.MarshalIndent(dump, "", "  ")
+
+			out, _ := cmd.Flags().GetString("out")
+			if out == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return
+			}
+
+			if err := ioutil.WriteFile(out, data, 0600); err != nil {
+				logrus.Errorf("Error writing export: %s\n", err.Error())
+				return
+			}
+
+			logrus.Errorf("Exported %d consents to %s, checksum %s\n", len(dump.Consents), out, dump.Checksum)
+		},
+	}
+	dumpCmd.Flags().String("out", "", "file to write the export to, defaults to stdout")
+	cmd.AddCommand(dumpCmd)
+
+	restoreCmd := &cobra.Command{
+		Use:     "restore [file]",
+		Example: "restore consent-store.json",
+		Short:   "restores a JSON dump created by export into an empty store",
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires a file argument")
+			}
+
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error restoring store: %s\n", err.Error())
+				return
+			}
+
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				logrus.Errorf("Error restoring store: %s\n", err.Error())
+				return
+			}
+
+			var dump pkg.Dump
+			if err := json.Unmarshal(data, &dump); err != nil {
+				logrus.Errorf("Error restoring store: %s\n", err.Error())
+				return
+			}
+
+			n, err := cs.Restore(context.TODO(), dump, "cli")
+			if err != nil {
+				logrus.Errorf("Error restoring store: %s\n", err.Error())
+				return
+			}
+
+			logrus.Errorf("Restored %d consents with %d records\n", len(dump.Consents), n)
+		},
+	}
+	cmd.AddCommand(restoreCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "backup",
+		Short: "writes a consistent snapshot of the live database to the configured backup directory",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error creating backup: %s\n", err.Error())
+				return
+			}
+
+			backup, err := cs.Backup(context.TODO())
+			if err != nil {
+				logrus.Errorf("Error creating backup: %s\n", err.Error())
+				return
+			}
+
+			logrus.Errorf("Backup written to %s (%d bytes)\n", backup.File, backup.Size)
+		},
+	})
+
+	importCmd := &cobra.Command{
+		Use:     "import [file]",
+		Example: "import consent.csv --mapping subject=bsn;validFrom=start --dry-run",
+		Short:   "imports consent from an NDJSON or CSV file, the format is derived from the file extension unless given",
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires a file argument")
+			}
+
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cs, err := localStore()
+			if err != nil {
+				logrus.Errorf("Error importing consent: %s\n", err.Error())
+				return
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				logrus.Errorf("Error importing consent: %s\n", err.Error())
+				return
+			}
+			defer file.Close()
+
+			format, _ := cmd.Flags().GetString("format")
+			if format == "" {
+				format = api.ImportFormatNDJSON
+				if strings.EqualFold(filepath.Ext(args[0]), ".csv") {
+					format = api.ImportFormatCSV
+				}
+			}
+
+			var lines []pkg.ImportLine
+			switch format {
+			case api.ImportFormatNDJSON:
+				lines, err = api.ReadNDJSON(file)
+			case api.ImportFormatCSV:
+				m, _ := cmd.Flags().GetString("mapping")
+				var mapping api.CSVMapping
+				if mapping, err = api.ParseCSVMapping(m); err == nil {
+					lines, err = api.ReadCSV(file, mapping)
+				}
+			default:
+				err = fmt.Errorf("unknown format %s, expected %s or %s", format, api.ImportFormatNDJSON, api.ImportFormatCSV)
+			}
+			if err != nil {
+				logrus.Errorf("Error importing consent: %s\n", err.Error())
+				return
+			}
+
+			batchSize, _ := cmd.Flags().GetInt("batch-size")
+			if batchSize == 0 {
+				batchSize = cs.Config.ImportBatchSize
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			report, err := cs.ImportConsent(context.TODO(), lines, batchSize, dryRun)
+			if err != nil {
+				logrus.Errorf("Error importing consent: %s\n", err.Error())
+			}
+
+			if dryRun {
+				logrus.Errorf("Read %d consents, %d would be recorded, %d failed\n\n", report.Lines, report.Recorded, report.Failed)
+			} else {
+				logrus.Errorf("Read %d consents, recorded %d, %d failed\n\n", report.Lines, report.Recorded, report.Failed)
+			}
+			for _, e := range report.Errors {
+				logrus.Errorf("line %d: %s\n", e.Line, e.Error)
+			}
+		},
+	}
+	importCmd.Flags().String("format", "", "ndjson or csv, defaults to csv for .csv files and ndjson otherwise")
+	importCmd.Flags().String("mapping", "", "CSV column for every field that isn't in a column with the field name, e.g. subject=bsn;validFrom=start")
+	importCmd.Flags().Int("batch-size", 0, "number of consents recorded in a single transaction, defaults to the configured importBatchSize")
+	importCmd.Flags().Bool("dry-run", false, "validate the file against the store without writing anything")
+	cmd.AddCommand(importCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "erase-subject [subject] [erasedBy]",
+		Example: "erase-subject urn:oid:2.16.840.1.113883.2.4.6.3:999999990 dpo",
+		Short:   "erases all consent data of the subject and prints the erasure certificate",
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("requires 2 arguments")
+			}
+
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			csc := client.NewConsentStoreClient()
+
+			certificate, err := csc.EraseSubject(context.TODO(), args[0], args[1])
+			if err != nil {
+				logrus.Errorf("Error erasing subject: %s\n", err.Error())
+				return
+			}
+
+			data, _ := json.MarshalIndent(certificate, "", "  ")
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		},
+	})
+
 	return cmd
 }
+
+// localStore returns the consent store with an open db connection, for commands that can't be executed through the REST api
+func localStore() (*pkg.ConsentStore, error) {
+	cs := pkg.ConsentStoreInstance()
+	if cs.Config.Mode != engine.ServerEngineMode {
+		return nil, errors.New("command is only available in server mode")
+	}
+
+	if cs.Db == nil {
+		if err := cs.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cs, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package fhir
+
+// BundleTypeSearchSet is the type of a Bundle holding search results
+const BundleTypeSearchSet = "searchset"
+
+// Bundle is the subset of the FHIR R4 Bundle resource used for search results
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	Type         string        `json:"type"`
+	Total        int           `json:"total"`
+	Link         []BundleLink  `json:"link,omitempty"`
+	Entry        []BundleEntry `json:"entry,omitempty"`
+}
+
+// BundleLink is a link of a Bundle, like the self link of a search
+type BundleLink struct {
+	Relation string `json:"relation"`
+	URL      string `json:"url"`
+}
+
+// BundleEntry is a single resource in a Bundle
+type BundleEntry struct {
+	FullURL  string       `json:"fullUrl,omitempty"`
+	Resource Consent      `json:"resource"`
+	Search   *EntrySearch `json:"search,omitempty"`
+}
+
+// EntrySearch tells why an entry is in a searchset Bundle
+type EntrySearch struct {
+	Mode string `json:"mode"`
+}
+
+// NewSearchSet returns a searchset Bundle holding a page of the matching consents, total is the number of matches of all pages
+func NewSearchSet(consents []Consent, total int) Bundle {
+	bundle := Bundle{
+		ResourceType: ResourceTypeBundle,
+		Type:         BundleTypeSearchSet,
+		Total:        total,
+	}
+
+	for _, c := range consents {
+		bundle.Entry = append(bundle.Entry, BundleEntry{
+			FullURL:  ResourceTypeConsent + "/" + c.ID,
+			Resource: c,
+			Search:   &EntrySearch{Mode: "match"},
+		})
+	}
+
+	return bundle
+}
+
+// OperationOutcome is the FHIR resource for errors
+type OperationOutcome struct {
+	ResourceType string  `json:"resourceType"`
+	Issue        []Issue `json:"issue"`
+}
+
+// Issue is a single issue of an OperationOutcome
+type Issue struct {
+	Severity    string `json:"severity"`
+	Code        string `json:"code"`
+	Diagnostics string `json:"diagnostics,omitempty"`
+}
+
+// NewOperationOutcome returns an OperationOutcome with a single error issue, code is one of the FHIR IssueType codes like invalid or not-found
+func NewOperationOutcome(code string, diagnostics string) OperationOutcome {
+	return OperationOutcome{
+		ResourceType: ResourceTypeOperationOutcome,
+		Issue:        []Issue{{Severity: "error", Code: code, Diagnostics: diagnostics}},
+	}
+}
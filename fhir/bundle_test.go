@@ -0,0 +1,44 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package fhir
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearchSet(t *testing.T) {
+	bundle := NewSearchSet([]Consent{{ResourceType: ResourceTypeConsent, ID: "hash"}}, 3)
+
+	assert.Equal(t, ResourceTypeBundle, bundle.ResourceType)
+	assert.Equal(t, BundleTypeSearchSet, bundle.Type)
+	assert.Equal(t, 3, bundle.Total)
+	if assert.Len(t, bundle.Entry, 1) {
+		assert.Equal(t, "Consent/hash", bundle.Entry[0].FullURL)
+		assert.Equal(t, "match", bundle.Entry[0].Search.Mode)
+	}
+}
+
+func TestNewOperationOutcome(t *testing.T) {
+	oo := NewOperationOutcome("invalid", "missing patient")
+
+	assert.Equal(t, ResourceTypeOperationOutcome, oo.ResourceType)
+	assert.Equal(t, []Issue{{Severity: "error", Code: "invalid", Diagnostics: "missing patient"}}, oo.Issue)
+}
@@ -0,0 +1,320 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package fhir converts between the consent of the store and FHIR R4 Consent resources
+package fhir
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+// ContentType is the media type of FHIR resources in JSON
+const ContentType = "application/fhir+json"
+
+const (
+	// ResourceTypeConsent is the resourceType of a Consent resource
+	ResourceTypeConsent = "Consent"
+	// ResourceTypeBundle is the resourceType of a Bundle resource
+	ResourceTypeBundle = "Bundle"
+	// ResourceTypeOperationOutcome is the resourceType of an OperationOutcome resource
+	ResourceTypeOperationOutcome = "OperationOutcome"
+)
+
+const (
+	// SystemRecordHash is the identifier system for the hash of a consent record
+	SystemRecordHash = "https://nuts.nl/fhir/NamingSystem/consent-record-hash"
+	// SystemPatientConsentID is the identifier system for the ID of the patient consent a record belongs to
+	SystemPatientConsentID = "https://nuts.nl/fhir/NamingSystem/patient-consent-id"
+	// ExtensionPreviousRecordHash is the extension holding the hash of the previous version of a consent record
+	ExtensionPreviousRecordHash = "https://nuts.nl/fhir/StructureDefinition/previous-record-hash"
+)
+
+const (
+	// ConsentStatusActive is the status of a record that's the latest of its chain and valid now
+	ConsentStatusActive = "active"
+	// ConsentStatusInactive is the status of a record that's replaced, deleted or not valid now
+	ConsentStatusInactive = "inactive"
+)
+
+// scope, category, policyRule and the actor role are fixed for all consent in the store
+var (
+	consentScope      = CodeableConcept{Coding: []Coding{{System: "http://terminology.hl7.org/CodeSystem/consentscope", Code: "patient-privacy"}}}
+	consentCategory   = CodeableConcept{Coding: []Coding{{System: "http://loinc.org", Code: "59284-0", Display: "Patient Consent"}}}
+	consentPolicyRule = CodeableConcept{Coding: []Coding{{System: "http://terminology.hl7.org/CodeSystem/v3-ActCode", Code: "OPTIN"}}}
+	actorRole         = CodeableConcept{Coding: []Coding{{System: "http://terminology.hl7.org/CodeSystem/v3-ParticipationType", Code: "IRCP"}}}
+)
+
+// Consent is the subset of the FHIR R4 Consent resource used by the consent store
+type Consent struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id,omitempty"`
+	Meta         *Meta             `json:"meta,omitempty"`
+	Extension    []Extension       `json:"extension,omitempty"`
+	Identifier   []Identifier      `json:"identifier,omitempty"`
+	Status       string            `json:"status"`
+	Scope        CodeableConcept   `json:"scope"`
+	Category     []CodeableConcept `json:"category"`
+	Patient      *Reference        `json:"patient,omitempty"`
+	DateTime     *time.Time        `json:"dateTime,omitempty"`
+	Organization []Reference       `json:"organization,omitempty"`
+	PolicyRule   *CodeableConcept  `json:"policyRule,omitempty"`
+	Provision    *Provision        `json:"provision,omitempty"`
+}
+
+// Meta holds the version of a resource
+type Meta struct {
+	VersionID   string     `json:"versionId,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// Extension is a FHIR extension with a string value
+type Extension struct {
+	URL         string `json:"url"`
+	ValueString string `json:"valueString,omitempty"`
+}
+
+// Identifier is a FHIR identifier
+type Identifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value"`
+}
+
+// Reference is a logical FHIR reference by identifier
+type Reference struct {
+	Identifier *Identifier `json:"identifier,omitempty"`
+}
+
+// CodeableConcept is a FHIR CodeableConcept
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+// Coding is a FHIR Coding
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+// Period is a FHIR Period, End is open ended when nil
+type Period struct {
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Provision is the rule of a Consent: what is permitted for whom, during which period
+type Provision struct {
+	Type   string           `json:"type,omitempty"`
+	Period *Period          `json:"period,omitempty"`
+	Actor  []ProvisionActor `json:"actor,omitempty"`
+	Class  []Coding         `json:"class,omitempty"`
+}
+
+// ProvisionActor is an actor of a provision with its role
+type ProvisionActor struct {
+	Role      CodeableConcept `json:"role"`
+	Reference Reference       `json:"reference"`
+}
+
+// IdentifierFromURN splits a Nuts identifier like urn:oid:2.16.840.1.113883.2.4.6.3:999999990 into its system and value
+func IdentifierFromURN(urn string) Identifier {
+	i := strings.LastIndex(urn, ":")
+	if i < 0 {
+		return Identifier{Value: urn}
+	}
+	return Identifier{System: urn[:i], Value: urn[i+1:]}
+}
+
+// URN joins the system and value of the identifier into a Nuts identifier
+func (i Identifier) URN() string {
+	if i.System == "" {
+		return i.Value
+	}
+	return i.System + ":" + i.Value
+}
+
+// TokenToURN converts a FHIR token search value in the system|value form into a Nuts identifier, other values are returned as is
+func TokenToURN(token string) string {
+	parts := strings.SplitN(token, "|", 2)
+	if len(parts) != 2 {
+		return token
+	}
+	return Identifier{System: parts[0], Value: parts[1]}.URN()
+}
+
+func codingFromURN(urn string) Coding {
+	i := IdentifierFromURN(urn)
+	return Coding{System: i.System, Code: i.Value}
+}
+
+func referenceFromURN(urn string) Reference {
+	i := IdentifierFromURN(urn)
+	return Reference{Identifier: &i}
+}
+
+// FromPatientConsent converts every record of the patient consent to a Consent resource.
+// Only the latest record of a chain can be active, so the records must be ordered by version within their chain.
+func FromPatientConsent(pc pkg.PatientConsent, now time.Time) []Consent {
+	var consents []Consent
+
+	for i, r := range pc.Records {
+		latest := true
+		for _, other := range pc.Records[i+1:] {
+			if other.PreviousHash != nil && *other.PreviousHash == r.Hash {
+				latest = false
+			}
+		}
+		consents = append(consents, FromConsentRecord(pc, r, latest, now))
+	}
+
+	return consents
+}
+
+// FromConsentRecord converts a single record of a patient consent to a Consent resource, the hash of the record is used as ID
+func FromConsentRecord(pc pkg.PatientConsent, r pkg.ConsentRecord, latest bool, now time.Time) Consent {
+	validFrom := r.ValidFrom
+	patient := referenceFromURN(pc.Subject)
+	status := ConsentStatusInactive
+	if latest && !r.IsDeleted() && !r.ValidFrom.After(now) && (r.ValidTo == nil || r.ValidTo.After(now)) {
+		status = ConsentStatusActive
+	}
+
+	c := Consent{
+		ResourceType: ResourceTypeConsent,
+		ID:           r.Hash,
+		Identifier: []Identifier{
+			{System: SystemRecordHash, Value: r.Hash},
+			{System: SystemPatientConsentID, Value: pc.ID},
+		},
+		Status:       status,
+		Scope:        consentScope,
+		Category:     []CodeableConcept{consentCategory},
+		Patient:      &patient,
+		DateTime:     &validFrom,
+		Organization: []Reference{referenceFromURN(pc.Custodian)},
+		PolicyRule:   &consentPolicyRule,
+		Provision: &Provision{
+			Type:   "permit",
+			Period: &Period{Start: &validFrom, End: r.ValidTo},
+			Actor:  []ProvisionActor{{Role: actorRole, Reference: referenceFromURN(pc.Actor)}},
+		},
+	}
+	if r.Version > 0 {
+		c.Meta = &Meta{VersionID: strconv.Itoa(int(r.Version))}
+	}
+
+	lastUpdated := r.DeletedAt
+	if lastUpdated == nil && !r.CreatedAt.IsZero() {
+		lastUpdated = &r.CreatedAt
+	}
+	if lastUpdated != nil {
+		if c.Meta == nil {
+			c.Meta = &Meta{}
+		}
+		c.Meta.LastUpdated = lastUpdated
+	}
+
+	if r.PreviousHash != nil {
+		c.Extension = append(c.Extension, Extension{URL: ExtensionPreviousRecordHash, ValueString: *r.PreviousHash})
+	}
+
+	// the data classes are categories as well, so they can be searched on
+	for _, dc := range r.DataClasses {
+		c.Provision.Class = append(c.Provision.Class, codingFromURN(dc.Code))
+		c.Category = append(c.Category, CodeableConcept{Coding: []Coding{codingFromURN(dc.Code)}})
+	}
+
+	return c
+}
+
+// ToPatientConsent converts a Consent resource to a patient consent with a single record
+func (c Consent) ToPatientConsent() (pkg.PatientConsent, error) {
+	if c.ResourceType != ResourceTypeConsent {
+		return pkg.PatientConsent{}, fmt.Errorf("expected resourceType %s, got %s", ResourceTypeConsent, c.ResourceType)
+	}
+
+	pc := pkg.PatientConsent{}
+	record := pkg.ConsentRecord{}
+
+	for _, i := range c.Identifier {
+		switch i.System {
+		case SystemRecordHash:
+			record.Hash = i.Value
+		case SystemPatientConsentID:
+			pc.ID = i.Value
+		}
+	}
+	if record.Hash == "" {
+		return pc, fmt.Errorf("missing identifier with system %s", SystemRecordHash)
+	}
+	if pc.ID == "" {
+		return pc, fmt.Errorf("missing identifier with system %s", SystemPatientConsentID)
+	}
+
+	for _, e := range c.Extension {
+		if e.URL == ExtensionPreviousRecordHash && e.ValueString != "" {
+			previous := e.ValueString
+			record.PreviousHash = &previous
+		}
+	}
+
+	if c.Patient == nil || c.Patient.Identifier == nil {
+		return pc, errors.New("missing patient identifier")
+	}
+	pc.Subject = c.Patient.Identifier.URN()
+
+	if len(c.Organization) != 1 || c.Organization[0].Identifier == nil {
+		return pc, errors.New("expected a single organization identifier")
+	}
+	pc.Custodian = c.Organization[0].Identifier.URN()
+
+	p := c.Provision
+	if p == nil {
+		return pc, errors.New("missing provision")
+	}
+	if p.Type != "" && p.Type != "permit" {
+		return pc, fmt.Errorf("unsupported provision type %s", p.Type)
+	}
+	if len(p.Actor) != 1 || p.Actor[0].Reference.Identifier == nil {
+		return pc, errors.New("expected a single provision actor identifier")
+	}
+	pc.Actor = p.Actor[0].Reference.Identifier.URN()
+
+	if p.Period == nil || p.Period.Start == nil {
+		return pc, errors.New("missing provision period start")
+	}
+	record.ValidFrom = *p.Period.Start
+	record.ValidTo = p.Period.End
+
+	if len(p.Class) == 0 {
+		return pc, errors.New("missing provision class")
+	}
+	for _, class := range p.Class {
+		record.DataClasses = append(record.DataClasses, pkg.DataClass{Code: Identifier{System: class.System, Value: class.Code}.URN()})
+	}
+
+	pc.Records = []pkg.ConsentRecord{record}
+	return pc, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func patientConsent() pkg.PatientConsent {
+	validFrom := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	validTo := validFrom.AddDate(1, 0, 0)
+	previous := "previous"
+	return pkg.PatientConsent{
+		ID:        "id",
+		Subject:   "urn:oid:2.16.840.1.113883.2.4.6.3:999999990",
+		Custodian: "urn:oid:2.16.840.1.113883.2.4.6.1:00000000",
+		Actor:     "urn:oid:2.16.840.1.113883.2.4.6.1:00000007",
+		Records: []pkg.ConsentRecord{{
+			Hash:         "hash",
+			PreviousHash: &previous,
+			Version:      2,
+			ValidFrom:    validFrom,
+			ValidTo:      &validTo,
+			DataClasses:  []pkg.DataClass{{Code: "urn:oid:1.3.6.1.4.1.54851.1:MEDICAL"}},
+		}},
+	}
+}
+
+func TestIdentifierFromURN(t *testing.T) {
+	i := IdentifierFromURN("urn:oid:2.16.840.1.113883.2.4.6.3:999999990")
+
+	assert.Equal(t, Identifier{System: "urn:oid:2.16.840.1.113883.2.4.6.3", Value: "999999990"}, i)
+	assert.Equal(t, "urn:oid:2.16.840.1.113883.2.4.6.3:999999990", i.URN())
+	assert.Equal(t, Identifier{Value: "plain"}, IdentifierFromURN("plain"))
+}
+
+func TestTokenToURN(t *testing.T) {
+	assert.Equal(t, "urn:oid:2.16.840.1.113883.2.4.6.3:999999990", TokenToURN("urn:oid:2.16.840.1.113883.2.4.6.3|999999990"))
+	assert.Equal(t, "urn:oid:2.16.840.1.113883.2.4.6.3:999999990", TokenToURN("urn:oid:2.16.840.1.113883.2.4.6.3:999999990"))
+}
+
+func TestFromConsentRecord(t *testing.T) {
+	pc := patientConsent()
+
+	t.Run("maps the record", func(t *testing.T) {
+		c := FromConsentRecord(pc, pc.Records[0], true, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, ResourceTypeConsent, c.ResourceType)
+		assert.Equal(t, "hash", c.ID)
+		assert.Equal(t, "2", c.Meta.VersionID)
+		assert.Equal(t, ConsentStatusActive, c.Status)
+		assert.Equal(t, "999999990", c.Patient.Identifier.Value)
+		assert.Equal(t, "00000000", c.Organization[0].Identifier.Value)
+		assert.Equal(t, "00000007", c.Provision.Actor[0].Reference.Identifier.Value)
+		assert.Equal(t, Coding{System: "urn:oid:1.3.6.1.4.1.54851.1", Code: "MEDICAL"}, c.Provision.Class[0])
+		if assert.Len(t, c.Category, 2) {
+			assert.Equal(t, c.Provision.Class, c.Category[1].Coding)
+		}
+		assert.Equal(t, pc.Records[0].ValidTo, c.Provision.Period.End)
+		assert.Equal(t, []Extension{{URL: ExtensionPreviousRecordHash, ValueString: "previous"}}, c.Extension)
+	})
+
+	t.Run("inactive when not valid", func(t *testing.T) {
+		c := FromConsentRecord(pc, pc.Records[0], true, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, ConsentStatusInactive, c.Status)
+	})
+
+	t.Run("inactive when not latest", func(t *testing.T) {
+		c := FromConsentRecord(pc, pc.Records[0], false, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, ConsentStatusInactive, c.Status)
+	})
+}
+
+func TestFromPatientConsent(t *testing.T) {
+	pc := patientConsent()
+	next := pc.Records[0]
+	next.PreviousHash = &pc.Records[0].Hash
+	next.Hash = "next"
+	pc.Records = append(pc.Records, next)
+
+	consents := FromPatientConsent(pc, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	if assert.Len(t, consents, 2) {
+		assert.Equal(t, ConsentStatusInactive, consents[0].Status)
+		assert.Equal(t, ConsentStatusActive, consents[1].Status)
+	}
+}
+
+func TestConsent_ToPatientConsent(t *testing.T) {
+	pc := patientConsent()
+	pc.Records[0].Version = 0
+
+	t.Run("round trip through JSON", func(t *testing.T) {
+		data, _ := json.Marshal(FromConsentRecord(pc, pc.Records[0], true, time.Now()))
+		var c Consent
+		assert.NoError(t, json.Unmarshal(data, &c))
+
+		result, err := c.ToPatientConsent()
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, pc, result)
+		}
+	})
+
+	t.Run("missing patient consent id", func(t *testing.T) {
+		c := FromConsentRecord(pc, pc.Records[0], true, time.Now())
+		c.Identifier = c.Identifier[:1]
+
+		_, err := c.ToPatientConsent()
+
+		assert.EqualError(t, err, "missing identifier with system "+SystemPatientConsentID)
+	})
+
+	t.Run("missing provision class", func(t *testing.T) {
+		c := FromConsentRecord(pc, pc.Records[0], true, time.Now())
+		c.Provision.Class = nil
+
+		_, err := c.ToPatientConsent()
+
+		assert.EqualError(t, err, "missing provision class")
+	})
+
+	t.Run("wrong resource type", func(t *testing.T) {
+		_, err := Consent{ResourceType: "Patient"}.ToPatientConsent()
+
+		assert.Error(t, err)
+	})
+}
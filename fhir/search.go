@@ -0,0 +1,115 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package fhir
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+)
+
+const (
+	// DefaultCount is the number of resources per page when _count isn't given
+	DefaultCount = 50
+	// MaxCount is the maximum number of resources per page
+	MaxCount = 500
+)
+
+// dateLayouts are the precisions of a FHIR date search value with the step to the end of its range
+var dateLayouts = []struct {
+	layout string
+	end    func(time.Time) time.Time
+}{
+	{"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+	{"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+	{"2006-01-02", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+	{"2006-01-02T15:04Z07:00", func(t time.Time) time.Time { return t.Add(time.Minute) }},
+	{time.RFC3339, func(t time.Time) time.Time { return t.Add(time.Second) }},
+	{time.RFC3339Nano, func(t time.Time) time.Time { return t.Add(time.Nanosecond) }},
+}
+
+// ParseDate parses a FHIR date search value, like ge2020-01-01, into a range implied by its precision.
+// Dates without a time zone are in UTC.
+func ParseDate(value string) (pkg.DateRange, error) {
+	r := pkg.DateRange{Comparator: pkg.DateEqual}
+
+	if len(value) > 2 {
+		switch c := pkg.DateComparator(value[:2]); c {
+		case pkg.DateEqual, pkg.DateApproximately, pkg.DateGreater, pkg.DateGreaterOrEqual, pkg.DateLess, pkg.DateLessOrEqual, pkg.DateStartsAfter, pkg.DateEndsBefore:
+			r.Comparator = c
+			value = value[2:]
+		case "ne":
+			return r, fmt.Errorf("unsupported date prefix %s", c)
+		}
+	}
+
+	for _, l := range dateLayouts {
+		t, err := time.Parse(l.layout, value)
+		if err == nil {
+			r.Start = t
+			r.End = l.end(t)
+			return r, nil
+		}
+	}
+
+	return r, fmt.Errorf("invalid date %s", value)
+}
+
+// CategoryToDataClass converts a category token to the data class it matches, nil is returned for the Patient Consent category all consent has
+func CategoryToDataClass(token string) *string {
+	urn := TokenToURN(token)
+	if urn == consentCategory.Coding[0].Code || urn == consentCategory.Coding[0].System+":"+consentCategory.Coding[0].Code {
+		return nil
+	}
+	return &urn
+}
+
+// PageLinks returns the self, first, previous and next links of a page of search results. The links are the self URL with another _offset.
+func PageLinks(self url.URL, offset int, count int, total int) []BundleLink {
+	page := func(relation string, offset int) BundleLink {
+		query := self.Query()
+		query.Set("_offset", strconv.Itoa(offset))
+		query.Set("_count", strconv.Itoa(count))
+		u := self
+		u.RawQuery = query.Encode()
+		return BundleLink{Relation: relation, URL: u.String()}
+	}
+
+	links := []BundleLink{{Relation: "self", URL: self.String()}}
+	if count <= 0 {
+		return links
+	}
+
+	links = append(links, page("first", 0))
+	if offset > 0 {
+		previous := offset - count
+		if previous < 0 {
+			previous = 0
+		}
+		links = append(links, page("previous", previous))
+	}
+	if offset+count < total {
+		links = append(links, page("next", offset+count))
+	}
+
+	return links
+}
@@ -0,0 +1,105 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package fhir
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Run("day without prefix", func(t *testing.T) {
+		r, err := ParseDate("2020-01-01")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, pkg.DateEqual, r.Comparator)
+			assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), r.Start)
+			assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), r.End)
+		}
+	})
+
+	t.Run("year with prefix", func(t *testing.T) {
+		r, err := ParseDate("lt2021")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, pkg.DateLess, r.Comparator)
+			assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), r.End)
+		}
+	})
+
+	t.Run("instant", func(t *testing.T) {
+		r, err := ParseDate("ge2020-01-01T12:00:00+01:00")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, pkg.DateGreaterOrEqual, r.Comparator)
+			assert.True(t, r.Start.Equal(time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC)))
+			assert.Equal(t, time.Second, r.End.Sub(r.Start))
+		}
+	})
+
+	t.Run("ne is unsupported", func(t *testing.T) {
+		_, err := ParseDate("ne2020")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseDate("yesterday")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCategoryToDataClass(t *testing.T) {
+	assert.Nil(t, CategoryToDataClass("http://loinc.org|59284-0"))
+	assert.Nil(t, CategoryToDataClass("59284-0"))
+	assert.Equal(t, "urn:oid:1.3.6.1.4.1.54851.1:MEDICAL", *CategoryToDataClass("urn:oid:1.3.6.1.4.1.54851.1|MEDICAL"))
+}
+
+func TestPageLinks(t *testing.T) {
+	self, _ := url.Parse("http://localhost/fhir/Consent?patient=p&_count=10&_offset=10")
+
+	t.Run("middle page", func(t *testing.T) {
+		links := PageLinks(*self, 10, 10, 25)
+
+		assert.Equal(t, []BundleLink{
+			{Relation: "self", URL: self.String()},
+			{Relation: "first", URL: "http://localhost/fhir/Consent?_count=10&_offset=0&patient=p"},
+			{Relation: "previous", URL: "http://localhost/fhir/Consent?_count=10&_offset=0&patient=p"},
+			{Relation: "next", URL: "http://localhost/fhir/Consent?_count=10&_offset=20&patient=p"},
+		}, links)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		links := PageLinks(*self, 20, 10, 25)
+
+		assert.Len(t, links, 3)
+		assert.Equal(t, "previous", links[2].Relation)
+	})
+
+	t.Run("only the total", func(t *testing.T) {
+		links := PageLinks(*self, 0, 0, 25)
+
+		assert.Len(t, links, 1)
+	})
+}
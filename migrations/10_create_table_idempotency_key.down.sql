@@ -0,0 +1,2 @@
+DROP INDEX idx_idempotency_key_created_at;
+DROP TABLE idempotency_key;
@@ -0,0 +1,10 @@
+CREATE TABLE idempotency_key (
+    key VARCHAR(255) NOT NULL,
+    caller VARCHAR(255) NOT NULL DEFAULT '',
+    fingerprint VARCHAR(64) NOT NULL,
+    status INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (key, caller)
+);
+
+CREATE INDEX idx_idempotency_key_created_at ON idempotency_key(created_at);
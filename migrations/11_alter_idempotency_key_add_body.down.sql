@@ -0,0 +1,18 @@
+DROP INDEX idx_idempotency_key_created_at;
+
+ALTER TABLE idempotency_key RENAME TO idempotency_key_tmp;
+
+CREATE TABLE idempotency_key (
+    key VARCHAR(255) NOT NULL,
+    caller VARCHAR(255) NOT NULL DEFAULT '',
+    fingerprint VARCHAR(64) NOT NULL,
+    status INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (key, caller)
+);
+
+CREATE INDEX idx_idempotency_key_created_at ON idempotency_key(created_at);
+
+INSERT INTO idempotency_key SELECT key, caller, fingerprint, status, created_at FROM idempotency_key_tmp;
+
+DROP TABLE idempotency_key_tmp;
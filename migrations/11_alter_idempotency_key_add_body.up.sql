@@ -0,0 +1,2 @@
+-- the body of responses that aren't empty, like the report of a bulk import or an erasure certificate
+ALTER TABLE idempotency_key ADD COLUMN body TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE consent_record ADD COLUMN deleted_at DATETIME NULL;
+ALTER TABLE consent_record ADD COLUMN deleted_reason VARCHAR(255) NULL;
+ALTER TABLE consent_record ADD COLUMN deleted_by VARCHAR(255) NULL;
+
+CREATE INDEX idx_consent_record_deleted_at ON consent_record(deleted_at);
@@ -0,0 +1,2 @@
+DROP INDEX idx_audit_entry_subject;
+DROP TABLE audit_entry;
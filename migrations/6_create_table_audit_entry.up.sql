@@ -0,0 +1,10 @@
+CREATE TABLE audit_entry (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    timestamp DATETIME NOT NULL,
+    action VARCHAR(255) NOT NULL,
+    actor VARCHAR(255),
+    subject VARCHAR(255) NULL,
+    details TEXT
+);
+
+CREATE INDEX idx_audit_entry_subject ON audit_entry(subject);
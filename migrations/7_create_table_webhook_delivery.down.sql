@@ -0,0 +1,3 @@
+DROP INDEX idx_webhook_delivery_status;
+DROP TABLE webhook_delivery;
+DROP TABLE webhook_outbox;
@@ -0,0 +1,21 @@
+CREATE TABLE webhook_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL
+);
+
+CREATE TABLE webhook_delivery (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    outbox_id INTEGER NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_attempt_at DATETIME NULL,
+    last_error TEXT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (outbox_id) REFERENCES webhook_outbox(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_delivery_status ON webhook_delivery(status, next_attempt_at);
@@ -0,0 +1,7 @@
+ALTER TABLE consent_record ADD COLUMN expiring_notified_at DATETIME NULL;
+ALTER TABLE consent_record ADD COLUMN expired_notified_at DATETIME NULL;
+
+-- records that expired before the scheduler existed are not notified anymore
+UPDATE consent_record SET expired_notified_at = CURRENT_TIMESTAMP WHERE valid_to IS NOT NULL AND julianday(valid_to) <= julianday('now');
+
+CREATE INDEX idx_consent_record_valid_to ON consent_record(valid_to);
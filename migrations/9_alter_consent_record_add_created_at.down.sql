@@ -0,0 +1,30 @@
+DROP INDEX idx_consent_record_created_at;
+DROP INDEX idx_consent_record_valid_to;
+DROP INDEX idx_consent_record_deleted_at;
+DROP INDEX uniq_record_version;
+
+ALTER TABLE consent_record RENAME TO consent_record_tmp;
+
+CREATE TABLE consent_record (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    patient_consent_id VARCHAR(255) REFERENCES patient_consent(id),
+    valid_from DATE NOT NULL,
+    valid_to DATE NULL,
+    hash VARCHAR(255) NOT NULL UNIQUE,
+    version INTEGER DEFAULT 1,
+    uuid VARCHAR(255),
+    previous_hash VARCHAR(255),
+    deleted_at DATETIME NULL,
+    deleted_reason VARCHAR(255) NULL,
+    deleted_by VARCHAR(255) NULL,
+    expiring_notified_at DATETIME NULL,
+    expired_notified_at DATETIME NULL
+);
+
+CREATE UNIQUE INDEX uniq_record_version ON consent_record(patient_consent_id, uuid, version);
+CREATE INDEX idx_consent_record_deleted_at ON consent_record(deleted_at);
+CREATE INDEX idx_consent_record_valid_to ON consent_record(valid_to);
+
+INSERT INTO consent_record SELECT id, patient_consent_id, valid_from, valid_to, hash, version, uuid, previous_hash, deleted_at, deleted_reason, deleted_by, expiring_notified_at, expired_notified_at FROM consent_record_tmp;
+
+DROP TABLE consent_record_tmp;
@@ -0,0 +1,6 @@
+ALTER TABLE consent_record ADD COLUMN created_at DATETIME NULL;
+
+-- the moment records were stored before this migration is unknown, they get the time of the migration
+UPDATE consent_record SET created_at = CURRENT_TIMESTAMP;
+
+CREATE INDEX idx_consent_record_created_at ON consent_record(created_at);
@@ -1,5 +1,9 @@
 // Package migrations Code generated by go-bindata. (@generated) DO NOT EDIT.
 // sources:
+// 10_create_table_idempotency_key.down.sql
+// 10_create_table_idempotency_key.up.sql
+// 11_alter_idempotency_key_add_body.down.sql
+// 11_alter_idempotency_key_add_body.up.sql
 // 1_create_table_consent_rule.down.sql
 // 1_create_table_consent_rule.up.sql
 // 2_alter_consent_record_add_version_uuid.down.sql
@@ -8,6 +12,16 @@
 // 3_rename_resource_to_data_class.up.sql
 // 4_alter_consent_record_make_valid_to_optional.down.sql
 // 4_alter_consent_record_make_valid_to_optional.up.sql
+// 5_alter_consent_record_add_tombstone.down.sql
+// 5_alter_consent_record_add_tombstone.up.sql
+// 6_create_table_audit_entry.down.sql
+// 6_create_table_audit_entry.up.sql
+// 7_create_table_webhook_delivery.down.sql
+// 7_create_table_webhook_delivery.up.sql
+// 8_alter_consent_record_add_expiry_notifications.down.sql
+// 8_alter_consent_record_add_expiry_notifications.up.sql
+// 9_alter_consent_record_add_created_at.down.sql
+// 9_alter_consent_record_add_created_at.up.sql
 // bindata.go
 package migrations
 
@@ -85,6 +99,86 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var __10_create_table_idempotency_keyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x47\x00\xb8\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x69\x64\x78\x5f\x69\x64\x65\x6d\x70\x6f\x74\x65\x6e\x63\x79\x5f\x6b\x65\x79\x5f\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x69\x64\x65\x6d\x70\x6f\x74\x65\x6e\x63\x79\x5f\x6b\x65\x79\x3b\x0a\x03\x00\xab\x51\xef\x6f\x47\x00\x00\x00")
+
+func _10_create_table_idempotency_keyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_create_table_idempotency_keyDownSql,
+		"10_create_table_idempotency_key.down.sql",
+	)
+}
+
+func _10_create_table_idempotency_keyDownSql() (*asset, error) {
+	bytes, err := _10_create_table_idempotency_keyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_create_table_idempotency_key.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1792410577, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __10_create_table_idempotency_keyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x4d\x4b\x03\x31\x10\x86\xef\xf9\x15\xef\xad\x1b\xe8\x49\xac\x97\x9e\x62\x77\xd4\x60\x9a\x4a\x48\xc5\x9e\x42\xd8\x1d\x25\xb4\xae\x25\x8d\xe0\xfe\x7b\x51\x17\x95\xd0\xdb\xc0\xf3\xcc\xc7\x3b\x2b\x47\xca\x13\xbc\xba\x36\x84\xd4\xf3\xeb\xf1\xad\xf0\xd0\x8d\x61\xcf\x23\x1a\x01\x00\x5f\xd5\xa3\x72\xab\x3b\xe5\x9a\x8b\xc5\x42\xc2\x6e\x3c\xec\xd6\x98\xf9\x37\xee\xe2\xe1\xc0\xf9\xbc\x81\x96\x6e\xd4\xd6\x78\xcc\x66\x3f\xf2\x73\x1a\x5e\x38\x1f\x73\x1a\xca\x6f\xc7\xd5\x65\x3d\xf2\x54\x62\x79\x3f\x41\x5b\x4f\xb7\xe4\x2a\xd8\x65\x8e\x85\xfb\x10\x0b\x5a\xe5\xc9\xeb\x35\x55\xc6\x83\xd3\x6b\xe5\x76\xb8\xa7\x1d\x9a\x3d\x8f\xf3\xe9\x46\x29\xe4\x52\x88\x29\xb1\xb6\x2d\x3d\x21\xf5\x1f\xa1\x4a\x1d\xfe\x2d\xd8\xd8\xfa\x27\xcd\x1f\x95\x4b\xf1\x39\x00\x73\xbe\x60\xd7\x3e\x01\x00\x00")
+
+func _10_create_table_idempotency_keyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_create_table_idempotency_keyUpSql,
+		"10_create_table_idempotency_key.up.sql",
+	)
+}
+
+func _10_create_table_idempotency_keyUpSql() (*asset, error) {
+	bytes, err := _10_create_table_idempotency_keyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_create_table_idempotency_key.up.sql", size: 318, mode: os.FileMode(420), modTime: time.Unix(1792410766, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __11_alter_idempotency_key_add_bodyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x52\x3d\x6f\xb3\x30\x18\xdc\xfd\x2b\x6e\x0b\x91\x98\x5e\xbd\xe9\xc2\xe4\xc2\x93\x16\xd5\x98\xc8\x71\xaa\x66\xb2\x10\xb8\x15\x4a\xa0\x88\xb8\x52\xf9\xf7\x15\x69\x54\x90\x9b\x48\x9d\xef\x9e\xf3\x7d\x38\x51\xf9\x06\xa9\x4c\xe8\x05\x75\xf5\x69\xea\xca\x36\xdd\xbb\xb3\x6d\x39\x98\x83\x1d\x4c\xd9\xdb\xc2\xd9\xca\x14\x2e\x62\x8c\x0b\x4d\x0a\x9a\xdf\x0b\x82\x47\x84\x22\xc9\x33\x82\xce\x7d\xc4\xb8\xa6\x8b\x18\x8b\x15\x71\x4d\x37\x8e\x03\x06\x00\xa3\xcc\x33\x57\xf1\x23\x57\xc1\xbf\xd5\x6a\x09\x99\x6b\xc8\x9d\x10\xe1\x19\x2e\x8b\xe3\xd1\xf6\xd7\x19\x48\x68\xcd\x77\x42\x63\xb1\xf8\x26\xbf\xd6\xed\x9b\xed\xbb\xbe\x6e\xdd\xcf\xc5\xdd\x7f\x5f\xf2\xe4\x0a\xf7\x71\x42\x2a\x35\x3d\x90\xf2\xc0\x29\x3a\x12\xae\x49\xa7\x19\x79\x8c\x8d\x4a\x33\xae\xf6\x78\xa2\x3d\x82\x83\x1d\xc2\x8b\xc7\x25\x5b\x4e\x89\xff\xd2\x2d\x72\xe9\x77\x12\x4c\xe8\x28\x96\xca\x2d\x29\x3d\x3a\xfd\x55\x30\xb6\x24\x28\xd6\x98\x19\x08\xe7\xf9\xc3\x4b\xcc\x10\xb3\x07\xd7\x2a\xcf\x6e\x2c\x75\xfe\x11\x57\x77\x32\xae\xe9\x22\xf6\x35\x00\x99\x38\x95\x1a\x32\x02\x00\x00")
+
+func _11_alter_idempotency_key_add_bodyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__11_alter_idempotency_key_add_bodyDownSql,
+		"11_alter_idempotency_key_add_body.down.sql",
+	)
+}
+
+func _11_alter_idempotency_key_add_bodyDownSql() (*asset, error) {
+	bytes, err := _11_alter_idempotency_key_add_bodyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "11_alter_idempotency_key_add_body.down.sql", size: 562, mode: os.FileMode(420), modTime: time.Unix(1792414108, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __11_alter_idempotency_key_add_bodyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xce\x31\x8e\x83\x30\x10\x46\xe1\x9e\x53\xfc\x1d\xcd\x72\x82\xad\xbc\x8b\x53\x39\x20\x45\x46\x4a\x17\x19\x18\x84\x05\xd8\xd6\x78\x28\x7c\xfb\x28\xa1\x7d\xfa\x8a\xd7\x34\x90\x95\x30\xc6\xb9\x20\x2e\x60\xca\x29\x86\x4c\x19\xb2\x3a\x81\x63\x0a\xb5\x80\x8e\x24\xe5\x07\xbb\xdf\xe8\xab\x99\x52\x64\xf9\x78\x87\xf1\xdc\x37\xf8\xe3\x0a\x0c\x17\x40\xec\xf2\xc9\x84\x89\x58\xfc\xe2\x27\x27\x54\x29\x63\xf5\x03\x56\xfd\x19\x0d\x3f\xd3\x91\xa2\x50\x98\xca\x6b\xa3\x02\xd5\xb6\xf8\xef\xcd\x70\xef\xae\x0d\xab\x9f\x16\x5d\x6f\xd1\x0d\xc6\xa0\xd5\x37\x35\x18\x8b\xba\xfe\xad\xde\x03\x00\xea\xd8\xe4\xec\xad\x00\x00\x00")
+
+func _11_alter_idempotency_key_add_bodyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__11_alter_idempotency_key_add_bodyUpSql,
+		"11_alter_idempotency_key_add_body.up.sql",
+	)
+}
+
+func _11_alter_idempotency_key_add_bodyUpSql() (*asset, error) {
+	bytes, err := _11_alter_idempotency_key_add_bodyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "11_alter_idempotency_key_add_body.up.sql", size: 173, mode: os.FileMode(420), modTime: time.Unix(1792414108, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __1_create_table_consent_ruleDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x28\xcd\xcb\x2c\x8c\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\xb5\xe6\x02\xcb\x84\x38\x3a\xf9\xb8\x2a\xa0\x09\xa2\x28\x4f\xce\x2f\x4a\x41\x51\x9c\x9c\x9f\x57\x9c\x9a\x57\x82\x2a\x85\xa4\xa5\x20\xb1\x24\x13\x24\x0f\x55\x87\xa2\x17\x43\x0e\x10\x00\x00\xff\xff\x55\xac\xed\x91\x9f\x00\x00\x00")
 
 func _1_create_table_consent_ruleDownSqlBytes() ([]byte, error) {
@@ -245,7 +339,207 @@ func _4_alter_consent_record_make_valid_to_optionalUpSql() (*asset, error) {
 	return a, nil
 }
 
-var _bindataGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x9a\xed\x6f\x1b\x47\x92\xc6\x3f\x93\x7f\xc5\xac\x80\x0d\xc8\x83\x4f\x9a\xf7\x17\x01\x06\x0e\x9b\xe4\x80\x7c\xb8\xec\xe1\x92\xfd\x74\x7d\x10\xa6\x67\x7a\x14\x62\x25\x51\x21\xa9\xa4\x95\xc0\xff\xfb\xe1\x57\x55\xa3\x17\xdb\x6b\x53\xb6\x77\x17\xfb\xc1\x26\x39\x9a\xa9\xae\xae\xae\x7a\x9e\xa7\xba\xe7\xec\x2c\xf9\xef\x7e\xf8\x6b\x7f\x19\x92\xeb\xcd\xe5\xae\x3f\x6c\xb6\x37\xfb\xe4\xeb\xed\x18\x92\xcb\x70\x13\x76\xfd\x21\x8c\x89\xbf\x4f\x2e\xb7\xff\xee\x37\x37\x63\x7f\xe8\x4f\x93\xd5\x7f\x3c\xfc\x69\x9d\x7c\xf3\xe7\xe4\xfb\x3f\xff\x98\x7c\xfb\xcd\x77\x3f\x9e\x2e\xcf\xce\x92\xfd\xf6\x6e\x37\x84\xfd\x39\xdf\xb3\x8b\x61\x17\xfa\x43\xb8\x38\xf4\xfe\x2a\x5c\x0c\xdb\x9b\x7d\xb8\x39\x5c\xec\xee\xae\xc2\xe9\xb8\xfd\xf5\xe6\x74\xff\xf3\xd5\xc7\xee\xbb\xbb\x9d\xef\xca\x2f\xfa\xab\x43\xd8\x3d\xfe\x39\x0c\xdb\xdd\x78\xd1\x8f\xe3\xc5\x2f\x61\xb7\xdf\x6c\x6f\x2e\xee\xee\x36\xe3\x33\xcb\xc7\x3e\xf3\x38\x4a\x71\xb1\x0b\x37\xfd\x75\xb8\xd8\x05\x9d\xca\xc5\x61\x7b\xc1\xbc\x2f\x86\xab\x7e\xbf\x7f\x66\xfd\x63\xf7\x3e\x5a\x2d\xdf\xef\xc7\x75\xff\xd7\x70\xf1\x4b\x7f\xb5\x19\x79\x72\x7b\x4b\xf4\xfb\xab\x67\x63\xbc\xec\xc9\xc7\x11\xe7\xd5\xba\xdc\x2e\x6f\xdf\x59\xe0\xe5\x72\x73\x7d\xbb\xdd\x1d\x92\xd5\x72\x71\xe2\xef\x0f\x61\x7f\xb2\x5c\x9c\x0c\xdb\xeb\xdb\x5d\xd8\xef\xcf\x2e\x7f\xdb\xdc\x72\x61\xba\x3e\xf0\xb1\xd9\xea\xff\x67\x9b\xed\xdd\x61\x73\xc5\x8f\xad\x3c\x70\xdb\x1f\x7e\x3a\x9b\x36\x57\x81\x2f\x5c\xd8\x1f\x76\x9b\x9b\x4b\xf9\xdb\x61\x73\x1d\x4e\x96\xeb\xe5\x72\xba\xbb\x19\x66\x6f\xfe\x27\xf4\xe3\x8a\x2f\xc9\xff\xfe\x1f\xc3\xbe\x4a\x08\x5f\xa2\x8f\xad\x93\xd5\x7c\x35\xec\x76\xdb\xdd\x3a\xf9\x7d\xb9\xb8\xfc\x4d\x7e\x25\xe7\xaf\x13\xbc\x3a\xfd\x3e\xfc\x8a\x91\xb0\x5b\x89\xdb\xfc\xfe\xd3\xdd\x34\x85\x9d\x98\x5d\xaf\x97\x8b\xcd\x24\x0f\xfc\xe1\x75\x72\xb3\xb9\xc2\xc4\x62\x17\x0e\x77\xbb\x1b\x7e\xbe\x4a\xa6\xeb\xc3\xe9\xb7\x58\x9f\x56\x27\x18\x4a\xfe\xf8\xf3\x79\xf2\xc7\x5f\x4e\xd4\x13\x19\x6b\xbd\x5c\xbc\x59\x2e\x17\xbf\xf4\xbb\xc4\xdf\x4d\x89\x8e\xa3\x83\x2c\x17\x17\xea\xce\xeb\x64\xb3\x3d\xfd\x7a\x7b\x7b\xbf\xfa\xca\xdf\x4d\xaf\x92\xcb\xdf\xd6\xcb\xc5\x70\xf5\xed\xec\xe9\xe9\xd7\x57\xdb\x7d\x58\xad\x97\x5f\xca\x1f\xcc\xa8\xfd\xbf\x61\x28\xec\x76\xea\xb7\x5d\xf4\x77\xd3\xe9\x9f\x70\x7d\xb5\x7e\xc5\x1d\xcb\x37\xcb\xe5\xe1\xfe\x36\x24\xfd\x7e\x1f\x0e\x84\xfc\x6e\x38\x60\x45\xe6\x67\xeb\xb1\x5c\x6c\x6e\xa6\x6d\x92\x6c\xf7\xa7\xff\xb9\xb9\x0a\xdf\xdd\x4c\xdb\x87\xe7\x6c\x09\xe7\xeb\x4f\x2c\xc8\x1a\x26\x89\x2d\xe3\x72\xb1\xdf\xfc\x26\xbf\x37\x37\x87\xba\x5c\x2e\xae\x01\x93\xe4\xc1\xe8\x7f\x6d\xc7\x20\x17\x7f\xdc\x5c\x87\x84\x34\x39\xe5\x1b\xe3\x9c\x9d\x25\xdf\x63\xcb\xa6\x40\x66\x49\x18\x34\x87\x56\xd3\xe6\x6d\x27\xd6\x72\xff\x6a\x6d\x43\xe3\xcc\xc3\xb3\xa7\xf2\xa4\x5a\xfd\x01\x8f\x9e\x5a\xc5\xc5\x0f\x58\xe5\xfe\xd5\x5a\x27\xf0\xdc\xa8\x3c\xa8\x46\x99\xc8\x33\xa3\x4c\xf4\x03\x46\xb9\x7f\xb5\x7e\x1a\x86\xe7\xa6\xe5\xf1\xbf\x6d\x7a\x33\xdd\x4b\xb4\x3e\x3c\x02\xa1\x5c\xad\x1f\xc3\xfa\xce\x10\x4f\x62\xfd\xdd\xfe\x9b\xcd\xee\xd9\x30\xbf\xfe\x14\x0e\x3f\x85\x5d\xd2\x27\xe3\x66\x17\x86\xc3\x76\x77\xff\x81\xe1\xe4\xf9\xd5\x3a\xf1\xdb\xed\xd5\xbb\x53\xf9\x6a\xbb\x3f\x65\x1e\x8c\xf1\x87\xd7\x49\x3a\x2f\xc5\xfd\xfe\xd9\x90\x9b\x7d\xb2\xbf\xdf\x7f\x2c\x76\x3f\xdc\xef\x75\x3d\xc2\x6e\xea\x87\xf0\xfb\x9b\x27\xe3\x59\x72\x53\xaf\x17\x17\x1f\xa0\x92\x6f\xb6\xbf\xde\xfc\xf0\xf3\x55\xf2\xda\x92\x7d\x75\xe2\x62\x36\xb9\xd8\x7a\x17\xd3\xd6\xc5\x34\x7d\xff\xbf\x69\x72\xb1\xc9\x5d\x4c\x3b\x17\x27\x3e\x27\x17\x2b\xae\xf3\xaf\x74\xb1\x29\x5c\x6c\x32\x17\xdb\x51\xaf\xe7\xad\x8b\xc3\xe8\xe2\xe0\x5d\xcc\x07\x17\xdb\xc1\xc5\x7c\x72\xb1\xec\x5d\xcc\xb9\x1e\xf4\x37\xdf\xb9\x56\x06\x17\x7d\xe5\x62\xa8\x5d\x4c\x73\x7d\xae\x2d\x5d\x2c\x5a\x17\x8b\xde\xc5\xa9\x73\xd1\xb7\x2e\xe6\xbd\x8b\x7d\xaa\x7e\xf4\xb9\x8e\x53\x4e\x8f\xf6\xc4\x56\xe6\x62\x95\xb9\xd8\x0d\xf6\x0f\x5f\x1b\xfb\xde\xeb\xf7\x36\x57\x5b\x6d\xe5\x62\x5f\xba\xd8\x57\x2e\xe6\xa9\x8b\x3e\x73\x31\x2f\x5d\xcc\x0a\xfd\x94\x79\x56\x2e\xb6\x8d\x8e\x97\x35\x2e\x96\x85\x8b\x69\x70\x31\x7b\x2b\x3e\x93\xdd\xdb\x0f\x2e\x86\xd1\xc5\x2e\xd3\xb1\x1f\xe3\x78\x32\x53\xc1\x11\x4b\x64\xb8\xf5\x3e\x3e\x98\xd1\xed\x09\x9f\x2c\x17\x8b\x63\xd6\xfd\xd5\x72\xb1\x38\x39\x46\x91\x9c\xbc\x5a\x2e\xd6\x64\xd4\xb1\xee\xe2\xe9\xbf\x09\xac\x3e\xf5\x54\x70\xf5\x81\xbc\x8e\x9f\xf5\xc7\x18\xe3\x01\xe8\x05\xaa\xcf\x5f\xbf\x5d\x2c\xbf\x83\x7b\xe7\xc9\x91\x53\x15\x24\x3c\x4f\xb2\xaa\x7b\x25\x35\x78\xfe\x14\x9f\x56\x65\x9e\xae\xe5\x3a\xa8\x71\xae\xa8\xf2\x97\x9b\x4d\x5c\x65\x55\x93\xa5\x65\x96\xa5\xe9\xab\x24\x5d\xbf\x59\x2e\x7a\x3c\xf9\x4a\x82\xf0\xbb\xcc\xfc\x3c\xb1\x00\xe0\xe6\xb9\xfc\xff\xe6\x61\xf9\xfa\x57\x47\x57\xed\x5f\x6e\x3f\xb5\x66\xa9\xa1\x2e\xd7\x5a\xac\x83\x8b\xbd\x77\xb1\x48\x35\x77\xc9\xfd\xa9\x71\xb1\x18\x5c\x1c\x2a\xad\x53\x6a\xa7\xce\x5d\x6c\x2a\xad\x09\x72\xd9\xe7\x5a\xf3\xdc\x5f\x76\x2e\xb6\xbd\xd6\x59\x18\x5c\x1c\x6b\x17\x8b\x4c\xf3\xde\x17\x2e\x96\xd4\x48\xe9\x62\x65\x36\xa5\x76\xbc\x8b\x55\xa1\xe3\xf2\x7b\xf2\x2e\x4e\xb5\x7d\xa7\xa6\x3b\x17\x87\xcc\xc5\x31\x75\xb1\x29\xb5\x76\x8a\x42\x6b\xae\x9e\x5c\xf4\xd4\x76\xe6\x62\x48\x5d\x2c\x99\x6f\x69\xf5\x6b\x73\xac\x0b\x9d\x27\x63\xd4\xb5\x8b\x5d\x69\x73\x19\xb5\xde\x83\xd7\x39\x74\xb5\xce\x29\x65\xac\x46\x3f\x89\x13\x78\x95\x56\x1a\x07\xe2\x92\x05\x17\x47\xfb\x1e\x82\x8b\x53\x70\xb1\xf2\x2e\xf6\xa3\xde\x03\x0e\x85\xc2\xc5\x11\xbc\x0b\x8a\x81\x69\xa1\xcf\x84\x5c\xe7\x82\xdf\x5c\x23\x8e\x53\xa1\x18\x99\xe7\x8a\x93\xcc\x0d\xdf\xc0\xa8\xbc\x76\xb1\x4d\xf5\x13\xfc\xc4\x46\x59\x69\xbc\x7b\x62\x5b\xbb\xe8\x7b\xf5\xb1\xea\x5c\x6c\x3a\xc5\x4c\xe6\x18\x58\x8f\x5a\xf1\x0c\xbc\x64\x3e\x43\xa3\xbe\x16\x5e\x31\x75\x98\x34\x06\xc4\x36\x80\xa5\x41\xd7\xb8\x6d\x5d\xac\x5b\xfd\x6c\x27\xfd\xbb\xd8\xec\x5d\x2c\x46\xc5\x62\xdf\xb9\x98\x75\xfa\x9d\x31\xc4\x3f\xaf\x79\xd4\x80\xb5\xc4\x1f\x1f\x1a\x5d\x0b\xe6\xcc\xb3\xc2\x07\xb5\x61\x6b\xaf\x6b\xec\x4b\x5d\xf7\x31\x57\x5c\x6c\xc8\xc5\xcc\x70\x9d\x98\x75\x2e\x76\x9d\xfa\x98\x91\x3b\xb5\x8b\xb9\xb7\x6b\x5e\xed\x64\xf8\x3c\xda\x9c\x4b\xcd\x87\xac\x56\x1b\xac\x2b\xb9\x81\x1f\x6d\xe7\x62\xcd\xdf\x58\xdf\x51\x39\x84\x75\xec\x52\xcb\xdd\xa0\xb9\xc5\x1a\xd4\xcc\x89\x3a\xc9\x74\x4d\x53\x62\xde\xa9\x9f\x52\x43\x8d\xae\x57\x6e\xf9\xda\xb4\x2e\x56\xa3\xc6\x99\x79\x93\xc7\xf8\x86\x5d\xe6\xed\xed\x59\x62\x41\xfe\x74\x41\xf3\x81\xb5\x1c\x72\x17\xbd\xd7\x58\xf6\x85\x8b\x5d\xe3\xe2\x18\x34\x87\x64\x6d\x47\xad\x1b\xf2\x9d\x1c\x20\xc6\xe4\x06\xf3\xcf\x1b\x17\x03\xf7\x34\x9a\xe3\x63\xa9\x71\x0b\x36\x46\xc3\xba\x34\x3a\x0e\xbc\x56\xcd\x71\x63\xdc\xde\xc5\x1a\x6e\x9d\x74\xcd\xe1\x53\x30\x81\x71\xf9\x1b\xbf\x59\x1f\x6a\xac\xb3\x9a\xe9\xc9\x1b\xf2\xd6\xb8\x4c\x6a\xb1\xd7\x6b\x99\xd7\xf5\xa1\xee\xc7\x5e\xe7\x4b\xed\x13\x4b\xfc\xab\x6a\xcd\x23\xf2\x86\xb5\x4e\xbd\x62\x00\xb1\xf2\xd8\xaa\x15\xb7\x88\x01\x31\x02\x6f\xd0\x07\xf0\x3e\x3e\x48\x5d\x7b\x9d\x3b\x75\x4d\x1c\xfa\xa0\xb1\x82\xe3\x87\x42\xf3\x04\x6e\x66\x1c\xf1\xd1\x6b\x9d\x82\x1f\xac\x39\xf1\xc7\xef\x06\xbb\xcd\xbb\x3c\x3d\xa4\xca\xe1\xac\x2b\x38\x26\x58\x5a\xbc\x88\xa7\x05\x94\xbf\x2c\x4b\x8b\xc9\x8f\x72\xb4\xf6\xb7\xc7\x33\xb4\x58\xfd\x5c\x7e\x7e\x3a\xdb\x7f\x04\x3b\xcf\x93\x34\x6e\xee\xd2\xe6\xa5\xdc\x5c\xe5\x5d\x5b\x65\xcd\x17\xe1\xe6\x23\xb7\x50\x3e\x47\x5d\xd7\x83\x56\x0b\xe8\x8c\xa2\x6d\x0b\x45\xd8\x99\xa9\xa9\x5e\xaa\x02\x54\xa4\x12\x40\xb7\x2c\x57\x34\x23\x8b\x41\x5e\x10\xba\xea\xb5\x12\x05\x31\xbd\x7d\xef\xb4\x6a\xa8\x34\xc9\xf6\x4e\xd1\x30\x0f\x8a\x10\x1d\xa8\x44\x45\xb6\x5a\x75\xd3\xa8\xff\x40\x43\xaa\x1e\xa4\xcc\x2b\x45\x99\xc9\x2a\x71\x00\xa9\x06\x45\x48\xa9\xa2\x4a\xed\x53\x6d\xa0\x31\x6c\xdc\xf4\x8a\x94\xa0\x42\x68\x14\xd9\xa8\x58\x90\x7f\x66\x0a\xe6\x09\xb3\xe6\x56\xb1\xa8\x04\x18\x8f\x7b\x89\x1b\x73\xc7\x57\x98\x00\x74\x00\x3d\x88\x13\xac\x05\x3b\x85\x59\x39\x34\xaa\x04\x60\x28\xfc\xf5\x93\x32\x2e\xf6\x6a\x63\x17\x54\x7d\x39\xa8\xca\x07\x6d\x60\x2f\x10\xa3\x30\x3f\x51\x2f\xa8\x7e\xee\x11\xa6\x4a\x15\x7d\x8a\xf2\x51\xed\x83\xb8\xa8\x0b\xd8\x12\x76\x66\x0e\x65\xa9\x08\x96\x8d\xaa\xb0\x40\x71\x18\x3c\x33\xc4\x02\x5d\xa5\x83\x18\xd4\x36\xf3\x67\x5c\x18\x01\x3b\xa0\x2a\xb6\x50\x14\x74\x19\xa8\x8c\xa2\xd1\xeb\xac\xcd\x60\x4a\xa8\xf6\xda\xc5\xa0\xac\xb8\x1e\x4a\xfd\x27\xec\x91\xab\x12\xf1\xa9\x8b\x63\xa7\xc8\x09\x0b\x82\xd0\xe4\x09\xb6\xc8\x15\xd6\x18\xff\x4a\x43\x3c\x58\x43\x10\xd5\x2b\x23\x70\x1d\xc5\x02\xdb\x90\x93\xe4\x01\x48\x2f\x0a\xa6\xd1\x6e\x09\xb4\x17\xd5\x37\x2a\x2b\x07\xeb\xcc\x50\x21\x30\x2d\xcf\x8e\x33\xe3\xb7\x1a\x6f\xd6\xaa\x35\xf4\x25\x97\x47\x63\x66\xe9\xe8\x60\xbe\xa0\xb9\x8d\xaf\xe4\xac\x74\x8d\xa5\xae\x03\xb9\x87\x8d\x79\x0d\xb9\x1f\x56\x91\x5a\x31\x95\xc2\x3d\xe4\x2b\x4c\x8e\x7f\xe4\x08\xfe\xa2\x54\x89\x35\xf3\x44\x39\x4d\xbd\x32\x3a\xe3\xf0\xbb\xb6\x8e\x8e\xb9\xc2\xde\x43\x6b\xb9\x5f\xd9\xda\x4d\x1a\x8f\xd1\xf2\x9f\x3c\x2f\xa6\x47\xf5\xf8\x94\x55\x88\x21\x6b\x3a\x94\xaa\xe4\x46\xff\xf4\xbe\x47\x56\x79\x19\x9c\x7c\x02\xc7\xbc\x6c\x00\x61\x9c\x97\xee\x2c\xbf\xc5\x3f\x2f\x1b\xf1\x28\x36\xfa\xa4\x28\x7d\x29\x6e\x7a\x79\x38\x8c\xa9\xca\xa6\xfa\x17\x60\xaa\xcf\xe8\x28\x07\xad\x63\xea\xbf\x9d\x77\x69\x9e\xf0\x14\x35\x02\x66\x0a\x46\xa1\xc6\x7a\xc5\x36\xc1\xe7\x54\x95\x68\x51\x69\xdd\x85\x5a\xf1\x42\xb0\x6a\x70\xb1\x2e\xb5\x16\x51\xb6\xfc\xce\x6d\xd7\x45\x3a\x36\xdb\x91\xa1\xfb\x80\x67\x26\xc3\x26\xd9\x61\x0a\x8a\x8d\xe0\xbf\xd8\xef\x14\xdb\xc0\x19\xfc\x41\xe1\x63\x17\x15\x49\x97\x40\xf7\x48\x9d\x32\x67\x30\x11\xff\xe1\xaa\xac\xb1\x1d\x2c\xaf\xd8\x85\xbf\x74\x1b\xdc\x07\xc7\xc1\xc7\x28\xdf\x3e\x55\x8c\xa3\x13\x84\xa7\x88\x19\x38\x07\xb6\xd3\x51\x49\x57\xec\xad\xc3\xaa\x0c\xd3\x27\xeb\xc4\xad\x13\x63\xac\xc2\x3a\xab\xc9\x3a\xe3\xda\x78\x92\x58\xd3\x15\x83\xe3\x69\xa7\x73\xce\x5b\xc5\x7c\x62\x4b\x5c\x27\xeb\x06\x78\x2e\xb3\x67\x45\x33\x58\x87\xcf\xbd\xf0\x1b\x76\x50\xde\x93\xa9\x6d\xb1\x5f\x28\x97\x12\x33\x62\x0a\x1f\xc1\x95\xf0\x28\xbe\xa2\xd2\xe1\x7d\xe2\x84\x9a\x9f\x39\x99\x18\x0a\xcf\x60\x2f\x53\xee\x95\xae\xc7\x6b\xc7\xc7\x7a\xa1\xaa\x51\xe0\xf8\x57\xe4\xca\x79\xdc\x4f\x27\xcc\x5a\x4b\x97\x08\x8f\x55\xfa\x5d\xf4\x41\x6e\xbb\x74\xbd\xf2\xc6\x30\xbd\x9b\x77\xb2\xbb\x96\xeb\x7a\x0c\xd6\x75\x66\xfd\x67\xe1\xeb\xa7\x2a\xf8\x97\x98\x7f\x11\xb6\xbe\x57\xd9\xbf\x64\xb4\x2f\x89\xab\x7f\x0f\xc5\xff\xd2\x40\x18\xa6\xe6\x6d\xfe\xcf\xc4\xd4\x8f\x1c\x71\x7e\x8e\xea\x97\xfd\xb9\x4c\xd5\x4d\x69\x48\xf8\xa0\xfa\x6b\x45\xa1\x66\xd4\xfd\x05\x2a\xd9\x9b\xb2\xa3\x6f\x47\xf9\xa1\xf2\x51\x9c\xa3\x29\x70\xaa\x9b\x8a\x0e\x56\x79\xa9\xa9\x68\x94\x65\x6d\x15\xdc\xd5\xaa\x7c\x40\x4d\x50\x1c\x34\x03\x95\x40\x11\xd0\x8c\xea\x47\x59\x51\xe1\xa0\x64\x69\x2a\xbb\xb3\x4a\xc7\xef\xd2\xf6\x29\xa4\x82\xad\x9f\x47\xe5\x82\x2c\xc5\xbc\x97\xe1\x15\x41\x98\x07\x4a\x0f\x14\x10\xff\x32\x55\xcc\x28\xb3\xac\xb4\x7b\x1b\x45\x45\xec\xe3\x13\x68\x22\x7b\x0d\x93\x75\x2d\xad\x2a\x68\xe6\x39\x23\x05\xca\x11\xd4\xc5\x37\xc6\xc7\xcf\xae\xd1\xb9\xe0\x17\xf7\x73\x6f\x11\x94\x01\xf2\x42\xbb\x8e\x7a\x1e\xa3\x51\x3b\xa0\xb2\xa0\xe5\xa8\xfb\x52\x20\x4b\x6d\x6a\xbe\x31\x95\x2b\x7b\x87\x9d\xde\x0f\x3b\xc9\xde\x58\xa5\xfe\x97\xb6\xef\x02\xd3\xd0\x11\xe5\xd6\x51\x0d\x99\xce\x07\x65\x4a\x07\xd1\xd9\x3e\x19\x7e\xd1\x65\x81\x92\xac\x5f\x61\x68\xd7\xd9\xbe\x69\x6a\x4a\x95\x0e\x8a\xd8\xa1\x54\x59\x47\xd8\x51\x94\xac\x75\x2d\xa8\x5d\x5f\x29\x9a\xc2\x0e\x7c\xe7\x13\xa5\x8a\x1d\xba\x08\xbe\xb3\xbe\xac\x03\xaa\x17\xff\x40\x79\xfc\x2f\x2c\xb7\xa6\x56\x73\xa4\x0b\x9a\x43\x28\x6b\xae\x49\x9e\x94\x9a\x0f\x7c\xef\x7a\x45\xf8\xac\xb6\x0e\xc2\xf6\x2b\xe9\x28\x61\x28\xd6\x10\x9b\x28\x6c\x6f\xdd\x0b\x6b\xd4\x99\x42\xe0\x1e\xe2\x31\x2b\x03\xba\x2d\x61\x66\xdb\xa3\xe2\x3a\x6b\x55\xa5\xd6\xc5\x0d\xca\xc6\xe9\x64\x79\x66\x1d\x03\x5d\x55\x67\x35\x80\xd2\xe6\x19\x98\x9d\xae\xd6\xcf\x6b\x6e\xeb\x2f\xeb\x35\x2a\x6b\xa2\xe8\xdf\x66\x14\x58\x2a\xb5\x3d\x38\xe9\x56\xb3\xf7\x33\xca\x71\x10\xf0\x09\x5c\x72\x9c\x61\x61\x91\x63\xdf\xca\x78\x8b\x3f\x8e\x1b\xe1\x28\xe6\x78\x51\x14\xbe\x14\x67\x1c\x3f\xed\x59\x81\x97\x2f\x3e\xc7\xa9\xab\xae\xaa\x8a\xea\x1f\xc1\x16\x9f\xae\xbc\x1b\xe3\x8a\xe1\x09\x57\x14\x4f\xb8\x42\xd4\xae\x71\x45\x61\xfb\xea\x72\x5e\x9b\xa9\x92\xe5\x77\xdf\x28\x06\x8e\x76\xc6\xe0\xad\x13\x6e\x6c\xc7\xa8\xb4\xce\x9e\x5a\x80\x2f\xf0\x49\x14\x9e\xed\xab\x4b\x6d\x54\x86\xe5\x56\xa7\x99\x57\x95\x28\xbc\x33\x68\x2d\x0e\x41\xf1\x12\x95\x2c\xbe\x57\xba\xcb\x52\xd9\x2e\x46\x5a\xea\xce\x44\x6b\xe7\xb4\x70\xc9\x30\x77\xf4\xa3\x29\xe3\x5a\xaf\xcb\x59\xc1\xa4\x73\x6c\x4d\xa9\x96\x95\xce\x05\x9f\x67\xf5\x89\xaf\x61\x54\xae\x80\x37\x2b\xdb\x15\x22\x26\xb2\xb7\x3f\xd9\xde\x70\xa5\x7e\x7a\xc3\x62\x30\x04\xcc\x03\x17\xf1\x8b\xb9\x8f\x76\x0e\x16\xec\x5c\x1a\xbe\x02\x5b\x98\x6f\xb0\x3d\xec\xd1\xf6\xd2\xe1\x5a\x94\xee\x60\x67\x51\xf8\x0e\xd7\xf4\xc6\x11\x82\xdb\xb6\x17\x0f\xb6\x7b\x3b\xe3\x11\xe5\x3f\x6a\x77\x04\x36\x0b\x47\xd8\x39\x34\x5d\xc3\xcc\x11\xac\x27\xf9\x81\x0d\x34\x00\x63\x3e\xe3\x88\x5c\xcf\x4c\x9e\x71\x84\xed\x30\xa5\xb9\xfa\x4f\x27\x21\xcf\x56\x1a\x2f\xd9\x75\x1a\x34\x17\xe0\x25\xe1\x88\x41\x77\x57\xbc\xed\x7e\x79\xdb\xa5\x6b\x06\xc5\x74\x7c\xe3\x5e\x38\x82\xef\x7c\x4e\xc6\x6d\xa2\x15\x3a\xed\x42\xe4\x9c\xc0\xce\xb3\xc8\x17\x3e\x3b\xdb\xb5\x11\x7b\x5e\xed\x88\xfe\x31\xbc\x67\x5d\xe7\x73\x9f\x2a\xb3\x9a\xb0\xee\x90\x38\x8f\x8d\xf2\xbc\xbc\x1f\x40\x3e\xa5\x3a\x1e\xb9\x41\x27\xc5\xe7\x64\x7e\x92\x07\xcc\x8f\xb8\x3d\x9c\xa9\xe4\x7a\xb6\x41\x07\x41\x1e\x63\x93\xfc\x99\xec\x0c\x52\xce\x62\x32\x5b\x43\x8b\xad\xb7\xce\xf1\x7d\x3b\x3d\x8d\x75\x7d\x74\x20\x74\x7c\x43\xf1\x49\xbc\xf1\xa9\x1d\xc8\x31\x66\x8f\xe2\x8c\xf7\x76\x1c\xc7\x58\xff\x12\x7c\xf1\xf7\xe8\x30\x8e\x9d\xf0\xcc\x15\x55\xf6\xcf\xe4\x8a\x17\xbd\xd8\xf8\x39\x7d\x06\xbd\x40\x65\xbb\x03\xa2\x8d\xf3\xc7\x3e\x03\x8c\x9d\x0a\xdd\x7d\x15\xed\x1a\x14\x83\x44\x0b\xd9\x6e\x38\x5a\xb2\xe9\x14\xb3\xe9\x09\x2a\xd3\xdb\xb5\xf5\x2e\xe4\x3f\x98\x81\x3e\xcf\x8c\x43\xd0\x77\xc1\xce\x4e\x33\x7b\xbf\x40\x6a\xd7\xfe\xc9\x79\x5b\xab\x38\x2f\xef\xe0\x4c\xfa\x49\x8f\x00\x5e\xc1\x67\x85\xe9\xd4\xc2\xce\x79\xe1\x0e\x6a\xdf\xb7\xaa\x0d\x73\x7b\x0f\xa9\x37\x3e\x03\x5f\x06\x3b\xa7\x9c\x75\xfb\x60\xb5\x2d\xef\x19\x74\x8a\xf5\x9d\x9d\x19\xcb\x2e\x72\x65\xa7\x24\x41\x63\x92\xda\x19\x3e\xd8\x53\xd9\x2e\xf4\x6c\x0b\xed\x5b\x5a\x1f\x40\x9c\x32\xc3\x07\xd9\x4d\x69\x54\x9b\xb6\xb6\x13\x9f\xda\x8e\x4c\xdf\xda\xbb\x05\x83\xed\x30\xf5\xca\x2d\x59\xa6\xbc\x31\x9f\x3d\x57\xb6\x0b\x34\xe3\x48\x97\x6a\x1c\x0b\xdb\xb1\x87\x6f\x99\x5f\x66\xbd\xdd\x60\xdc\x3e\x9f\x8b\xf3\x77\x9e\x2d\xac\x07\x92\xf8\x67\x1a\x3f\x74\x3a\xbc\x09\x97\x63\x97\x79\x8b\x2e\xb6\x77\x0a\xf8\x3b\xf3\xf3\xd6\xaf\xc1\x9b\xe0\x7a\x61\xbf\x65\x27\xbd\xd6\xf5\xc5\x7f\x74\x88\x9c\x23\x7b\xc5\xcc\xc2\x78\xab\xb2\x71\x88\x07\x7c\x29\xfa\xc4\xde\x3b\x90\x1d\xae\xf9\x9d\x92\x4a\xe3\x01\x06\xcb\xbb\x52\x5e\xf9\xa7\x2f\x14\xeb\xe5\x04\xc4\xce\x80\xe5\x94\xc1\x6b\x8c\x64\xb7\xcd\xde\xd1\xf0\xbd\x9d\x36\x34\x8f\x27\x3c\x72\x36\x6f\xe7\xbb\xf2\x0e\x81\xad\x31\xd7\x53\xe3\x21\xee\xe3\x7e\xfc\xa2\x26\xc8\x5d\xb8\x10\x2c\x0f\x96\x8b\xe9\xac\x11\x1a\xed\x4b\xe4\xd4\xab\x57\x1d\x20\x3a\xa2\xd7\x1e\x4a\x76\xd5\x5a\xd5\x00\x9d\xf5\x1f\xac\x85\xb7\x77\xe4\xe4\xf4\x25\xb3\xf7\x50\x46\xcd\x5b\x34\x4b\x6a\x3c\x87\x4e\x6a\xcc\x0e\xfd\x7c\x6e\xa7\x2b\xbd\xf1\x8a\xf4\x55\xa5\xf6\x99\xc1\xf8\xab\xb6\x53\x26\x79\xaf\xa4\x52\xce\x1b\x6c\x67\x8f\x7c\xc3\x17\xf4\x1d\x5a\x25\xb7\x53\x2e\x62\xc1\xdf\xe4\xe4\xb0\x53\x2e\x46\x9b\xd1\xe3\x66\xe1\xf1\xbd\x09\xfc\xa5\x8e\xa4\xc6\x2a\xc5\x07\xd1\x06\x5e\xf3\x83\xf5\x20\x4e\x85\xed\x8c\xca\xae\x5e\xa7\x78\x40\xbd\xb2\x4e\xa3\x9d\x4e\x31\x87\xc6\x34\x1e\x7a\x55\xea\xd9\xf4\x12\x3d\x37\xba\x42\xce\xe9\x4b\x7b\xa7\x20\xd3\x39\x11\x5b\x74\xab\x9c\xf5\x7b\x7b\xaf\x30\xb7\xd3\xc3\xca\xfa\xc4\xc6\x4e\xcc\x2a\xe5\xe8\xc9\x34\x1f\xb8\xd1\x99\x3e\x85\xd3\x89\xf7\x64\x7e\xcd\x7a\x58\xea\xb1\xb4\x3c\x23\xff\xff\x3f\x00\x00\xff\xff\x92\x6d\xc9\xe0\x00\x30\x00\x00")
+var __5_alter_consent_record_add_tombstoneDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x52\xcd\xce\x9b\x30\x10\xbc\xfb\x29\xf6\x48\x24\x2e\xad\x94\x13\x27\x17\x36\x8d\x55\x30\xa9\x31\x6d\x73\xb2\x50\x4c\x15\x4b\x09\xa4\x60\xa2\x3e\xfe\x27\xf3\x97\x84\xf0\xdd\x2c\xef\xce\xcc\xce\xec\x46\x22\x3d\x00\xe3\x11\xfe\x01\xa3\xff\xab\x53\x5d\xb5\x65\x65\x55\x53\x9e\xea\x46\x2b\x5d\x5e\x4a\x5b\x6a\x55\xd8\x80\x3c\x75\x76\x95\xf9\x37\xb5\xdc\xcb\xa6\x35\x75\x15\x10\x42\x63\x89\x02\x24\xfd\x16\x23\xbc\xf2\x80\x40\x4e\x13\x04\x99\x2e\x0a\xca\x5e\x6f\x01\x21\xa1\x40\x2a\x71\x1d\xea\x11\x00\x00\xa3\x81\x71\x89\xdf\x51\xc0\x41\xb0\x84\x8a\x23\xfc\xc0\x23\xd0\x5c\xa6\x8c\x87\x02\x13\xe4\xd2\xef\x3b\x6f\x85\x35\x4e\x79\xa2\x31\x1a\x7e\x51\x11\xee\xa9\xf0\xbe\x6e\xb7\x1b\x10\xb8\x43\x81\x3c\xc4\x6c\xd9\xea\x19\xbd\x19\x38\xee\xc5\xc5\x68\xf5\xb7\xa9\xaf\x10\xb9\xc1\x78\x2a\x81\xe7\x71\xfc\x5c\xb5\xf5\x58\x9b\xff\xcf\x45\x7b\x7e\xd5\x9a\x70\x90\x73\xf6\x33\xc7\x11\x3e\x04\x36\xfb\x89\x70\x47\xf3\x58\xc2\x97\xa1\xdc\x75\x8b\x89\x47\x5b\x4d\x79\x37\x75\xd7\xaa\x37\x15\xb2\x79\x24\x38\xe8\x7c\xbe\x25\x48\xf9\x22\x5f\xef\x3d\x2f\xbf\x9f\xc1\x9f\x06\x75\xf4\x8c\x67\x28\xa4\x1b\x79\xb9\x41\xc8\x30\xc6\x50\x82\x03\xac\x51\x3d\xa2\x9c\xde\xb6\xf6\xc1\x99\x98\x05\x26\xbd\x57\x8b\x3b\x91\x26\x0b\x2d\x77\x2d\xf0\x7b\x8f\x02\xe1\x71\x98\xc0\xb2\x7e\x37\x01\x21\x11\xc6\x28\x71\x40\xea\xc2\x16\xea\x74\x29\xda\x76\x44\x2c\xa8\x8c\xee\xb7\xc3\x38\x78\xb3\x83\x35\x4d\xe7\xbe\xbf\xfc\xb5\xe3\x54\xf6\x7a\x0b\xc8\xc7\x00\x51\x86\x26\xce\x43\x03\x00\x00")
+
+func _5_alter_consent_record_add_tombstoneDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_alter_consent_record_add_tombstoneDownSql,
+		"5_alter_consent_record_add_tombstone.down.sql",
+	)
+}
+
+func _5_alter_consent_record_add_tombstoneDownSql() (*asset, error) {
+	bytes, err := _5_alter_consent_record_add_tombstoneDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_alter_consent_record_add_tombstone.down.sql", size: 835, mode: os.FileMode(420), modTime: time.Unix(1792405942, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __5_alter_consent_record_add_tombstoneUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcd\xb1\x0a\xc2\x30\x14\x85\xe1\xbd\x4f\x71\xc6\x76\x15\x3a\x75\xba\x36\x17\x2c\xa4\x29\x84\x54\xdc\x42\x6d\xee\x20\x48\x02\x69\x06\x7d\x7b\x27\x11\xc5\x45\xe7\xc3\xff\x1d\xd2\x8e\x2d\x1c\xed\x35\x63\x4d\x71\x93\x58\x7c\x96\x35\xe5\x00\x52\x0a\xfd\xa4\xe7\xd1\x20\xc8\x55\x8a\x04\xbf\x14\x28\x72\xec\x86\x91\x61\x66\xad\xbb\xea\xb7\x3e\xcb\xb2\xa5\x88\x23\xd9\xfe\x40\xb6\xde\xb5\x6d\xf3\x97\x73\xbe\x7f\x33\xaa\xde\x32\x39\xc6\x60\x14\x9f\x70\x09\x37\xff\x2e\xf9\x67\xbe\x14\x4c\xe6\xe3\xa6\x7e\x8d\x4d\x57\x3d\x06\x00\x41\xdf\x83\xdd\x17\x01\x00\x00")
+
+func _5_alter_consent_record_add_tombstoneUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_alter_consent_record_add_tombstoneUpSql,
+		"5_alter_consent_record_add_tombstone.up.sql",
+	)
+}
+
+func _5_alter_consent_record_add_tombstoneUpSql() (*asset, error) {
+	bytes, err := _5_alter_consent_record_add_tombstoneUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_alter_consent_record_add_tombstone.up.sql", size: 279, mode: os.FileMode(420), modTime: time.Unix(1792405942, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __6_create_table_audit_entryDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3c\x00\xc3\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x69\x64\x78\x5f\x61\x75\x64\x69\x74\x5f\x65\x6e\x74\x72\x79\x5f\x73\x75\x62\x6a\x65\x63\x74\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x61\x75\x64\x69\x74\x5f\x65\x6e\x74\x72\x79\x3b\x0a\x03\x00\xd7\xcf\x34\x28\x3c\x00\x00\x00")
+
+func _6_create_table_audit_entryDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_create_table_audit_entryDownSql,
+		"6_create_table_audit_entry.down.sql",
+	)
+}
+
+func _6_create_table_audit_entryDownSql() (*asset, error) {
+	bytes, err := _6_create_table_audit_entryDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_create_table_audit_entry.down.sql", size: 60, mode: os.FileMode(420), modTime: time.Unix(1792406452, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __6_create_table_audit_entryUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcf\xc1\x4a\xc4\x30\x14\x85\xe1\x7d\x9e\xe2\x2c\x5b\x70\x25\xcc\x6a\x56\xb1\x73\xd1\x60\x9b\x4a\xb8\x23\x33\xab\x92\x69\xb2\xc8\x60\x5b\x69\x6e\x41\xdf\x5e\xb0\x2a\x55\xf7\xdf\x39\xf0\x57\x8e\x34\x13\x58\xdf\xd5\x04\xbf\x84\x24\x5d\x1c\x65\x7e\x47\xa1\x00\x20\x05\x18\xcb\x74\x4f\x0e\x4f\xce\x34\xda\x9d\xf1\x48\x67\xe8\x23\xb7\xc6\x56\x8e\x1a\xb2\x7c\xf3\x29\x25\x0d\x31\x8b\x1f\x5e\x71\xd0\x4c\x6c\x1a\x82\x6d\x19\xf6\x58\xd7\x2b\xf0\xbd\xa4\x69\xc4\xb3\x76\xd5\x83\x76\xc5\xed\x6e\x57\xfe\x17\xd3\xfc\x0b\xac\xcb\xbc\x5c\xae\xb1\x97\x3f\xd3\x9f\x59\x88\xe2\xd3\x4b\x06\xd3\x89\x55\xb9\x57\xea\xab\xc9\xd8\x03\x9d\x90\xc2\x5b\xb7\xe9\xea\xbe\xcf\x5a\xbb\xcd\x2d\xf2\x72\xb9\xc6\x5e\xca\xbd\xfa\x18\x00\x1f\xf3\xa7\x3a\x12\x01\x00\x00")
+
+func _6_create_table_audit_entryUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_create_table_audit_entryUpSql,
+		"6_create_table_audit_entry.up.sql",
+	)
+}
+
+func _6_create_table_audit_entryUpSql() (*asset, error) {
+	bytes, err := _6_create_table_audit_entryUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_create_table_audit_entry.up.sql", size: 274, mode: os.FileMode(420), modTime: time.Unix(1792406452, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __7_create_table_webhook_deliveryDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x60\x00\x9f\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x69\x64\x78\x5f\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x64\x65\x6c\x69\x76\x65\x72\x79\x5f\x73\x74\x61\x74\x75\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x64\x65\x6c\x69\x76\x65\x72\x79\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x6f\x75\x74\x62\x6f\x78\x3b\x0a\x03\x00\x57\xfd\xeb\x31\x60\x00\x00\x00")
+
+func _7_create_table_webhook_deliveryDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_create_table_webhook_deliveryDownSql,
+		"7_create_table_webhook_delivery.down.sql",
+	)
+}
+
+func _7_create_table_webhook_deliveryDownSql() (*asset, error) {
+	bytes, err := _7_create_table_webhook_deliveryDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_create_table_webhook_delivery.down.sql", size: 96, mode: os.FileMode(420), modTime: time.Unix(1792407338, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __7_create_table_webhook_deliveryUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x4f\x6b\xc2\x30\x18\xc6\xef\xfd\x14\xef\xb1\x05\x0f\x32\x26\x0c\x3c\x65\xed\xab\x0b\xab\xe9\x88\xaf\x43\x4f\x21\x2e\x81\x95\x75\x46\xd2\xe8\xf4\xdb\x0f\xac\x38\x8d\xfb\xc3\xce\xcf\x2f\xe1\xc9\xf3\x4b\x2e\x91\x11\x02\xb1\xfb\x12\xe1\xc3\x2e\x5f\x9d\x7b\x53\x6e\x13\x96\x6e\x07\x69\x02\x00\x50\x1b\xe0\x82\x70\x8c\x12\x9e\x24\x9f\x30\xb9\x80\x47\x5c\x00\x9b\x51\xc5\x45\x2e\x71\x82\x82\x7a\x07\xf2\xc5\x5b\x1d\xac\x51\x3a\x40\xc1\x08\x89\x4f\x10\x44\x45\x20\x66\x65\xd9\x11\x76\x6b\x57\x41\x85\xfd\xda\xc2\x33\x93\xf9\x03\x93\xe9\xcd\x60\x90\x45\xd4\x5a\xef\x1b\xa7\x0d\x10\xce\xe9\x14\x25\xd9\x30\x49\xbe\x6d\x6b\x6c\x53\x6f\xad\xdf\xff\xbb\x6f\xf7\x4c\x75\x76\xe0\xb2\xc7\xc6\x37\x5f\x35\xfb\xb7\x77\x71\xcf\x36\xe8\xb0\x69\xcf\x90\x18\xd0\x21\xd8\xf7\x75\x68\xaf\xee\x87\x02\x47\x6c\x56\x12\xf4\x3b\x70\x65\x77\x41\x1d\xe9\x5f\xe6\x6b\x74\xfb\x03\x76\x89\x58\xef\x9d\x3f\xce\x77\x4a\xfe\xb6\x33\xaa\x24\xf2\xb1\x38\xe8\x4d\x4f\xe3\x64\x20\x71\x84\x12\x45\x8e\xd3\xe8\x87\xa4\xb5\xc9\xa0\x12\x50\x60\x89\x84\x90\xb3\x69\xce\x0a\x3c\x37\xc5\x45\x81\x73\xa8\xcd\x4e\xc5\xb6\xd4\x71\xbd\x4a\x5c\x89\x4c\xbb\xa8\x17\xcf\x92\x0d\x93\xcf\x01\x00\x83\x27\x69\x2b\xaf\x02\x00\x00")
+
+func _7_create_table_webhook_deliveryUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_create_table_webhook_deliveryUpSql,
+		"7_create_table_webhook_delivery.up.sql",
+	)
+}
+
+func _7_create_table_webhook_deliveryUpSql() (*asset, error) {
+	bytes, err := _7_create_table_webhook_deliveryUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_create_table_webhook_delivery.up.sql", size: 687, mode: os.FileMode(420), modTime: time.Unix(1792407338, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __8_alter_consent_record_add_expiry_notificationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x53\xc1\xae\x9b\x30\x10\xbc\xfb\x2b\xf6\x08\x12\x97\x56\x7a\x27\x4e\x2e\x6c\x5a\x54\x30\xaf\x8e\xa9\xfa\x4e\x16\x2f\x76\x15\x4b\x09\x4e\xc1\x44\xcd\xdf\x57\x40\x80\x40\x68\xd5\x9b\x61\x66\x77\x67\x67\xec\x98\xe7\xaf\x90\xb0\x18\x7f\x80\x51\xbf\xe5\xc1\x56\x8d\xae\x9c\xac\xf5\xc1\xd6\x4a\x5e\xcb\x93\x51\xd2\xd9\x90\xfc\x9b\xa7\xf4\x49\x3b\xad\x64\xe9\x16\xcc\xb6\x32\xbf\xa6\x56\xba\x6e\x8c\xad\x42\x42\x68\x2a\x90\x83\xa0\x9f\x52\x84\x65\x1f\xe0\xc8\x68\x86\x20\xf2\x15\x20\xdd\xf9\x12\x12\x12\x71\xa4\x02\xb7\x4b\x3d\x02\x00\x60\x14\x24\x4c\xe0\x67\xe4\xf0\xca\x93\x8c\xf2\x37\xf8\x8a\x6f\x40\x0b\x91\x27\x2c\xe2\x98\x21\x13\x41\xcf\xbc\x94\xce\x74\x93\xc7\x36\x46\xc1\x77\xca\xa3\x2f\x94\x7b\x1f\x5f\x5e\x7c\xe0\xb8\x43\x8e\x2c\xc2\xfd\x9a\xea\x19\xe5\x0f\x3d\x06\x7b\x7e\xd6\xf6\x0c\x71\x27\x8c\xe5\x02\x58\x91\xa6\x8f\xa8\xb3\x77\x6c\xfa\x7f\x2c\x9b\xe3\x72\xd6\x58\x07\x05\x4b\xbe\x15\x78\x2f\x1f\x0c\x9b\xf6\x89\x71\x47\x8b\x54\xc0\x87\x01\x6e\xdb\x95\xe2\xfb\x5a\xb5\xbe\x1a\xdb\x36\xf2\x69\xca\x80\xcf\x49\xf5\xb2\x44\x92\x3d\x4a\x1b\xd1\x5a\x97\x8d\xad\x16\xe5\x1b\xac\xf7\xdb\x33\x83\xf8\x73\x4e\xc3\x36\x7f\xbf\x0b\x90\xb3\x55\x8a\xde\x73\x2a\x41\xbf\x69\x30\xda\xe1\x87\x63\xf7\xff\xb8\x8c\x1b\x03\x66\xb0\x13\x9a\xb0\x3d\x72\xd1\x59\xbc\xbe\x71\xb0\xc7\x14\x23\x01\xdd\xe8\x2d\x51\x73\xf4\xe3\xd9\xd9\x00\x3a\xd3\x27\xa9\xa3\xf2\x45\x24\xc1\xe4\x5e\xe9\xe6\xf3\xe0\xf7\xfc\xfd\x7e\x83\x1d\xcf\xb3\xed\x57\xd0\x3f\xb0\xad\x37\x20\xdd\xf9\x12\x92\x3f\x03\x00\x94\x32\xf1\xd0\xd2\x03\x00\x00")
+
+func _8_alter_consent_record_add_expiry_notificationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_alter_consent_record_add_expiry_notificationsDownSql,
+		"8_alter_consent_record_add_expiry_notifications.down.sql",
+	)
+}
+
+func _8_alter_consent_record_add_expiry_notificationsDownSql() (*asset, error) {
+	bytes, err := _8_alter_consent_record_add_expiry_notificationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_alter_consent_record_add_expiry_notifications.down.sql", size: 978, mode: os.FileMode(420), modTime: time.Unix(1792407555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __8_alter_consent_record_add_expiry_notificationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x8f\xcd\x4e\xeb\x30\x14\x84\xf7\x7e\x8a\xd9\xf5\x76\xd1\x27\xe8\xed\xc2\x34\x96\x88\x94\x38\x55\xe2\x08\x76\x96\x89\x4f\x89\x51\xb0\x91\xe3\x42\xfa\xf6\x28\xa0\xf0\x53\x21\x24\xb6\x47\x67\xbe\xf9\x86\x17\x4a\xd4\x50\xfc\xaa\x10\xe8\x82\x1f\xc9\x27\x1d\xa9\x0b\xd1\x82\x67\x19\xf6\x55\xd1\x96\x12\x34\x3d\xb9\xe8\xfc\xbd\xf6\x21\xb9\xa3\x23\xab\x4d\x42\xc6\x95\x50\x79\x29\x20\xdb\xa2\xd8\xb2\x3f\x90\xc8\xfe\x06\x62\x9b\x0d\xde\x83\x23\x52\x6f\xd2\x92\xc1\x1d\x1d\x43\x24\xa4\x9e\x30\x76\x3d\xd9\xd3\x40\x11\x34\xb9\x31\x91\x85\x89\x04\x1f\x12\x16\x30\x8c\x3f\x3f\x86\x48\xac\x3d\xcc\xa2\x97\x4a\x8d\x50\x3f\xba\xec\xb0\x6f\xeb\x5a\x48\xa5\xe7\x69\x8d\xe2\xe5\x01\x37\xd7\xa2\x16\x78\x36\x83\xb3\x3a\x05\xe4\x0d\x64\xa5\xde\x56\x83\xcb\x0c\x0f\xa7\xc1\x19\x6f\xcd\xf9\xdf\xf2\xb2\xc6\xff\xdd\x97\xf3\xca\x87\x97\xd5\x7a\xcb\xd8\xbe\x16\xb3\x4a\x2e\x33\x71\x0b\x67\x27\xfd\x5d\x4a\x7f\x54\x54\xf2\xc2\xf7\x13\xbd\x65\xaf\x03\x00\xef\xbd\x51\x97\xb3\x01\x00\x00")
+
+func _8_alter_consent_record_add_expiry_notificationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_alter_consent_record_add_expiry_notificationsUpSql,
+		"8_alter_consent_record_add_expiry_notifications.up.sql",
+	)
+}
+
+func _8_alter_consent_record_add_expiry_notificationsUpSql() (*asset, error) {
+	bytes, err := _8_alter_consent_record_add_expiry_notificationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_alter_consent_record_add_expiry_notifications.up.sql", size: 435, mode: os.FileMode(420), modTime: time.Unix(1792407555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __9_alter_consent_record_add_created_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x54\xcf\x6f\x9b\x30\x18\xbd\xf3\x57\x7c\xc7\x44\xe2\xb2\x49\x3d\x71\xf2\xc8\x97\x0d\x0d\x4c\xe7\x98\x69\x3d\x59\x34\x76\x57\x4b\x0d\xce\x8c\x53\xb5\xff\xfd\x04\xc4\xd0\x10\xb3\xe5\xe6\xe4\x3d\xbf\xef\xc7\x7b\x66\xc3\xca\x7b\xc8\xe8\x06\x7f\x81\x96\x6f\x62\x6f\x9a\x56\x35\x4e\x58\xb5\x37\x56\x8a\xbd\x55\xb5\x53\x52\xd4\x2e\x89\xfe\xcd\x7c\xad\x5f\xb4\x14\xce\xfc\x8f\x27\xd5\x8b\x0a\x28\x9e\x1a\xfd\x67\x94\x52\xb6\xd5\xa6\x49\xa2\x88\xe4\x1c\x19\x70\xf2\x25\x47\xb8\xd4\x01\x86\x94\x14\x08\xbc\x9c\x01\xc2\x1d\x8e\x49\x14\xa5\x0c\x09\xc7\xf0\xd5\x55\x04\x00\xa0\x25\x64\x94\xe3\x57\x64\x70\xcf\xb2\x82\xb0\x07\xf8\x8e\x0f\x40\x2a\x5e\x66\x34\x65\x58\x20\xe5\x71\xcf\x3c\xd6\x4e\x77\x95\xbd\x8c\x96\xf0\x93\xb0\xf4\x1b\x61\xab\xcf\x77\x77\x6b\x60\xb8\x45\x86\x34\xc5\xdd\x9c\xba\xd2\x72\x3d\x68\x0c\xeb\x79\xb2\xe6\x00\x9b\xae\x31\x5a\x72\xa0\x55\x9e\x7f\x44\x9d\x39\x63\xe3\xff\xcf\x75\xfb\x7c\x59\xcb\xdf\x83\x8a\x66\x3f\x2a\x3c\x5f\x1f\x16\x36\xce\xb3\xc1\x2d\xa9\x72\x0e\x9f\x06\xf8\x74\x9a\x75\x7c\x1e\xcb\xaa\x57\x6d\x4e\xad\xb8\xaa\x32\xe0\x93\x53\x7d\x5b\x3c\x2b\x3e\xb6\xe6\x51\xab\xea\xd6\x34\x17\xd7\x03\xac\xc7\xf7\x25\x86\x7a\x3b\x6a\xab\x9b\xdf\xa2\x31\x4e\x3f\xe9\xc5\x7a\x3d\x4f\xc9\x65\x5a\xb4\x9e\x6c\x1f\x96\xb3\x1c\x2d\x28\xe9\x2c\x14\xab\x6b\x93\xe3\x7e\x71\xb1\xdf\xee\x3a\xf1\xea\x37\x64\x3b\x50\x60\x02\x6f\x50\x1a\x03\x71\xad\xe3\xa1\x6e\xdc\x8c\xee\x90\xf1\xce\xf7\xf9\x33\x80\x1d\xe6\x98\x72\xe8\x06\x08\x8d\x36\xe5\xd1\x9f\x9d\x89\xa1\x4b\xc2\x38\xb0\x9f\xff\x22\x27\xf1\x68\x69\xed\xa6\xf3\x10\x82\xe9\xf7\xe3\x7b\x1c\x34\x36\x0e\xda\xb8\x65\x65\x11\x7e\xc5\xfd\x07\x22\xf4\x86\x85\x3b\x1c\x93\xe8\xef\x00\x93\xf4\xe5\xb8\xbc\x04\x00\x00")
+
+func _9_alter_consent_record_add_created_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_alter_consent_record_add_created_atDownSql,
+		"9_alter_consent_record_add_created_at.down.sql",
+	)
+}
+
+func _9_alter_consent_record_add_created_atDownSql() (*asset, error) {
+	bytes, err := _9_alter_consent_record_add_created_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_alter_consent_record_add_created_at.down.sql", size: 1212, mode: os.FileMode(420), modTime: time.Unix(1792408476, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __9_alter_consent_record_add_created_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8e\xc1\x6a\xc3\x30\x10\x44\xef\xfa\x8a\x39\xb6\xd0\x7c\x81\xe9\x41\xb5\x75\x08\xc8\x4a\x70\x64\xe8\x4d\xb8\xf6\x26\x11\xc5\x5a\x90\xb6\xa4\xfd\xfb\xa2\x16\xda\x24\xb7\x81\xdd\x79\xf3\xb4\xf5\x66\x80\xd7\x2f\xd6\x60\xe6\x54\x28\x49\xc8\x34\x73\x5e\xa0\xbb\x0e\xed\xce\x8e\xbd\xc3\x9c\x69\x12\x5a\xc2\x24\xe8\xb4\x37\x7e\xdb\x1b\xb8\xd1\xda\x46\xa9\xcd\x06\x72\x26\xac\xbc\x52\x12\xfc\x56\x0b\x2e\x94\x09\x45\x38\xd3\x82\x37\x3a\x72\x26\xc8\x39\x16\xac\xf1\x94\x27\x89\x9c\x10\x0b\x3e\xd2\x7b\xe2\x4b\x7a\xaa\x80\x2f\x9c\x48\x6a\x80\xc4\x95\xc0\xc7\x9f\xfc\xf7\xae\xc6\x7d\x1d\xbe\x57\x3c\x18\x7f\xed\xf6\x8c\x76\x1c\x06\xe3\x7c\xa8\x86\x07\xaf\xfb\x7d\xa3\x54\x3b\x98\x5a\xdd\xba\xce\xbc\x22\x2e\x9f\xe1\x16\x12\xae\x00\x3b\x77\xb7\xf0\xf0\x7f\x7c\x6c\xd4\xf7\x00\xac\xd6\xa8\x19\x2d\x01\x00\x00")
+
+func _9_alter_consent_record_add_created_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_alter_consent_record_add_created_atUpSql,
+		"9_alter_consent_record_add_created_at.up.sql",
+	)
+}
+
+func _9_alter_consent_record_add_created_atUpSql() (*asset, error) {
+	bytes, err := _9_alter_consent_record_add_created_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_alter_consent_record_add_created_at.up.sql", size: 301, mode: os.FileMode(420), modTime: time.Unix(1792408476, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _bindataGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\xbd\x5b\x6f\xdc\x66\xba\xa6\x7d\x6c\xfd\x8a\x5a\x06\x56\x43\xfa\x90\xcf\xe1\x7e\x63\x20\xc0\xa0\x37\x03\xf4\xc1\xf4\x1a\x4c\xb7\x8f\x86\x03\x81\x9b\x97\x69\xa1\x6d\x49\x2d\xc9\x09\x9d\x20\xff\x7d\x70\x91\x17\x5d\x92\x22\x45\x55\x25\x39\xc6\x1c\xc8\x96\xaa\xc8\x97\xef\xf6\x7e\xee\x67\xcb\x6f\xbf\xdd\xfc\xcf\xb6\xff\x57\xfb\x7d\xd8\x7c\x38\xfb\xfe\xaa\xbd\x39\xbb\x38\xbf\xde\xfc\xe9\x62\x08\x9b\xef\xc3\x79\xb8\x6a\x6f\xc2\xb0\xe9\x3e\x6d\xbe\xbf\xf8\xff\xbb\xb3\xf3\xa1\xbd\x69\xdf\x6c\x8e\xff\xdb\xe7\xaf\x4e\x36\x7f\xfe\xaf\xcd\xdf\xfe\xeb\x1f\x9b\xbf\xfc\xf9\xaf\xff\x78\x73\xf4\xed\xb7\x9b\xeb\x8b\x8f\x57\x7d\xb8\x7e\xcb\xef\x71\x74\xda\x5f\x85\xf6\x26\x9c\xde\xb4\xdd\xfb\x70\x7a\x36\x84\x0f\x97\x17\x37\xe1\xbc\xff\x74\xfa\xaf\xf0\xe9\xcd\x70\xf1\xe3\xf9\x9b\xeb\x7f\xbf\xdf\xe5\xda\x8f\x97\x9f\xaf\x8c\x4f\xdb\xf7\x37\xe1\xea\xfe\x25\xa7\xed\x30\x9c\x76\x17\xc3\xbd\x76\x9f\xbc\xfa\x56\xcb\x77\xbb\xd0\x5f\x9c\x5f\x87\xf3\x9b\xd3\xab\x8f\xef\xc3\xdd\x36\x7f\xe3\xba\x6d\x6b\x89\x0f\xfe\xfc\x75\xe8\x2f\xae\x86\xb9\x97\x3f\x84\xab\xeb\xb3\x8b\xf3\xd3\x8f\x1f\xcf\x86\x3b\x2d\xef\x7a\xcf\xf6\x29\xe9\xe9\x55\x38\x6f\x3f\x84\xd3\xab\xb0\x4c\xfd\xe9\xcd\xc5\x29\xeb\x74\xda\xbf\x6f\xaf\xaf\xef\xb4\xfe\xd4\xb5\xdb\x56\xb3\x87\xfb\xf1\xa1\xfd\x57\x38\xfd\xa1\x7d\x7f\x36\xf0\x94\x8b\x4b\x76\x4b\xfb\xfe\xce\x33\xf6\xbb\x73\xfb\xc4\xfc\xe1\xfb\x98\xad\x9b\x8b\x0f\xdd\xf5\xcd\xc5\xf9\xdd\x45\xd8\xe9\x86\x6d\xfb\xc5\xdd\x35\x6b\x3f\x0e\x67\x37\xa7\xe1\xfc\xe6\xea\xee\x76\xf9\x8d\xcb\xb6\x6d\x95\x77\x2f\xfa\x31\x74\xff\xbc\xb8\xf8\xd7\xe9\x10\xde\x9f\xfd\x10\xee\x35\xf8\xd4\xb5\xdb\x56\xab\xc7\x07\x14\xa6\xcb\xb3\xab\x4f\xa7\xe7\x17\x37\x67\xe3\x59\xbf\x1c\xd2\x3b\x4f\xd9\xf7\xde\xed\x53\xeb\xc7\xef\x5c\x3a\x3e\x9c\xb6\x37\x77\x9e\xb5\xdb\x1d\xdb\x27\xac\xb8\xf1\xfd\xc5\xd1\xe5\xaf\xa0\xe6\xe8\xe8\xec\xc3\xe5\xc5\xd5\xcd\xe6\xf8\xe8\xd5\xeb\xee\xd3\x4d\xb8\x7e\x7d\xf4\xea\x75\x7f\xf1\xe1\xf2\x2a\x5c\x5f\x7f\xfb\xfd\x4f\x67\x97\x7c\x30\x7e\xb8\xe1\xbf\xb3\x8b\xe5\xdf\x6f\xcf\x2e\x3e\xde\x9c\xbd\xe7\x8f\x8b\xf9\x86\xcb\xf6\xe6\x9f\xdf\x8e\x67\xef\x03\xbf\xf0\xc1\xf5\xcd\xd5\xd9\xf9\xf7\xf3\x77\x37\x67\x1f\xc2\xeb\xa3\x93\xa3\xa3\xf1\xe3\x79\xbf\xf6\xe6\x7f\x85\x76\x38\xe6\x97\xcd\xff\xfe\x3f\x3c\xf6\x9b\x0d\x07\x63\xb3\xdc\x76\xb2\x39\x5e\x3f\x0d\x57\x57\x17\x57\x27\x9b\x9f\x8f\x5e\x7d\xff\xd3\xfc\xd7\xe6\xed\x77\x1b\x7a\xf5\xe6\x6f\xe1\x47\x1a\x09\x57\xc7\x5c\x79\xcd\xdf\x7f\xfc\x38\x8e\xe1\x6a\x6e\xf6\xe4\xe4\xe8\xd5\xd9\x38\xdf\xf0\x1f\xdf\x6d\xce\xcf\xde\xd3\xc4\xab\xab\x70\xf3\xf1\xea\x9c\x3f\xbf\xd9\x8c\x1f\x6e\xde\xfc\x85\xd6\xc7\xe3\xd7\x34\xb4\xf9\xcf\x7f\xbf\xdd\xfc\xe7\x0f\xaf\x97\x9e\xcc\xcf\x3a\x39\x7a\xf5\xcb\xd1\xd1\xab\x1f\xda\xab\x4d\xf7\x71\xdc\x2c\xcf\x59\x1e\x72\xf4\xea\x74\xbe\x64\xf3\xdd\xe6\xec\xe2\xcd\x9f\x2e\x2e\x3f\x1d\xff\xa1\xfb\x38\x7e\xb3\xf9\xfe\xa7\x93\xa3\x57\xfd\xfb\xbf\xac\x3d\x7d\xf3\xa7\xf7\x17\xd7\xe1\xf8\xe4\xe8\xa5\xfa\x43\x33\x4b\xfb\x8f\x34\x14\xae\xae\xb8\xee\x68\xfd\xb0\xfb\x38\xbe\xf9\x23\x5d\x3f\x3e\xf9\x86\x1b\x8e\x7e\x39\x3a\xba\xf9\x74\x19\x36\xed\xf5\x75\xb8\x61\xca\x3f\xf6\x37\xb4\x32\x8f\xcf\xf5\x38\x7a\x75\x76\x3e\x5e\x6c\x36\x17\xd7\x6f\xfe\xfb\xd9\xfb\xf0\xd7\xf3\xf1\xe2\xf3\x7d\x2e\xe1\xfa\xf9\xad\x16\xe8\xe9\x66\xb3\x71\x19\x8f\x5e\x5d\x9f\xfd\x34\xff\x7d\x76\x7e\x53\x64\x47\xaf\x3e\x20\xd6\x36\x9f\x1b\xfd\x1f\x17\x43\x98\x3f\xfc\xc7\xd9\x87\xb0\x61\x9b\xbc\xe1\x37\x9e\xf3\xed\xb7\x9b\xbf\xd1\x96\x43\x60\x67\xcd\xcb\xb2\xec\xa1\xe3\xf1\xec\x7e\x27\x4e\xe6\xeb\x8f\x4f\x7c\xf4\xe6\xe7\xcf\xc3\x1f\xcf\xde\xcc\x77\x2e\xad\xfe\xfd\xec\xa7\xbb\xad\xd2\xc5\xdf\x68\x95\xeb\x8f\x4f\x96\x01\xdc\x6d\x74\xbe\x71\x69\x94\x81\xdc\x69\xf4\xc3\xc5\xf0\x5b\x8d\x72\xfd\xf1\xc9\xed\x69\xb8\xdb\xf4\x87\x8b\xe1\xb7\x9a\x3e\x1b\x3f\xcd\xb3\xf5\xdb\x4f\x60\x2a\x8f\x4f\xb6\xd3\xfa\xab\x47\xdc\x9a\xeb\xbf\x5e\xff\xf9\xec\xea\xce\x63\x7e\xfc\x67\xb8\xf9\x67\xb8\xda\xb4\x9b\xe1\xec\x2a\xf4\x37\x17\x57\x9f\x7e\xe3\x71\xf3\xfd\xc7\x27\x9b\xee\xe2\xe2\xfd\xaf\x87\xf2\x87\x8b\xeb\x37\x8c\x83\x67\xfc\xc7\x77\x9b\xc8\x87\xfe\xfd\xd3\xf5\x9d\x47\x9e\x5d\x6f\xae\x3f\x5d\x3f\x35\x77\x7f\xff\x74\xbd\xac\x47\xb8\x1a\xdb\x3e\xfc\xfc\xcb\xad\xe7\xb9\xb9\x39\xaf\xa7\xa7\x4f\x10\x9a\x3f\x5f\xfc\x78\xfe\xf7\x7f\xbf\xdf\x7c\xe7\x86\x3f\x7e\xdd\x4c\xf1\xd8\x4c\x55\xd7\x4c\x51\xd5\x4c\x51\xf4\xf0\xcf\x38\x2e\xff\x67\xe5\xf2\x7f\x57\x2d\x9f\x65\x59\x33\xe5\x49\x33\x65\x63\x33\xe5\x51\x33\x25\x51\x33\x65\x75\x33\x65\x61\xf9\x2e\xcb\x9b\x29\xaf\x96\xcf\x8b\xba\x99\x8a\xac\x99\xca\xaa\x99\xf2\x71\xfb\x77\x91\x37\x53\x31\x34\x53\x19\x35\x53\x31\x36\x53\xb9\x7e\x16\x9a\xa9\x48\x9b\xa9\xac\xbd\xbe\x5b\x3e\xff\xfc\x37\xdf\x25\x5e\x1b\xdf\xba\x2f\xf3\x7b\x3f\x4b\x19\x5b\xfb\x70\x5f\xf3\xac\x99\xb2\xb8\x99\x32\xc6\xd0\x2f\xfd\x4d\xa2\xe7\xf5\x6d\x7d\x5e\x94\x2e\x73\xd5\x76\xcd\x94\xc7\xcd\x14\xe8\xd3\xb8\x9d\xc3\xe5\xe7\xf5\x2a\x22\x76\x5c\x3a\x31\xed\x21\x59\xe1\x7e\x70\xf3\x80\xa6\xc7\x47\xaf\x5e\xed\xba\x27\xbe\x39\x7a\xf5\xea\xf5\x13\x97\x7e\x96\xc8\xaf\xbf\x39\x7a\x75\x72\xf4\xcb\x7e\x5d\x3f\x3e\xd9\x1c\xff\x7f\x33\xfc\xde\xee\x35\xa3\xb8\xfe\x2c\xe4\xf6\x9b\x85\xa7\xa4\xcb\x67\xa1\x30\xc3\xfa\xdb\xef\xee\x1f\xac\x9f\xc1\xc8\xb7\x9b\x3d\x86\xbd\x01\x00\xdf\x6e\xca\xf8\x9b\xf9\xc8\xbe\xbd\x0d\x67\xc7\x59\x12\x9d\xcc\x9f\x03\x32\x6f\x17\x10\x7a\x77\x7e\x36\x1d\xc7\x65\x9d\x64\x71\x94\x97\xe5\x37\x9b\xe8\xe4\x97\xa3\x57\x2d\x62\xf2\x0f\xf3\x5c\xfc\x3c\x4f\xc0\xdb\x8d\xf3\x40\x4f\xdf\xce\xff\xfe\xf2\x79\x45\xdb\x6f\xf6\x3a\xe4\xef\x2e\x0f\x3d\xe2\x45\xdf\x4c\x35\x47\x78\x68\xa6\x8c\x6d\x9c\x36\x53\x1a\x37\x53\x1c\x35\x53\x55\x2c\x5b\x78\xac\x9b\x29\xce\x97\xdf\xdb\xa1\x99\xe2\xae\x99\x42\xb5\x1c\xfb\x96\xfb\xcb\x66\xaa\x39\xba\x49\x33\x95\x65\x33\x0d\x1c\xe7\xa8\x99\x6a\x8e\x20\x3f\x55\x33\xf5\xf9\x72\x0d\xc7\x6e\xa8\x9a\x29\x1e\x9a\x29\xc9\x9b\xa9\xcb\x9a\xa9\x0d\xcb\xef\xd5\xd0\x4c\x21\x6a\xa6\x31\x34\x53\xe9\x11\x8a\x69\x3b\x6f\xa6\x21\x6a\xa6\xa1\x6b\xa6\x9e\xef\xd3\x66\xea\xfb\x66\xea\xcb\xe5\xe8\x25\xdd\x72\xc4\xfa\xb6\x99\xe2\xb4\x99\xba\xbe\x99\xba\xb6\x99\xd2\xa2\x99\xaa\x6c\xe9\xcf\x98\x36\x53\xe8\x9a\x69\x8c\x97\x31\x8c\xb4\xc7\x18\x87\x05\x4a\xfa\xb1\x99\x92\xb4\x99\xe2\xb6\x99\xa2\x78\x99\x1f\x8e\xf9\x90\x37\x53\x2b\xec\x70\xac\x79\x5e\x60\x2c\xed\x32\xbf\x8c\x8b\x31\xf5\xc0\x52\x68\xa6\xb4\x6f\xa6\xd0\x37\xd3\x50\x34\x53\x0d\x64\xd6\xcd\x94\x96\xcd\x14\x42\x33\x85\xa4\x99\x42\xec\x18\xea\xa5\x9f\x55\xdc\x4c\x75\xb1\xdc\x4b\x3f\xb9\x0f\xb8\x64\x7c\x45\xd1\x4c\xe9\xd8\x4c\x23\xf3\x6a\xdf\x72\x9e\xc1\xfc\x8d\xdb\xcf\x18\x37\x50\xc5\x7c\xd0\x5f\x60\x29\x1d\x96\xfb\x80\xbb\x22\x11\xa6\x80\xa2\xb8\x99\x72\xe6\x6b\x6c\xa6\x8e\x7e\x65\xcd\x94\xb4\xcb\x9a\x70\x5f\x15\x9a\xa9\xca\x9b\x69\xec\x96\x3d\x10\xb1\x0e\xed\x32\xe6\xbe\x5e\xe6\x30\x05\xe2\xf3\x66\xea\x99\xdf\xb4\x99\x86\x74\x81\x43\xae\x29\x8b\x45\x54\xb4\xe5\xb2\xc6\xec\x01\xfa\x52\x8d\xae\x01\xa2\xa2\x68\xa6\x84\xb6\x84\xe7\x0a\x71\xc1\x7c\xc4\xcd\xd4\xf1\xdd\xb0\xdc\x93\xc4\xcd\x34\xe6\xcb\x58\x5b\x20\x9b\xf5\x65\x5c\xec\x97\x61\xe9\x33\x3f\x63\xdb\x4c\x49\xd9\x4c\x3d\x7b\x73\x5c\xf6\x4b\xe6\x5a\xa7\xf5\xb2\x96\x25\xfb\x82\x3d\xca\xba\xb3\x6f\xc2\x76\x9d\xf7\x80\xe4\x77\x97\x5f\x04\x90\xdf\x5d\xee\x0a\xc7\x1f\x2f\xf7\x07\xe3\x77\x97\x2f\x04\xc5\xef\x2e\x7f\x7f\x20\xfe\x78\x79\x07\x86\xd3\xb8\x3a\x04\x87\xcb\xa2\x78\x19\x1c\x7e\xca\xca\xf3\x1c\xba\x55\xf5\xcb\x79\x60\xef\x73\x9e\xbb\xb4\x99\xd2\xa8\x99\xe2\xaa\x99\x86\xbe\x99\x46\x30\x13\x4a\xc4\xde\xed\x9a\xa9\x06\x3b\xa0\x56\xa1\x99\x3a\xb0\xb3\x5e\x70\x28\x64\xcb\xff\x35\xf8\x50\x2c\x98\x50\x83\x29\x55\x33\x95\xe0\x1f\x78\xc1\x39\x4d\x96\x33\xce\x79\x05\xdf\xb3\xb6\x99\x5a\x30\xb1\x5a\x3e\xa3\x2f\x60\xff\xc8\x59\x06\x57\xa0\x61\xd9\x22\x2f\x6a\x30\xa4\x6a\xa6\x9a\xe7\x72\xe6\xc2\x72\xbe\x4b\xce\x2e\x7d\xe2\xec\x72\xf6\x8a\x66\x6a\x39\xf3\xe0\x22\x74\x13\x8c\x00\x5f\x6c\x2f\x88\x5f\x60\xf5\x30\x34\x53\xd7\x2d\xe3\x86\x82\x71\x76\xe9\x77\xc5\x38\x90\x4f\xe0\x6a\xad\x1c\x40\x8e\xd4\x62\x3e\x18\xa6\xfc\x61\x0e\xc1\xac\x6c\x58\x28\x19\xf8\x33\x40\x65\xbb\x66\xaa\xa0\x7c\xe5\x22\x13\x12\x30\x1b\x4c\x4c\x97\xcf\x7b\x64\xce\xd0\x4c\x7d\x26\x7e\x81\x6d\x60\x60\xe5\xff\xf4\x1b\x0c\xa2\x5f\xb4\x11\xa4\x7b\x85\x94\x4f\x99\x94\xa6\xcd\x94\x97\x0b\xf6\x24\xfe\xde\x83\x6b\xca\x92\x02\xf9\xc2\xb8\xa0\xc6\xc8\x33\xe7\x90\x75\x40\x36\xc4\xf5\x32\x16\xf6\x4b\x00\x5b\x99\x47\xe6\x86\x35\x60\x1e\xab\x66\x2a\x58\x4b\xe8\x73\xb9\xc8\x1e\x68\x69\xc7\x75\xe0\x68\xe1\xb3\x90\xdd\xc3\xb2\x4e\x61\x9d\xd7\xa0\x9c\x61\x9c\xc8\x0b\xe4\x2a\xed\x40\xe5\x95\x33\xec\x1d\xe6\x8d\x35\x2f\xa5\xc9\xc8\x87\x54\x99\xc2\xda\x23\x57\x90\x61\x60\x78\xca\xfc\x23\x1b\x83\x7c\xc0\x7e\x42\x8b\x59\x0b\x64\x3a\xfb\x8a\x79\x41\xce\x8f\xca\xb7\xd6\x7d\x5e\x25\xdb\xf5\x65\xcf\x32\x6e\xe4\x18\x7c\x60\x96\x53\xc8\x23\xf6\x20\x63\x4a\x16\xdc\x2f\xe9\x27\xcf\xe0\x59\xd2\x78\xf8\x48\xc2\x5c\x16\xcb\x9e\xe0\xba\xb9\x7f\xac\x17\xe3\x19\x16\x99\xc5\x79\x9a\x65\x0c\x7b\xa6\xda\xca\x69\xe6\x9d\xf3\x19\x58\x3b\xf6\x55\xba\xc8\x10\xe4\x30\x6b\xc3\x9e\x8c\x90\xaf\xf4\x8d\x3d\x0b\xb5\x47\x0e\xf5\xee\xe7\xd0\x4c\x31\xb2\x95\x79\x43\xbe\x38\x27\x81\xf5\xe4\x1c\x21\xcb\xf3\x65\xaf\xd4\xf4\x8b\xbe\x22\xdb\x98\x9b\xa4\x99\xa2\xe4\x01\x59\xb4\x23\xd8\x1c\x22\x8f\x76\x6c\x7a\x51\x11\x9e\xba\xf8\x51\x25\x61\xc7\xa7\xec\x26\x9b\xf6\x9b\x8d\x17\x93\x4f\x7b\x0c\x5e\x19\x95\x17\xc9\x01\x32\x2a\x8b\xa3\xea\xf7\x91\x51\x87\x6b\x0b\x09\x92\x05\x06\x1a\xcb\x14\x57\x09\x85\x06\x01\xe2\xc4\x8b\x24\xc8\xd3\x66\x1a\x7b\x4f\xb5\x27\x96\x93\x0e\xf3\x9e\x59\x2f\xac\x92\x7b\x6b\x0d\x08\x48\x9f\x42\x86\x27\x82\x20\xf5\x40\xea\x28\x97\xe9\x89\x1c\x9c\xf2\x12\x69\xd8\x2d\x27\x18\x66\x88\xd4\x81\x05\x56\xad\xec\x6e\x95\x52\x9e\x78\x98\x6a\xe7\xb3\x12\x59\xe0\x8c\x12\xa0\x14\xcf\xa5\xaf\x30\x50\x50\x89\x76\xe2\x05\x55\x41\xc2\x0e\x56\x8c\x54\xe4\xb4\x67\x0b\xcb\x05\x09\x90\x54\x48\x16\x90\x07\xcd\x80\x53\x8d\xc4\xc4\x88\x80\xd4\x84\xc5\x57\xa0\x49\xbc\x48\x6f\xb4\x80\x11\x84\xb1\xdd\x88\x67\x82\xe0\x20\x7f\x2f\xaa\xd2\x9f\x6c\x41\x3c\x8c\x28\xcc\x21\x5a\x03\xec\x96\x1f\xd8\x3c\xa8\x46\xdf\x60\xc6\x18\x1c\xf2\x62\x41\xb7\x19\xc1\x40\x3b\x90\x32\x5d\xd8\x01\x28\x9b\x47\x4a\x7f\xa4\x7e\xb7\x48\x2a\xd0\x06\x56\x00\x12\xce\x7d\x1a\x5d\xa7\x48\x2d\x0f\xe9\x36\x38\xae\x71\x61\x11\xb9\xac\x64\x40\x3b\x62\x5d\x91\xec\xb0\x69\xe6\x98\x39\xcf\x5d\x33\x24\x0d\x88\x1b\x96\xf5\x1e\x56\x49\x49\xdb\x6a\x1a\x48\x19\xb4\xa4\x76\xd8\xee\xaf\xbd\x90\xef\x60\x1e\xbe\x53\xc3\x3b\xa2\xde\xc3\x5c\x7c\xa7\x27\xbc\x0c\xe2\x7d\x11\x3e\xfe\xd4\x43\xef\x33\xf2\xb8\x4c\xbf\x2a\xda\xdd\x55\x1f\x3e\x3b\x8b\x3e\xbe\x0f\xcf\xe1\xe2\x70\x8c\x08\xde\xc1\xff\x9a\x0f\xb1\x19\x8c\x98\x33\xb1\x07\x80\x80\x83\x66\x45\xf9\x58\x0f\x22\x81\x6e\x7d\x33\x25\xe8\xda\xf2\x10\xb8\x25\x7f\x27\x72\x21\x4c\xa5\xa0\x4a\x80\x3b\x72\xea\xbb\xe5\xd4\xa7\x95\x5c\x05\x24\x02\xe9\xe4\xe3\xf4\x83\x93\x0c\xda\x65\xe3\xb6\xbd\xb9\x2d\x38\x07\xa8\xdb\xfb\x43\x5f\x4b\x7f\x6f\x97\xdf\x2b\x39\x1c\x7a\x7d\x8b\xbd\x45\x93\x27\x9c\x11\x44\xc7\x66\xc2\xff\x11\xf7\x82\x76\xe5\xf2\x3c\x6c\x2f\x19\x27\x17\xc4\x77\x6e\x72\xda\xe8\xe5\x96\x3c\x77\x7c\xe4\x04\x3f\xb9\x2c\x87\x9c\xde\x27\x1b\x5d\x4e\xee\xe3\x97\x3d\xca\x54\x9e\x6c\x79\xb7\x13\xbb\xeb\xa8\x5f\xec\xb4\xee\x34\xd4\xf5\xa4\xe6\xf5\x83\x27\x35\x2d\x1e\x39\xa9\x45\x9c\xa6\x65\x16\xa5\xd1\x97\x3e\xa9\x87\x33\x12\x18\x02\x92\x8e\x73\xc1\x4f\x75\x4b\x67\xee\xb5\x5f\xc2\x16\x2a\x75\x9a\xb9\x3d\x98\x00\x67\x04\x29\x89\xd4\x43\x92\xf1\x3d\x6e\x00\x78\x3c\x52\xb3\xd6\x8c\x8f\x44\xc5\x94\xaf\x7b\x23\xe6\x1a\xce\x55\xa1\x5d\x10\x77\x02\xac\x01\xfd\x0b\x3b\x99\x8c\x27\x51\xdf\x46\xba\xd6\xba\x0d\x38\xef\x41\xdd\x62\xb5\xab\xa2\xb7\x63\x87\xc2\x7e\x99\xa8\x8f\x47\xe8\xa0\x30\x01\xf4\x42\xbe\x0b\xb2\x8b\x58\x86\x82\x4d\x8f\xfe\x85\x05\x3b\xf2\x76\xd1\x7b\xa2\xa2\x99\x72\xf4\x5f\xdd\x0d\x19\xcc\x0c\x5d\x9b\xdf\x75\xc3\x94\xba\x74\xd0\x71\x4b\x75\x6d\xee\xa7\x7f\xe0\x5b\xaf\x2e\x8b\x3e\x86\x3e\x1a\x79\x2d\x3a\x19\x73\x0b\xe3\xe1\x39\xe8\xed\xf3\x78\x60\x6e\x5c\x8b\x0d\x13\x5d\x0a\xa9\xae\x4e\x16\x69\x57\x0e\xda\x7c\x33\xd8\x06\x2c\x10\x5d\x08\x46\xc6\x3c\x82\x29\xea\x8b\xe0\x63\x2d\xc3\xe2\xda\x59\x77\xc6\x26\x8d\xcd\x14\xf6\xa3\xfd\x15\xfc\xa4\x3f\xb3\x6e\x0f\xab\x00\x37\x99\x27\x30\x59\xfd\x18\x9b\x22\x36\x5d\xec\xc6\x73\x9b\xea\xa0\xb5\x6c\x14\x7d\x14\xac\xad\xed\x5f\xa7\xfd\xa1\x44\xdf\x84\xd5\xc1\xd0\xd8\x53\x30\x1c\xf0\x9a\xf1\x8e\xb2\xc1\xdc\xfd\x52\x69\xf7\x60\x2f\xc8\xc6\x60\x62\xe8\xcc\xd8\x42\xb0\xa7\xe6\xa9\xee\xa2\xd5\xee\x51\x68\x6b\x96\x31\x62\x4f\x6d\xd1\xa1\xe9\x77\xef\xb8\xe9\x2f\x6b\xc7\x7c\xb1\xce\xcc\x87\x2e\x35\x98\x21\xec\x35\x96\x85\x21\x33\xb0\x2d\xa3\x97\xc3\x56\x61\x6c\xb0\x43\x64\x03\x76\xfc\x79\x7f\x61\x9b\x60\x4f\x38\x76\x64\x40\xe4\x9e\x2e\xc0\xf8\xfe\xd6\xfe\xd7\xae\xce\xdc\xa3\xab\xa3\xb3\xcf\x67\x87\x3d\x09\x13\x67\x8f\xc2\x08\x99\x57\xd6\x81\xb5\x29\xb4\x3b\x21\x0f\x91\x1d\xcc\x33\xfb\x86\xb3\x04\xe3\x67\x5e\x5a\xf7\x9c\xec\x9f\x67\x47\xda\x62\x33\x75\x7b\x6c\x3e\xd8\xbc\x4b\x6d\xd6\xd8\x4d\xb0\x39\x70\x6e\x91\x79\x19\xf6\x31\x58\x2b\xfb\x09\xd6\xce\x7e\xc1\x0e\x8d\xcd\x20\x68\x2b\xd1\x2f\xc1\xda\x72\x06\xd9\xdf\xd8\x4b\x4a\xed\x00\xb3\x1d\x83\xf9\xa7\x7f\x9c\x45\x6d\x07\xd8\xf5\x0b\xe7\x87\xf3\x99\xba\x5f\xf3\xae\x99\x4a\xed\x53\x30\xd6\xb9\x1f\x8c\x85\xf6\x0a\xdd\x91\xf1\xc2\x07\x6a\xf7\x3c\x76\x75\xec\xdd\xa9\x6b\x92\x38\x47\xf4\x67\x3e\xdf\xc9\x32\x06\xe4\x6d\x26\x46\xcc\x78\x90\x69\xdb\xeb\xc5\x16\x98\x38\xdf\x63\xdf\xe1\x7b\x6d\x42\xd8\x2a\x60\xd5\x83\x5c\x21\x96\x45\x73\x76\x23\xb8\x86\x7d\xad\xf4\xe7\xec\x21\x9b\x0f\xe6\xd5\x4f\x34\xf9\xa4\x5c\x7e\x98\x4b\x3f\xd1\xea\x73\x65\xf2\x17\xe1\xcf\x8f\x3f\xee\x3e\x73\xae\xa3\xf2\x6b\xca\xe3\x1d\x63\x00\x9f\xc3\xa2\x67\xef\xa2\xc8\x9c\x29\xad\x57\x7b\x41\xa5\x47\x11\xc4\xc4\xfa\x8c\x87\x86\xef\xf9\x41\x62\xe2\x6d\xaa\xf5\x76\xe5\x5a\xb0\x07\x25\x03\x27\x1a\xe4\x40\xf7\x47\xd2\x8f\x7a\x1d\x41\xc1\x44\x14\xa9\xed\x1f\x0e\xfe\x96\x6b\xf0\x08\xf1\x99\xd2\x39\x07\x41\x61\xda\xb4\x53\xa8\xcb\x23\x11\x7b\x6d\x08\xfc\x80\xbe\x9c\x30\x58\x70\x25\x62\xc3\x96\x3b\x6d\x10\x9c\x34\x50\x90\xbe\xcb\x8a\xf1\x08\x56\x5a\x60\xd1\xfb\x39\xa5\xa0\x03\xc8\xd2\x81\x1e\xa0\xa7\xed\x54\x8e\x07\x7d\xbc\xd7\x92\x1e\xa9\x0d\x30\xbf\xfc\x8d\x27\x0d\xe9\x04\x5a\xe0\x0d\x84\x25\x24\x4a\x35\x6c\x29\x04\x39\x0c\xb2\x92\x28\xdd\x5a\x43\xf1\xde\xce\xd7\xc4\x4b\xdf\x98\x1b\xbc\x74\xf4\xa9\x1c\xb5\x3c\x7e\xf6\x80\x29\x09\x58\x27\xa4\xe7\xb0\x45\xa7\x92\x39\x85\x11\x39\x06\x50\x14\x84\x44\x6b\x60\x7d\xf0\x60\xb2\x16\xf9\x2d\xcb\x36\xd2\xa9\x16\xf1\x4a\x11\x3b\xd1\x1e\x92\xe8\x21\x1c\x94\xfa\xa0\x17\x96\xee\x04\xaf\x2d\x7d\xd3\x36\x13\xb3\xf6\x58\xbc\xd9\x1b\x20\xea\xaa\x8d\x80\x6c\x99\x8c\x89\xbd\x02\x2b\xc3\xf6\x22\x5a\x17\x7a\x16\xd9\x7b\xd8\x4f\x52\xdb\x67\x3d\x98\xaf\x19\xe9\x99\x07\xd6\xc2\x75\x27\x20\x24\x05\x79\x65\x85\x48\x4f\xd8\x4e\xbb\x4a\x2b\xe6\x7c\x54\x82\xca\x10\xf2\xd5\xba\x0e\x73\xd2\xcb\x80\xd7\x06\xfb\x50\x1e\x6d\x3d\x90\x30\x32\x3c\x01\xab\xcd\xab\xd7\x2b\xdd\xba\x26\x68\x94\xa3\x88\x5f\xe8\xf5\xe4\x7e\xbc\xd2\xcc\x6d\xad\x8d\xaa\x77\xce\xb0\x2f\xf1\x5c\xd8\x0d\xd6\x69\x3c\xee\x9d\x76\x19\xc6\xc8\x39\x9a\x99\x1c\x4c\x01\x69\xcd\xb5\xda\x83\x72\x99\x25\x9e\x6d\xae\x61\x5d\xb0\x63\xc1\x12\x90\x72\xd9\x70\x77\xef\x20\x35\x23\xa5\x4b\xd0\xeb\x82\x87\x84\x3d\x34\xc8\xcc\xee\x4b\x97\xfd\x60\xe5\x00\x59\xb3\xdf\x03\x66\xc9\xb3\xe3\x2d\x8f\x69\x87\xfb\x3d\x71\x27\xa9\x74\xd0\x2c\xbd\x94\x8c\xda\x7f\x3a\x94\x58\x59\x99\xff\x3f\x20\xb1\x9e\xa1\x4d\x72\xde\x83\x98\xd9\xde\x92\x57\x06\xb6\xcd\x1a\x13\x67\x3c\x59\x98\x67\x24\x33\xe4\x0c\xc1\xca\x61\xfc\x7c\x0e\xcb\x04\x0b\x38\x9b\x33\xc6\x13\xcd\xb1\x6a\x8a\x6a\x47\x89\x56\x16\xce\x10\xe7\xb1\x96\x75\xc2\xf0\xf1\x1e\x12\x81\x51\x69\x0b\x07\x33\x7a\x3d\x5b\xa3\x6c\x7b\xc6\x35\x64\x14\x1a\x9b\x56\xa0\x2a\xde\x46\x8d\x44\x32\xd3\x51\xef\x28\xf8\xdc\xc9\xea\x7b\xed\xf7\xa9\xac\x1a\xe6\x98\x8a\xcf\x6d\xb1\x60\x3b\xda\x5d\x62\x1b\xb0\x68\x70\xa9\x5e\x23\x7c\x22\x31\x52\xef\x21\xf2\xb5\x8a\xc4\x71\xe4\x54\x6a\x54\x8c\x1e\x50\xb4\xac\x5a\x4d\x04\xed\x23\xd3\xea\x04\x8e\x20\xd7\x0b\xed\xc5\xb5\x1e\x5d\xf0\xac\x55\x33\x4a\xd5\x54\xc1\x51\x2c\x59\x68\x69\xbd\x11\x26\x83\x5a\x1e\x56\xaa\x99\x7d\xcb\x31\xc0\x56\xe4\x3b\xd7\xa5\x70\x0a\xd6\x0b\x8d\x87\xa8\x91\xd5\xfa\x05\x8e\x26\x32\x6a\xfa\x97\xe8\xc5\x55\xd6\x0c\x6a\xe9\x60\x2e\x7d\x27\xb2\x67\xe6\x1a\x06\x20\x62\x9f\x47\x9b\xc7\x53\xc9\x38\x0a\x35\x52\xfa\xcf\xba\xa3\x85\xcd\xf2\x50\xce\x81\x95\x02\xf9\x43\xb4\xd2\xac\x3d\xab\xa5\xe3\x33\x80\x03\xc0\x1b\xb2\xf2\x6e\x04\xd2\x01\xb8\x7a\x28\x83\xdf\xa7\xf9\xbd\x30\xf5\x41\x66\xbf\xcf\xd3\x5e\x12\x4f\xbf\x04\xe3\xdf\xf1\xd1\xf7\xd9\x7f\x52\x25\x5f\x13\x4b\x9f\xc8\xd1\x79\x0e\xeb\xaf\xb2\x97\x61\xfd\xb0\x7d\x62\x0d\x72\x51\x71\x10\x29\x12\x63\xc8\x68\x1b\xc6\x02\xab\x2a\xd7\x18\xb6\x4c\x16\xcc\xdf\x30\xa7\xd4\xd8\x01\x58\x1b\xa8\x05\xf3\x87\x05\xc2\x8e\x60\x31\xd8\xb4\xd4\x0c\x0a\xed\x53\xb0\x74\x18\x33\xac\x3a\xc0\x6e\x0a\xbd\x7a\xda\x11\x60\x44\x9c\x52\x50\x27\xe6\x44\x07\xbd\x6a\xa0\xb8\x63\x05\x6d\x19\x07\x73\x36\x6a\xdf\xe1\x7f\xc6\x57\x69\x37\x89\x0c\x69\x2e\xb4\x81\xc4\xc6\x28\x60\x0b\x99\x9f\x6b\x6c\x08\x5e\xb2\x5e\xcf\xdc\x3c\x4f\xd8\x54\x44\x6f\x50\xbe\xd3\x6e\x81\xe6\x03\x6b\xc6\x5e\x87\x8d\x6c\xb6\xb1\x30\x16\x90\x09\x54\xa4\x6f\x30\x59\x18\x9c\x5e\x3a\xec\x19\x83\xde\xc1\x5a\x0f\x6c\xab\x6d\x8c\xdf\x99\x57\x18\x1f\x08\x0c\x5b\xc4\x1e\x02\xd2\x62\x17\x62\x7e\x60\xae\xa9\xb1\x43\x68\x65\xd8\x33\x90\x42\x95\xb6\x23\x62\x78\xf8\x8e\xdf\xd9\x0f\xa0\xe5\x1c\x0f\x14\xc9\x2c\x99\x43\xc6\x00\xba\x8a\x90\x91\xec\xb9\x94\x2d\xe7\xda\xd7\x72\x7f\x5f\x7d\x29\x48\x1d\x24\xd2\xec\x21\x66\xbc\x30\x64\x90\x1e\x09\xc4\xdf\xcc\x1d\xf7\x1a\xe7\xd8\x6a\x73\x44\x32\xd7\xda\x9b\x06\xe3\x33\x56\x89\xdd\x2a\xa9\x23\xd9\x38\x7b\xa2\x2c\xb7\x76\xbf\xd2\xb9\xe0\x7e\xf6\xc0\xa0\x4d\x18\x69\x59\xc3\xc8\xd9\x7f\xd8\xff\x18\x73\x6c\xfc\x64\xa6\x84\x63\x9e\x5c\xbf\xda\x79\xc4\x8b\xcb\xfa\xc7\x4a\x0c\xd6\x23\xd7\xab\x3d\xef\x7f\x6d\xae\xcc\x03\x8c\x00\xd6\x4e\x8c\x4a\x1e\xfd\x76\x3b\x48\xd3\x54\xe6\x8e\x9d\x19\xbb\x10\xfb\x6e\x5e\xcf\xf8\x61\x09\xb3\x1b\x24\x1c\x20\x5b\x76\x6b\x78\x96\x2a\x4f\x5c\xfa\x18\x43\xdf\xed\x09\x3b\x49\x92\xbd\x66\xe1\xa5\x64\xc8\xee\xc3\x5e\x99\x78\xf6\x55\x7d\x39\x4f\xf4\xf7\x70\x06\x5e\x2a\x3b\x7a\xfd\x1e\x77\x64\xc7\xea\xcf\x19\x16\x7f\xce\x8c\x2d\x89\xac\x5a\x26\x89\x8d\x16\x59\x81\x4c\x48\x8c\x86\x80\x71\xc5\xc6\x3d\xc7\xde\x4b\x14\x43\x2c\xab\x05\xc7\xb0\x68\x24\xfa\x50\x88\x49\x07\x6f\xe9\x43\xa7\x25\x08\x5c\x00\xdb\xf8\x1c\x8b\x06\xe7\x0e\xac\x9d\x19\x9f\xd1\x1d\xb5\xb2\x26\x18\x99\x52\xd9\x16\xd6\x9a\x44\x6b\x4e\x69\x44\x06\x7d\xc1\x4a\x44\x5b\x43\xbe\xb5\x3a\xd1\x0f\xfc\x36\x60\x68\x6b\xac\x7b\xa1\x85\x81\x18\xf1\x12\x9b\x39\x72\x02\x59\x84\x1d\x18\x46\x8f\x56\x6f\xb4\x45\x66\x6c\x34\x9a\xc8\x00\xcb\x04\x1f\xc1\xe4\x20\x8e\xda\xff\xd1\x18\xc8\x9c\x36\xd6\xd8\x7f\xe3\x03\x91\x4b\x68\x02\xb5\x2c\x19\x3f\x31\xac\x1d\x5f\x13\x2c\xb8\x12\xe7\x2a\x7d\x09\x8c\x93\x35\x63\x6e\xd7\x38\xc1\xa0\x25\x0e\x59\x04\xc3\x07\x97\x12\x65\x46\x69\xb4\x4d\x62\x94\x4f\x2e\x56\x32\x5e\xee\xcd\x8c\x99\x64\x7e\x61\xef\xb5\x31\x86\x58\x75\x3a\xd3\x78\x90\x23\x11\xf3\x4c\xd4\x09\xd6\x95\xca\x7d\x52\x18\xef\x8e\xac\xa9\xb7\x3e\x97\x55\x7b\x48\x13\x7d\x3e\x46\x07\xb5\x5a\xf0\x98\x0f\xe6\xa2\x58\x23\x94\x90\xff\x62\x31\x31\xf7\x5c\xc7\xbe\xea\x95\xbb\xac\x0b\x7d\xc9\x8c\xe2\x41\xfb\xc3\xb2\x84\x55\x84\xfe\xa2\xa5\xa0\xc5\xf0\x5c\xac\x6e\x8c\x0f\x59\xcd\x9e\xc5\xb2\x92\xc8\x83\x98\x3b\x7c\x4e\xf0\x8e\xca\x38\xcd\x19\xaf\xcb\xed\xbd\xb4\xc3\x9c\xc3\x7d\x90\xb7\xec\x85\x72\xb5\x00\x1a\xdb\x9f\x1b\x11\x85\xdf\x84\x3d\xcd\x7c\xa5\x72\x24\xfa\x84\x1f\x12\xab\x1f\x72\x95\x33\xc1\x5a\x8e\xe6\x2c\x60\xf1\x41\x1b\xc5\xb7\xc7\x7a\x8d\x6a\x5c\x9c\x49\xfa\xc6\x99\x45\xd6\xcd\x3e\xb5\xf4\x20\xd9\x71\xa8\x56\xb2\x4b\xb3\x3b\xc9\x8d\x07\xb5\x90\x27\x6e\xda\x5d\xfb\xd8\x63\xf4\xbf\x97\xc4\xb8\xa7\x6d\x64\x79\xfc\x35\xe5\xc5\x5e\xd9\xfa\xcf\xd1\x3d\xc0\xe9\xdc\xd8\x5d\xce\x0c\x16\x96\x4c\xf9\x01\xaf\xc7\x53\xd0\x6a\xb1\x98\x23\xf4\xc0\x47\xad\x11\xc8\x19\x2c\x2f\x60\x1b\x1c\x35\x36\xa7\x08\x8b\x0e\xd6\xe9\x54\xff\x1c\xb2\x23\xc8\x81\x39\x37\x9c\x39\xe4\x54\xa5\x95\x03\xdd\x21\xac\xf1\x42\xfc\x2f\xbf\x8f\x94\x29\xc8\xa2\x5a\x5f\x29\x78\x84\x7c\x81\x4f\xb6\xfa\x98\x07\x31\x82\x31\xcc\x3e\xf8\xc2\xbc\x22\x9e\xcb\xf5\xa3\x3e\x4d\x78\x66\x6e\xac\x0f\x9c\x1e\x6f\x46\x30\xae\x19\xeb\x45\xe6\x77\x5a\xa5\x88\x01\x42\xfe\xd4\xdd\xad\x98\x86\x4e\x2b\xba\x5c\xbe\xd2\x77\x09\xb6\x25\xc6\x84\x73\x1f\x78\x81\x5c\xe3\xff\x78\x8d\x53\xd0\x4a\x8f\x7c\xeb\xf5\x14\x30\x1e\xe4\xe4\x6c\xe1\x50\x9f\x83\x43\x63\xa9\x06\x97\xc0\xf0\x58\x1f\x32\xfa\x13\xf2\xe2\x73\x0c\xbd\xd6\x10\xe6\x8d\xf9\x64\x1d\x6a\xbd\x10\x73\x7f\xc0\x74\xac\x6f\xca\x25\xf8\x78\x2e\x66\xa1\x03\xc4\xc6\x43\x20\x63\x62\xe3\x96\xb0\x5e\x61\x99\xca\x56\xbe\xbe\xc6\x2a\xd4\xfa\xaa\xe1\xe7\x60\x70\xed\xf3\x95\x1b\xe3\x3a\xdf\x5a\x93\xc0\x6d\xe4\x70\x65\x9e\x13\x56\x1b\x62\x23\xb0\xc4\xa1\xc7\x32\x26\xac\x81\x91\xfe\x6f\x2c\x43\xac\x27\x72\x23\x57\x47\x83\xb7\xa7\xfa\x7a\xd1\x0d\x73\x65\xdb\x68\xbc\x18\x6d\xe3\x29\x60\x3c\xad\x71\x27\xf1\x9a\xf7\x64\xbc\x16\xf7\x76\xde\x3f\x68\x35\x22\xbe\x9f\x68\xd2\xdc\x1f\xf6\x30\x3a\x5d\xad\x75\x10\xae\x91\x9b\xd3\x90\x9a\x8f\x46\xfb\x9d\x63\xea\xb5\xfe\xf7\x7a\x1d\x72\x73\xb7\xd8\xeb\x99\xfb\x19\xd9\x9c\x7b\x66\x3a\xf5\x56\xe4\x24\x6b\x35\xea\x8b\xaf\xd4\xf1\x22\xf3\x12\xb0\xe0\xb1\xce\xa9\xb9\x59\xc4\x98\x71\x9e\x12\x75\xad\x5a\xcb\x5a\x0e\x57\xd1\x3b\xd1\xfb\x19\x73\x89\x05\xae\x35\x6f\x82\x67\xa4\xea\x6e\xc8\xaf\x58\x5d\x98\xb3\x97\xab\x3f\x92\x7b\xc6\x7e\x80\x93\x70\x46\x89\x69\x49\xd5\xdf\x5b\xf9\x20\xeb\x90\x18\x6b\x93\x78\x66\x56\x5d\x3d\x95\x3b\x61\x9d\x44\x26\xce\xf7\xa4\xca\x6e\x78\x8d\xb1\x2d\xe8\x90\xa5\x67\xb2\x94\xb7\xb1\x17\xb1\xda\xc1\x1f\x2a\xbd\x8b\x85\x39\x86\xc8\xf4\x4a\x2e\x04\x1e\xa5\x46\xe4\xa2\x2f\xa3\x57\x32\x9f\xac\x33\xfb\x00\xaf\x12\x7b\x20\xe8\x89\x63\x5f\xe7\xf2\x20\xbc\x80\x45\xbf\x9d\x7f\x70\x0e\x99\x5f\xf9\xd9\xea\x95\x0a\x7a\x78\xe0\x2b\xa3\xde\x1d\xce\xde\xdc\x0f\xac\xc5\x5c\x6f\xcc\x04\x5c\x2b\xd2\x3e\x80\x2e\xdb\xcb\x63\x59\xaf\x3c\x6c\xcf\x26\xfd\x87\x17\xc4\x8e\x7b\x8d\x2b\xb9\xcf\x03\x0e\x01\xfa\x03\x78\xc1\x21\x8f\x99\x79\xc2\x5e\x37\x3e\xa6\x6d\x1e\xf2\xf4\x9d\x78\xc4\x33\x66\xef\xa5\x78\xc5\xa1\x13\x24\xcf\xa8\xf3\xaf\x1a\xd3\xb0\x57\xef\x9f\xa7\xa5\x82\x3a\xd9\x9a\xa9\x77\x8f\x65\x80\xd2\x20\x57\xa9\xe6\x55\x2b\x1d\x41\xa4\x41\xed\x89\x53\x86\x0f\x23\x11\x61\xd7\x8c\xa5\x64\x45\x32\x23\x78\x41\xe6\x44\x1f\x41\x61\x26\x60\x2b\xf3\xaf\x8d\x0b\x28\xfd\xc1\x67\x0d\x22\x81\xd4\x68\x64\x83\xec\x9d\x68\x37\xfc\x24\x48\x20\xfa\xc3\xef\xa3\xcc\x02\x4d\x13\x29\x0f\x6a\x70\xa2\x33\x33\xa2\x61\x4b\x20\x70\x2a\xe3\x40\xa2\xf0\x3b\xac\x04\x69\xc5\x58\xd0\x98\xd0\xe4\x90\x30\xad\x7e\xf9\x5a\x7f\x14\xda\x34\xda\x4d\xad\x16\x02\x02\x05\x51\x91\x39\x86\x55\x24\x46\xda\xcd\x5a\x86\x59\x82\x48\x3b\xc6\x4a\x14\x24\x52\x28\x33\xe2\xb0\xa8\xb7\x51\x5c\xad\xe8\x3f\x8a\xa4\xad\xd6\x3d\xfa\x84\x55\x30\x11\x99\x66\x89\x96\x69\x49\xf5\x9a\x42\xc6\x06\x92\xa6\x46\x0a\x22\x09\x82\x3e\x71\x9e\x9f\x9a\x99\x97\x89\x88\xeb\x1c\xf1\xac\x44\x89\x33\x18\x05\x86\x94\xac\x65\x96\x9d\x59\x8d\xb5\x39\x22\x30\x8e\x5a\x34\x07\x99\x59\xb3\x51\xe9\x83\xe4\x19\xeb\xad\x96\x88\x76\xdc\xcb\x6c\x98\xbb\xc4\xe8\xca\x59\x92\x69\xb9\x64\xae\x6a\x23\x05\x07\xa3\xce\x72\xe3\x50\x40\xfc\xa4\xd8\x6a\x78\xfc\x5f\x9a\xf5\x38\x18\xef\x91\x9b\x29\x58\x69\xe9\xed\x65\x5b\xf4\xa9\x53\xc2\x8c\xc3\x36\x76\x82\x3d\xc5\x7e\x83\x11\x30\x0f\xb4\xd7\x1a\xb1\x0a\x53\xcc\xcc\xcf\xe1\x0c\xe0\xeb\x1a\x8d\x36\x85\x19\xc3\xd8\x60\x30\x95\x91\xb9\x85\x11\x8f\xac\x1b\x7d\xe1\x1c\x75\xc6\xc6\x0c\xc6\xad\x60\x4d\x9f\xfb\x12\xeb\xeb\xec\xb7\x6b\x45\xdf\xb1\x02\xb3\xdf\x93\x76\xab\xe5\xd3\x2e\xcf\xa2\x8d\x51\x89\xc7\xfc\xc7\xec\x43\xa2\x45\x9d\xe3\xde\x02\x1e\xcc\x13\xcc\x17\x56\xc1\x79\x49\xcd\xf2\x2c\x94\x9c\x9c\x01\xd6\x11\x96\x02\xc3\x62\xbc\xac\xc3\xfc\x9d\x1a\x36\x73\x9b\xc8\x30\x62\xa3\xfc\x0b\x73\x84\x82\xd1\xc1\xc1\xb9\x60\xdd\x39\x1f\x71\xf9\x1b\xec\x40\x16\x8b\x15\x99\x3d\x59\xeb\x03\xe5\xec\x04\x9f\xd5\x9b\xb9\xc9\xba\xcd\x8c\x65\xcd\x04\x58\x33\xdd\xdd\xf3\xa3\x0c\xad\x34\x56\xa8\xad\x1e\xce\x2e\xdc\x1f\x2a\xbf\xb4\x9c\xde\x6a\xf3\x7b\xdd\xf6\xb0\x6e\xbf\xff\x93\x5f\x5e\x42\x7f\x09\xbd\xff\xb0\xa9\x51\x3a\x17\x55\xf4\x35\xa5\xf3\x2e\xa5\xf4\x9e\xa3\xfc\xaf\x62\xb9\x37\xe9\x66\x56\x74\x57\xb1\xbc\xa6\x0c\xd6\x2a\x2c\xc2\x7e\xa2\xe1\xb4\xd6\x08\x90\xe8\x44\xc1\x78\x56\xa9\x9c\x40\xd8\x31\x08\xa4\x12\x7c\x1c\x53\x9d\x86\xdc\x19\xb6\x2c\x38\x01\x04\xb6\x16\x3f\x99\x89\x3e\x62\x41\x23\xc1\x68\x50\xf4\x2a\xb6\x38\xca\x88\xfa\xe0\x31\xee\x0d\x2e\x0e\x06\x81\x27\x86\x78\x44\x86\x55\xcc\x0a\x82\x45\x2b\x5a\x0d\xcd\xad\x21\x65\x40\x4c\x67\xe1\x8f\xc2\xe2\x1c\xb9\xe1\x79\x41\x71\x9a\x98\xcc\x90\x1b\xc0\x0c\x24\x61\x50\xcc\x4d\xaf\x44\x49\x4e\x4d\x66\x86\x7e\xd4\xf9\xb6\x78\x49\x67\x88\x03\xca\x75\x6b\x22\x33\xd7\xc5\xc2\xec\x9a\x84\x8e\xc2\x11\xa9\x58\x21\x46\x50\x16\x92\x55\x51\x4c\x54\xc4\xf9\x09\x2a\xe8\x28\xc0\x26\xbc\x47\x86\x4e\xd0\xbf\x59\x9c\xa0\x88\xc7\x16\xfe\x88\x34\x5e\x68\x64\x66\x4c\xf1\x67\x23\xe4\xa2\xa8\x55\x8a\xc3\x4a\x8a\x95\x5b\x68\x05\x85\xb4\xd3\xa0\x80\xe1\x3f\x31\x35\x95\xef\xf8\x1d\x08\x46\x84\x32\xae\x4a\x03\x08\x63\x88\x35\xa0\xcc\xce\xd8\xd1\xb0\x41\x68\x87\xe1\x3d\x18\xda\x4b\x13\xb3\xd8\x17\xb1\x34\x26\x57\x69\x47\xc4\xb4\x26\x55\xac\x89\xf7\x38\x13\x62\x0b\xab\xa0\x0c\x66\xf6\x9b\x79\xef\x0d\x59\x1c\x2c\x8a\x10\x9b\x1c\x12\x9b\x2c\x4e\x7b\x28\xfb\xfc\x40\x03\x11\xb5\xb4\xc7\x33\x5a\x03\xf2\x51\xe8\x31\x30\x95\x26\x8f\xd1\x76\x30\x91\x7f\xd4\x38\xd0\x59\x7c\x61\xa6\x88\x88\x8e\xd1\x10\x1a\xc3\x9b\x4a\x45\x63\xbd\x1a\x99\x2d\x9e\x02\xc5\x48\x0d\x8f\x89\xd7\x70\x49\x9c\x33\x3a\x26\xab\x7a\x9b\x5c\xc2\x5e\xa7\x7d\xe8\x45\x6d\x98\xee\xe0\xde\xaa\xbc\x36\x33\xa1\x03\xba\x59\xba\xcf\xf8\x1e\x65\x1a\xc3\x49\x6a\xd1\x89\xd5\xc0\x9d\x98\x20\x17\x4c\x22\xe8\x2c\x1a\x94\x59\x64\x87\xf3\x32\x8b\xf6\xd1\xe2\x31\xab\xa1\x84\xf1\xad\x89\x6a\xc5\xd6\xf9\x51\x19\xb2\x93\x48\x1f\x59\x17\x9e\xc3\x1c\x66\x6b\xe8\x90\xa9\xc3\x79\xa4\xa1\xd0\x90\xd0\xdc\xc4\x04\xd6\xbe\xd6\x51\xd0\x15\xdb\xd0\xaa\xda\xc2\x15\x50\x23\x28\x59\xa7\xb1\x8f\xb1\xa7\x16\xee\xe0\xf3\xa8\xb3\xa0\x81\x6a\x00\x7b\xa5\x94\x0e\xb0\x07\x83\x7b\xba\xd6\xf1\x34\xcf\x05\xc6\x80\x72\x7b\x3e\xe2\x5b\xc9\x05\xa5\x67\x25\x48\xed\x62\x13\x75\x4a\xd3\x86\x07\x0d\x17\xd0\x36\xb0\x07\x6a\xc0\x3c\x04\xf7\xe6\x9a\xf8\x50\x9a\x38\x51\x69\xa0\x63\x8d\x82\x09\x1d\x50\x8b\xd1\x7d\x04\x2e\x61\xc4\x62\xdc\xa8\x29\x91\xb4\xb9\x5f\x43\x9b\xa4\x9a\x8c\x15\x6c\xcb\xd2\x87\x8d\x06\x7b\xc8\x85\x03\x38\xc8\x1e\xad\xcf\x26\x82\x5d\xae\x7f\xcc\x32\xb0\xc7\xb3\x76\xa2\x1b\xfb\xcf\xcc\x4b\xf1\x8c\x3d\x67\x41\x82\x51\xa5\xf9\xfe\xc9\xc0\x51\x5e\x67\xc9\xef\x46\x30\x0e\xd7\xfa\x39\xea\x50\x0b\x34\xa8\xc8\x23\xb6\x46\x87\x62\xff\x0b\xe6\x5b\xa1\xed\x94\x1e\x4b\x44\x29\x30\x86\xd6\x5a\xe6\xdb\xba\x64\x50\x8c\x44\x4d\x3e\x51\x2b\xca\x8d\xac\xce\x8c\x4d\x09\xda\xd6\x33\x19\x7c\x61\x3d\x1d\xb4\x60\x60\x22\x11\x8a\x11\x09\x64\x39\x04\x8f\xf0\x68\x2c\x11\xa2\xab\xd2\xa6\x19\x9b\x9d\xc0\x71\x2e\xd4\xf2\xe9\x63\x2d\x54\x03\xa9\xb5\xa2\x3d\x58\xa7\x24\xd7\xa6\x8d\x28\xa7\x9f\xb4\x3f\xc4\x5b\x7b\x3f\xd4\x01\x91\x8a\xa6\x0e\x2c\xc4\xd6\x54\xc1\xc7\x10\xfa\xad\x5f\xb8\x30\x2a\x15\x9a\xd5\x19\xfd\xd8\x59\x83\xab\x37\xae\x08\xcd\x0e\xcd\x68\x16\xc9\x88\x4d\xe0\x22\x32\x2f\xcf\x7c\xe9\x62\xd8\xd2\xa8\xb5\x9e\x57\x69\xb4\x29\x22\x03\x18\x4e\xf5\xb1\x32\xf7\x85\x6b\x83\x0f\xa1\x34\x8f\x1a\xb1\xc2\xfc\x40\xfb\x10\x1b\xc5\x9a\xa7\x96\x99\x39\x01\x64\xa6\x5a\x13\x82\xf5\x6b\x84\xef\x74\xd8\xda\x43\x11\x11\x83\x16\x17\xa8\x5c\x2c\x24\x1f\x02\x6d\x87\x2a\x57\x3b\xb7\xbd\x3b\xac\x3d\xa8\x4a\xed\xfc\x9c\x17\x83\xb4\x77\x97\x5f\x09\xd0\xee\xe9\x4b\x49\x59\x7f\x4d\x38\x7b\xbc\x48\xf8\x73\xb4\xa4\x48\xa6\x1f\x19\xf6\xf2\xec\xaa\xae\xb0\x14\xab\xb0\xf2\x5d\xb9\x56\x63\xcd\x0d\x59\x21\x64\x6e\x0d\x53\xb0\x6c\x21\x50\x88\x01\x07\x0d\xa4\x10\x92\x0e\xae\xdc\xba\xc7\xf3\xef\x57\x6a\x85\x6d\x62\x0c\x4d\x85\xae\x75\x5e\xee\x1f\xe3\x27\x57\xe2\x80\xe3\xfb\x64\x9b\xf3\xb1\x7d\xfc\xaa\xc7\x38\xc8\x93\xed\xee\x74\x4c\x77\x1d\xf1\x4b\x1d\xcf\x9d\xc6\xe9\xb1\x2c\xa2\x03\x4e\x65\x91\xe5\x5f\xfa\x54\x1e\x4e\x2d\x38\x4f\xbd\x9a\x4a\x66\xc8\xed\x7d\x6a\x81\xd8\xaf\x2d\x23\x0a\x75\x40\x7b\x2a\x0d\x85\xea\xfb\x6d\x59\x02\xe8\x49\x69\xe9\xb8\x62\x2d\xd5\x67\xfa\x35\xe2\x75\x16\x97\x86\xb0\x16\x16\x3d\x42\x9c\x17\xba\x8a\xf9\xac\xd0\x95\x8b\x88\xcb\x35\xe6\x26\x5a\x0b\x46\x43\x85\x52\xb5\xdb\x5c\xa7\x42\xaa\x05\x05\x9c\x18\x74\xdb\xae\xd4\x00\x7a\x83\x06\x97\x4b\x45\x38\xf3\xa5\xee\xc7\x56\xb1\xc9\x59\x8e\x2c\x22\x04\x2d\x00\x0f\x38\xff\x91\x49\x93\xbd\xcf\x18\x4c\xdb\x1f\x75\x91\x22\xc2\x83\x63\xeb\xca\x6d\xc8\x30\x7d\x42\x2b\x67\x7c\xd0\x1b\xa8\x43\x62\xc2\x46\xa4\x7b\xbd\xd2\x45\xcd\x18\x4b\x43\x01\xc1\x16\x34\x71\xee\xad\xa4\x62\xb1\x56\xa3\xdc\xc4\xd3\xc4\x50\xdc\x4e\x6a\xc4\x9c\xb1\x66\x68\x4d\xa5\x25\x17\x2b\x7f\x5f\x5d\xf4\xcc\x29\x73\x45\x68\x6e\x6c\x82\xc9\x68\xb9\xc2\x56\xeb\x4a\xa7\x96\xde\x9a\x84\x54\x97\xb7\x0a\x3a\xb1\x16\x50\xc5\xca\x92\x0a\x86\xb9\x40\x49\x06\xad\x2f\xac\x4f\x67\x68\x43\xb0\x1c\x40\x6f\xe8\x7c\x64\xf9\x03\x1c\x34\xbd\xa1\xdc\xcc\x57\xd0\xe2\xd0\xe9\x18\xc2\xb8\x0c\x4d\x83\xea\x26\x96\x91\x85\xd2\x75\xd2\x2a\xe6\xaa\xd7\xf9\xb1\x86\xb6\xb3\x7e\xb1\x25\x5e\xdb\x72\x5b\x16\xf0\x21\x4a\xf4\xc4\xf9\x79\x51\x24\xdd\xd2\x9f\xc7\xaf\x79\x98\xf4\x3c\xd1\xe6\x33\x31\xf4\xdd\xe5\xef\x8a\xa0\x1f\x2f\xef\xe0\x67\x52\x66\x5f\x13\x40\x9f\x78\x55\xc9\x73\xb9\x4d\xe1\xdf\xf5\xf8\x7c\x6e\x43\xe8\x7f\xb1\x72\x15\x30\xd2\x24\x5f\x9c\x4e\xb9\xb8\x3d\x7f\xdf\xcb\x3d\x0a\xb1\xed\x3e\xdf\xc9\xe4\x29\x99\x16\xd6\xf4\x30\xae\xf3\xdc\xfe\x7c\x91\x67\x1a\xc6\xbc\x96\xed\x28\x74\x7c\xdf\xe7\x5a\x60\x12\xaa\x64\x30\xfc\xac\x88\x1e\xe6\x5a\xbb\x6d\x8f\x03\x60\x62\xb7\x86\x67\xd6\xf5\xc4\xa5\x8f\x51\xaf\xdd\x9e\xb0\x13\x76\xec\x35\x0b\x2f\x05\x21\xbb\x0f\x5b\x24\xa9\x8b\x03\x80\xa4\x4c\xd3\x97\xa9\xfd\xf6\x44\x77\x9f\x61\xe9\x31\x37\x34\x73\x9b\xf7\xc9\xaf\xab\x4a\xb1\x95\x63\xe3\x06\x3a\x23\xc4\xf0\x4d\x63\x81\xc0\xe0\x49\x44\x5e\x2a\xd5\x41\xfc\x23\x8a\x67\x51\x6f\xcd\x00\xac\x1d\xad\x51\x90\x6b\x7c\x44\x62\x3d\x85\x5a\x07\x0b\x8e\x1b\xac\x38\xd0\x9b\xd1\xac\xad\xc8\x28\xd7\xd4\x3a\x0d\xa3\xf9\xfd\xbd\xe2\x7b\x8e\xa9\xc0\xfa\x04\x65\x91\x22\xd0\x6e\xad\xb1\x18\x4b\x12\xfd\x1d\x8d\xe4\x84\x5a\x72\x3f\xd7\x70\x9c\x6b\xad\x4b\x38\x90\x0a\x1d\x35\x6b\x85\x7a\x62\x1a\x0a\x9d\x3c\xcc\x15\xd0\x81\xe1\x3c\x68\x20\x2f\xf9\x1f\xea\x84\x61\xdc\x7a\x99\xb5\x95\x70\xa0\x0f\x95\x0e\x82\x5c\x35\xab\xb6\xca\xff\x6a\xc1\x4a\xa4\x61\x95\xf5\x42\x4b\x23\x2a\xa1\x20\xbd\x15\x81\xe3\xe1\x96\x11\x1e\xf8\x34\xaa\x36\x32\x4b\x30\xd3\x19\xb2\x42\x3b\x10\x56\x08\x83\x83\xb5\x29\x66\x58\xe4\xd9\xd6\xbc\x80\x62\xb3\x9e\xc3\x6a\xd5\x4b\xcd\xe6\x30\x1e\xa0\x36\xa2\xb3\xd7\xcf\x5f\x5b\xb3\x94\x88\xb9\xdc\xa8\x5d\xe6\xa1\xb5\xca\x12\xcf\xef\xad\xfe\x1b\x1b\xed\x3f\x18\x8d\x98\x19\xa5\x0b\xad\x2c\xba\x6d\x6c\x43\xe1\xdb\x15\x06\xc5\x56\xa7\xc3\x69\xb4\x42\xd3\xea\x98\x0b\x3a\x02\x7a\xa9\x2e\x16\x2d\xd6\x2d\xb5\x6a\x7f\x67\x05\xe2\xd2\xb7\x1a\x60\x6d\x0c\x46\xd0\xb2\x76\x7c\x56\xbb\x97\x07\xa3\x6a\x07\x6b\xa8\x94\x3a\x97\x5a\x63\x24\x56\x67\x18\x6b\x36\xf7\x13\x6b\x26\x31\x23\xaa\xea\xa5\x39\xef\xa5\xf3\x18\xdf\x8a\x3e\x0c\xce\x31\x62\x61\xb4\x12\x51\x69\x16\x45\xbe\x46\xc1\x9a\xa1\xc3\x5c\xcd\x6d\x98\x07\xde\xe9\x6c\xc2\xaa\x87\xda\x30\xba\x67\x57\x07\x50\xa6\x95\x15\x07\x44\x61\x86\x0d\xe3\xad\x8c\x2e\xce\x7c\x93\x01\xb4\x38\xac\x79\xed\x56\xb0\xea\x8d\xf4\x8d\x75\xe0\x16\x56\xd5\x5a\x63\x97\x3a\x33\x6e\x7a\x9d\x92\xa9\xd9\x3d\xeb\x1e\xad\x54\xaf\x5a\xab\x3e\x66\x3a\xe6\xe8\x33\xfb\xa1\x5c\xad\xc0\xad\x59\x27\xc6\x35\xb1\x9e\xad\x99\x30\xbd\x19\x88\xec\x95\xda\xd8\xac\x95\x2a\x57\x3a\xa1\xe9\x03\x6a\x46\xab\x33\x68\x4f\x31\x79\x28\x97\xde\xa5\xd9\x9d\x44\xe4\x83\xac\x7a\x97\xd6\x5f\x42\x3c\xbe\xbb\xfc\xfd\x85\xe3\x3d\x92\x5d\x54\xe5\xd7\x94\x8d\x7b\xbe\xa9\xef\x39\xa4\xbb\x12\xc1\x30\x5e\xb4\x8f\x85\x5d\x74\x4b\xd8\x05\x6d\xc5\xba\x85\x73\xdd\x86\xec\x76\x4e\x11\xe8\x3f\xa3\x0c\x27\x47\x03\x08\xbb\xbf\x5a\x5d\xe9\xd6\x4e\x8d\xcd\x01\x2e\x8b\xbb\xa1\x17\x99\x79\xd5\x20\x7f\x2f\x92\xe6\x56\x58\x5e\x43\x0f\x0a\xf3\xca\xeb\x6e\xeb\x97\x00\x79\x0a\x7f\xc2\x9a\x47\x68\x9e\xc8\x8c\x08\xa2\x25\xbe\x86\x4a\xb7\x66\x67\xa5\x1f\x9e\x97\x1b\x2a\x32\x8f\x1d\x9f\x8e\xf9\xb8\xbd\x15\x79\x32\xc3\x1a\x50\xf0\x39\xed\xdc\x3f\x18\x01\xb8\xe6\x5d\xd4\x4a\x0f\xa4\x7c\x26\x51\x47\x1a\x80\x6e\x20\x6a\x6c\x0e\x05\x28\x03\xc3\xe0\xf9\xad\x86\x8e\xce\xdc\xc0\xca\x8a\xca\x6b\x4e\x64\x61\x18\x40\x2d\xaa\xb4\x2a\x45\xb9\xf9\x2a\x85\xef\x56\x09\xfa\x73\x0a\x73\x16\x13\x6b\xfc\xc7\x4a\x4f\xfc\x1b\xf8\x4a\xe2\x64\xfb\x53\x9a\x13\x08\x32\x21\x71\x58\x83\x74\xd8\x22\x55\xa1\xf1\x2a\x59\xfd\x2a\x91\xf3\x6b\xf8\x4b\x6f\x64\x64\x65\x68\xc8\x5a\x1d\xbb\x32\xc6\x3f\xf3\xdd\x33\xb0\x96\x44\x5f\x59\x58\x23\x30\xcd\xdd\xcc\x94\x70\x9d\xf9\x2f\xbd\xc6\xde\xda\x30\x05\x24\x5f\xa6\x4b\xbe\x34\x97\xa7\xb5\x42\x49\x6d\x1e\x77\xec\xbb\x0f\x58\x9f\xd2\x7e\x7e\x0e\xcd\x60\x4f\xf6\x46\x49\xfa\x2e\x9b\x35\x34\x23\xac\x6c\x4f\x9f\xd0\xe7\xd0\x09\xc3\x00\x60\x7f\xa5\x7d\x67\x8c\xf3\xf5\xd6\x32\x60\x4c\xac\x5d\x65\x1e\x12\x12\xa7\x54\xc2\x62\x50\x61\x9f\x25\xee\x89\xe0\xbb\x87\x06\x8d\x4f\xb0\xa1\xc8\x3d\x0f\x8b\x60\x8d\x50\xb6\x12\x73\x22\x22\xef\x1b\x95\xb4\xa5\xb5\x45\x2b\xf3\xd0\x09\x23\xa8\xf4\x55\xb1\x67\x06\xa3\x25\x99\xdb\xd1\x5a\xa2\xb8\xf6\x3b\x8d\x67\x7c\x9f\x5a\x17\xa1\xb0\x3a\x0b\x67\x80\x79\xcf\x8c\xf8\x84\x8d\x64\x32\xe6\x54\xc9\x07\x1b\xc2\x00\x49\x7b\x9c\xf5\xca\x3a\x06\xb1\x91\x91\xb1\x46\x2f\x58\xc7\xfc\x0c\xc3\x0d\xd8\x03\x48\xd7\xcc\xaa\x6a\x3c\xbb\x34\x94\x23\xea\xb6\x12\x7a\xf5\x3d\xe2\xf3\xe3\xfa\xc4\x5a\xc8\x9d\xaf\x99\x03\x7f\x60\x73\xc1\x48\x55\x1c\x0c\xa3\xbe\xbf\xce\xb0\x87\x58\x56\x02\x06\x74\xe6\x0c\x11\xf6\xd2\x99\x87\x3a\xcf\x3b\x63\xf0\x5d\x4d\x9d\xec\x94\xef\xd9\x5f\xf3\xfe\x26\xa4\x81\xf3\x6c\xe5\x2c\x0c\x66\xb9\x0c\x81\xb3\x1c\xf4\x67\x0e\xbe\x87\x28\x35\xaf\x85\x7d\x01\xa6\xb1\x1f\x47\x19\x0b\x67\xa5\x37\xe7\x28\x97\x3d\xc2\xa2\x30\xaa\xb1\x2f\xe7\xfe\xfb\x1e\x98\xd2\xea\xfb\x30\xae\xde\x77\xc5\xac\xf5\x37\x61\x75\xa9\x39\x5c\xad\xef\x2b\xb9\x7f\x8e\x6b\xa3\xba\x57\xe5\xbc\xb6\x36\xc4\xa8\x11\x74\x30\xdc\xe5\x3e\xeb\x38\x4c\xac\x1c\xc0\x43\x0e\x7b\xd0\xcc\x4c\xf6\xbc\xf5\x31\x65\xfe\xb0\x1e\xec\xc4\x5e\x9e\x35\x8b\x2f\xc5\x67\x0e\x9f\xa6\x55\xf9\x2f\x0f\x78\x17\x53\x54\xe6\x79\xfe\x35\x18\xce\xf3\xac\x01\x95\x6f\x48\x00\x97\xc2\xca\x6f\xac\x5b\xc3\xb9\x2c\xad\x93\x3b\x88\x89\x70\x93\x41\xfc\x47\x03\x09\xbe\xdf\x06\x07\x06\xda\x74\x65\x46\xc2\x5a\xdd\x72\xcd\xd3\xe7\xbe\x5a\xec\xce\xcc\xe1\xca\xc5\x1c\xe4\x46\xb0\xb2\xd6\x68\x25\x30\x9c\x96\xad\x79\xa6\xb9\xf5\x0c\x12\x23\xf9\x33\x39\x4d\x67\xac\x43\x65\xdc\x49\x66\xdd\x92\x5c\xee\xd1\x9a\x5f\x18\xcc\x87\x65\x5e\x7a\x6b\x64\x83\x2f\xad\x91\xef\x83\x9a\x79\x61\x55\xb2\xb1\xd8\xe6\xee\xd5\x6a\x86\x8c\x2f\x35\xb7\x30\xf8\x06\x0b\xb4\x21\xae\xa5\x6f\x9d\x98\x97\xe8\x84\xca\xe4\x2b\xbd\x21\xa7\xb9\x59\x32\x89\x6f\xeb\x40\x36\xb4\xd6\x8f\xee\xc4\xac\x5a\xbe\x32\x9a\x9f\x86\xd1\x71\xad\xb2\x35\x88\x8b\xb4\x9f\x6b\x8c\x1c\xc4\xbb\x5e\x0d\x95\x9f\xcc\x9a\xf7\x70\x98\xda\xfa\xf6\xcc\x7d\x6a\x4d\x98\xc1\x3a\x0c\x58\x0b\x52\xab\x26\x62\xb4\xac\xb5\x16\xb0\x2e\x38\xb7\x98\xab\xd8\x50\xbb\x54\xeb\x47\x6a\x8e\x62\x65\x98\x71\x6b\x15\xca\xde\xfc\xc1\xda\x35\x58\xdf\x1f\x90\x9a\x09\x01\xf7\xec\x7c\xed\x68\x50\x16\xcf\xf7\x1b\xdb\x53\x58\x13\x9c\xbd\x12\xcc\x37\x4c\x7d\x27\x51\x6b\x1f\xe0\x02\xa9\xef\xe5\xeb\xac\xe3\x03\x5f\x40\xa6\x44\xf2\x49\x62\x6d\x06\xeb\x41\x70\x1d\xbc\x34\xb2\x7a\x5a\xbb\xd6\x5e\xa8\x7d\xa7\xa0\x32\x63\x30\x17\xb8\x73\xad\x38\x17\xc8\x93\xda\x70\xcf\x99\x17\x90\x3b\xe8\x3b\x08\xe1\xf5\xb4\x3b\xaf\x9b\x9c\xbd\xb3\x2e\x13\x3c\x3f\xf5\x1d\x0a\xec\xab\xc2\xb7\x9b\x60\x81\xc9\xe5\x0f\xc8\xfc\xdc\x98\xa8\xd1\xf7\x2b\x72\xce\x90\xd1\xec\x27\xb8\x6b\x7b\x4b\x7e\x61\x4d\xe8\x94\xbd\xf4\xa9\x53\x2e\x3f\x53\x7e\xbd\xbb\xfc\x5d\xa4\xd7\x56\xab\xde\xf3\xc6\x87\x7d\x57\x87\x3c\xfd\x4b\xc8\xad\x77\x97\x5f\x5f\x6a\xdd\xd3\xca\xb3\x34\xff\x9a\x32\x6b\xa7\x77\xda\x3f\x5b\x17\x37\x98\xa0\x18\x6f\xe9\xe2\x95\x38\x6c\x45\x46\x74\x9d\xde\x0c\xae\xe0\xb9\xce\x7c\x5f\x5a\x69\xb6\x5b\x6f\x0e\x79\x64\xcd\xb3\x59\x8f\x2b\xcd\x40\xf3\x5a\x32\x00\xc1\xc7\xd2\x7a\xfd\x99\x38\xdc\xab\x8f\xe6\x66\x53\x8d\x5a\xcc\xc1\x4c\x1c\xe5\xc3\x6a\x1f\x48\xcd\xae\x42\x2f\x58\x65\x87\x61\xdc\x6b\xc6\x19\xfc\xbc\x17\x63\x4a\x43\x8c\x91\x2d\x91\xba\xc1\xe7\x77\xa8\x8e\x5b\x07\x54\x6d\x30\xc4\x1a\xaa\x9f\x9b\x6b\x9d\x6a\x77\xa8\x74\x60\x23\xbf\xba\xb5\x4e\x3f\x72\xc6\x94\x90\xca\x18\x44\x64\x7e\xa9\x5e\x8d\x9e\x48\x96\x5c\xac\xae\x39\x5a\x0b\x27\x11\xf3\x2a\x1d\x5e\x89\xef\x05\x88\x8d\x9d\x2c\xcc\xaf\xce\xd7\xf7\x0c\x58\x77\x26\x33\x7d\xa0\xf2\x8d\x48\xb1\x75\x71\x4a\xf5\xef\xc4\x8a\x90\xe8\x2b\x83\x35\xfb\xd7\xca\xbc\xb1\x59\x8d\x9d\xef\x7d\xe1\x3b\x30\x32\x5e\xf3\xf0\xb5\x3c\x83\xf5\x33\xb6\xa3\xbb\x95\xa6\x9e\x98\x22\x12\x65\xdb\xba\x0b\x89\x01\x54\xb5\x3a\x0b\x6b\xc9\xdf\xa5\xba\x5e\xa7\x45\x1e\xf9\x14\xad\xba\xba\xb5\x82\xd6\xf4\x0c\x74\x16\xd6\x2a\xd5\xb6\xb0\x3a\x55\x83\xef\x4e\xa4\x9d\xd4\x77\xe3\x66\x5a\x7c\x91\xf9\x9d\x19\xb0\xac\x21\x3c\xa6\xb6\xb6\xce\x68\x58\x3e\x76\x9a\xd6\x8a\xc7\xa5\xd6\xed\xc2\x8a\xab\xcc\x0d\xba\x53\xe5\x73\xd7\x77\x1a\xd6\xbe\x03\x78\x30\x78\x81\x7d\x8d\x7d\x83\xb9\x08\x6b\xa8\x7f\xe2\x3b\x78\xf4\x44\xb4\xbe\xd9\x0c\xb9\xcb\x3d\xad\xef\xbb\xa9\x8d\x33\x5d\x6b\xb7\xa5\xbe\x81\x2b\x18\x3c\xd7\xeb\x00\x1d\xad\x0a\xce\x99\xe8\x7d\x3b\x18\xf2\xb5\xf2\x7d\x9b\xa9\x99\x8b\xcc\x41\xae\xbe\x1e\xfb\x2e\x0c\xf6\x13\x32\x0b\xd9\x9b\xc8\xb9\xe8\x43\x14\xb6\xa9\x0b\xcc\x79\x69\xcd\x08\x9e\x57\xf6\xdb\x9a\x17\xad\xef\xfa\x29\xe4\xa8\xed\x5a\x6f\xc0\xf7\x0b\x06\xe7\x35\xf7\x1d\x9d\xb9\xb5\x9c\xb0\x30\xa7\x5a\xdc\xd1\x35\x73\xe7\x1a\x3b\xc7\xe0\x9c\xc6\x72\x8c\x35\xfd\x81\x73\x39\xfa\x8e\xc6\x4a\x1b\x1e\xf6\xaa\xc2\x80\x93\xda\xb1\xaf\xa9\x31\xf4\x87\xb1\xa3\xcf\xf6\x72\x83\x54\x0b\x3e\x3c\x73\xde\x2b\xad\x15\x59\xb5\x01\xc6\x66\x3f\x76\x6b\x8d\x45\xab\xb6\x07\x33\xa4\xd9\xff\xad\x95\xbc\x6b\xd7\xa2\x5c\xf5\x73\x03\x97\xf0\x60\xc0\x7f\xd0\x69\x83\x29\x25\x85\x9e\x8b\xd5\xf2\x1f\x89\x17\xfc\x1d\xeb\xac\x2e\x7d\x47\x74\xb0\xf6\xc3\x6c\x27\xb1\x26\xd4\xfa\xae\x8d\xd1\x8c\xcc\xd1\xf7\x45\xc2\x67\xb0\x8b\xc4\xd6\x17\xa9\xd7\x38\x68\x71\x2a\xd2\x5b\x97\x6a\xff\x18\x4c\x49\x61\x3f\x81\x35\x3c\x27\x5b\x3d\x8d\x7a\x6b\xd6\x00\x86\xc4\x37\xc0\xf1\xcc\x42\xec\x5d\x6b\x1b\xc2\xbd\x62\xbd\x6f\xc1\x77\xb5\xd2\x6f\x70\x92\x79\x4b\x4d\xd1\x89\x7c\x5f\x44\xe7\x7b\x9e\xf9\x81\xdb\x96\x7a\x2f\xc0\xa9\xc8\xcc\xe4\xd1\x9a\x5e\xf0\xdc\xd4\x76\x6a\x3d\x29\xc1\x20\x8b\x7a\xb5\x6f\x70\x7e\xc5\xa1\x28\xfb\x35\xdf\xda\x47\xe0\x1d\xc0\xb3\xf6\x69\x7e\xb6\x0d\xec\x74\xc3\x63\x16\x81\x7d\x9e\xb6\x13\x9f\x3a\x60\x76\x5e\x8a\x47\xed\x3b\x11\xf2\xa7\x38\x89\x93\x03\x08\x54\x95\x95\x2f\xf3\x02\xe6\x9d\xba\x7d\xb8\xaa\x9f\xf7\xdb\xf2\xac\x85\x2f\x3a\x58\x5d\x19\xd9\x5a\x24\xc0\x17\x4d\x22\x06\xba\x62\x5b\xd2\x0e\x1a\x12\xcc\x1e\xeb\x74\xe0\x47\xbe\x06\x2a\x33\x9b\xae\xb0\xb4\x4c\x26\x3c\x8f\x66\x47\xad\x69\x1c\xb9\xe6\x7a\xda\x6d\xcd\xa2\x1c\x55\x81\x11\x15\x83\x0e\xd1\xce\xa2\x04\x83\xd9\x73\xc0\x00\xd4\x04\xd3\x03\xa2\xa6\x32\xb6\x39\xf1\x45\x14\x85\x25\x4a\x73\xe3\x9c\xb3\x7a\x5b\x1c\x1b\x53\x25\xa2\xbb\x35\x19\x3d\xb2\x74\x6a\x6f\xc9\x50\xc4\x73\x6f\x81\xed\x42\xf3\x61\xae\xa3\x3c\x71\x3c\x3c\x3b\xd5\x0c\x82\xca\x8e\xd8\x66\x7c\xc0\xdc\x60\x06\x6a\xab\x03\x3d\xf2\xb5\x39\x8c\xbb\xf5\xa5\x0f\xb9\x6e\x85\x51\x27\x73\xb4\x06\x2d\x64\xba\x76\x72\x4b\x02\xfb\xba\xe6\xd4\x18\xc3\xb5\x9d\x79\x2c\xba\x49\x5a\x4b\x20\x41\x65\x80\x4d\xe0\xb5\xf6\x35\xbe\x91\xe2\x6e\xa6\x05\xbe\xd8\x21\xf3\x15\x3f\xad\xa6\xed\xc4\x54\x9f\xcc\x72\xc3\x7c\x06\x65\x85\xaa\xf4\x9a\x7e\x6b\xb3\xe0\x80\x5b\x68\x43\x6f\x5a\x4e\x6c\x69\xde\xbc\xdc\x16\x0c\x87\x3a\x04\x4d\x20\x95\xc5\x3d\x80\xd4\xc8\x54\x93\x72\xcd\xc4\x2b\x2c\x1f\xe5\xab\x7e\x68\xaf\x35\xc3\x0e\x18\xae\x2d\x95\x38\x0c\xdb\xd2\xbd\xcc\x1d\x90\x9d\x48\x13\x30\x77\xc7\x9a\x62\x5a\x5f\xb1\xc4\xbd\x50\xa6\x4e\xfa\xc7\x4f\xe9\x6b\xd1\x70\x39\x0c\x9a\xb0\x22\x4b\x03\xb1\x5f\x5a\x5f\x89\x9c\x0c\x0f\xab\xd1\xbb\x1f\xc3\x3f\x7e\xba\x09\xd7\xc7\x27\xff\x77\x00\x16\x75\x4e\xb9\x00\x90\x00\x00")
 
 func bindataGoBytes() ([]byte, error) {
 	return bindataRead(
@@ -260,7 +554,7 @@ func bindataGo() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "bindata.go", size: 28672, mode: os.FileMode(420), modTime: time.Unix(1585322116, 0)}
+	info := bindataFileInfo{name: "bindata.go", size: 73728, mode: os.FileMode(420), modTime: time.Unix(1792414108, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -317,15 +611,29 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"1_create_table_consent_rule.down.sql":                   _1_create_table_consent_ruleDownSql,
-	"1_create_table_consent_rule.up.sql":                     _1_create_table_consent_ruleUpSql,
-	"2_alter_consent_record_add_version_uuid.down.sql":       _2_alter_consent_record_add_version_uuidDownSql,
-	"2_alter_consent_record_add_version_uuid.up.sql":         _2_alter_consent_record_add_version_uuidUpSql,
-	"3_rename_resource_to_data_class.down.sql":               _3_rename_resource_to_data_classDownSql,
-	"3_rename_resource_to_data_class.up.sql":                 _3_rename_resource_to_data_classUpSql,
-	"4_alter_consent_record_make_valid_to_optional.down.sql": _4_alter_consent_record_make_valid_to_optionalDownSql,
-	"4_alter_consent_record_make_valid_to_optional.up.sql":   _4_alter_consent_record_make_valid_to_optionalUpSql,
-	"bindata.go":                                             bindataGo,
+	"10_create_table_idempotency_key.down.sql":                 _10_create_table_idempotency_keyDownSql,
+	"10_create_table_idempotency_key.up.sql":                   _10_create_table_idempotency_keyUpSql,
+	"11_alter_idempotency_key_add_body.down.sql":               _11_alter_idempotency_key_add_bodyDownSql,
+	"11_alter_idempotency_key_add_body.up.sql":                 _11_alter_idempotency_key_add_bodyUpSql,
+	"1_create_table_consent_rule.down.sql":                     _1_create_table_consent_ruleDownSql,
+	"1_create_table_consent_rule.up.sql":                       _1_create_table_consent_ruleUpSql,
+	"2_alter_consent_record_add_version_uuid.down.sql":         _2_alter_consent_record_add_version_uuidDownSql,
+	"2_alter_consent_record_add_version_uuid.up.sql":           _2_alter_consent_record_add_version_uuidUpSql,
+	"3_rename_resource_to_data_class.down.sql":                 _3_rename_resource_to_data_classDownSql,
+	"3_rename_resource_to_data_class.up.sql":                   _3_rename_resource_to_data_classUpSql,
+	"4_alter_consent_record_make_valid_to_optional.down.sql":   _4_alter_consent_record_make_valid_to_optionalDownSql,
+	"4_alter_consent_record_make_valid_to_optional.up.sql":     _4_alter_consent_record_make_valid_to_optionalUpSql,
+	"5_alter_consent_record_add_tombstone.down.sql":            _5_alter_consent_record_add_tombstoneDownSql,
+	"5_alter_consent_record_add_tombstone.up.sql":              _5_alter_consent_record_add_tombstoneUpSql,
+	"6_create_table_audit_entry.down.sql":                      _6_create_table_audit_entryDownSql,
+	"6_create_table_audit_entry.up.sql":                        _6_create_table_audit_entryUpSql,
+	"7_create_table_webhook_delivery.down.sql":                 _7_create_table_webhook_deliveryDownSql,
+	"7_create_table_webhook_delivery.up.sql":                   _7_create_table_webhook_deliveryUpSql,
+	"8_alter_consent_record_add_expiry_notifications.down.sql": _8_alter_consent_record_add_expiry_notificationsDownSql,
+	"8_alter_consent_record_add_expiry_notifications.up.sql":   _8_alter_consent_record_add_expiry_notificationsUpSql,
+	"9_alter_consent_record_add_created_at.down.sql":           _9_alter_consent_record_add_created_atDownSql,
+	"9_alter_consent_record_add_created_at.up.sql":             _9_alter_consent_record_add_created_atUpSql,
+	"bindata.go":                                               bindataGo,
 }
 
 // AssetDir returns the file names below a certain
@@ -369,15 +677,29 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"1_create_table_consent_rule.down.sql":                   &bintree{_1_create_table_consent_ruleDownSql, map[string]*bintree{}},
-	"1_create_table_consent_rule.up.sql":                     &bintree{_1_create_table_consent_ruleUpSql, map[string]*bintree{}},
-	"2_alter_consent_record_add_version_uuid.down.sql":       &bintree{_2_alter_consent_record_add_version_uuidDownSql, map[string]*bintree{}},
-	"2_alter_consent_record_add_version_uuid.up.sql":         &bintree{_2_alter_consent_record_add_version_uuidUpSql, map[string]*bintree{}},
-	"3_rename_resource_to_data_class.down.sql":               &bintree{_3_rename_resource_to_data_classDownSql, map[string]*bintree{}},
-	"3_rename_resource_to_data_class.up.sql":                 &bintree{_3_rename_resource_to_data_classUpSql, map[string]*bintree{}},
-	"4_alter_consent_record_make_valid_to_optional.down.sql": &bintree{_4_alter_consent_record_make_valid_to_optionalDownSql, map[string]*bintree{}},
-	"4_alter_consent_record_make_valid_to_optional.up.sql":   &bintree{_4_alter_consent_record_make_valid_to_optionalUpSql, map[string]*bintree{}},
-	"bindata.go":                                             &bintree{bindataGo, map[string]*bintree{}},
+	"10_create_table_idempotency_key.down.sql":                 &bintree{_10_create_table_idempotency_keyDownSql, map[string]*bintree{}},
+	"10_create_table_idempotency_key.up.sql":                   &bintree{_10_create_table_idempotency_keyUpSql, map[string]*bintree{}},
+	"11_alter_idempotency_key_add_body.down.sql":               &bintree{_11_alter_idempotency_key_add_bodyDownSql, map[string]*bintree{}},
+	"11_alter_idempotency_key_add_body.up.sql":                 &bintree{_11_alter_idempotency_key_add_bodyUpSql, map[string]*bintree{}},
+	"1_create_table_consent_rule.down.sql":                     &bintree{_1_create_table_consent_ruleDownSql, map[string]*bintree{}},
+	"1_create_table_consent_rule.up.sql":                       &bintree{_1_create_table_consent_ruleUpSql, map[string]*bintree{}},
+	"2_alter_consent_record_add_version_uuid.down.sql":         &bintree{_2_alter_consent_record_add_version_uuidDownSql, map[string]*bintree{}},
+	"2_alter_consent_record_add_version_uuid.up.sql":           &bintree{_2_alter_consent_record_add_version_uuidUpSql, map[string]*bintree{}},
+	"3_rename_resource_to_data_class.down.sql":                 &bintree{_3_rename_resource_to_data_classDownSql, map[string]*bintree{}},
+	"3_rename_resource_to_data_class.up.sql":                   &bintree{_3_rename_resource_to_data_classUpSql, map[string]*bintree{}},
+	"4_alter_consent_record_make_valid_to_optional.down.sql":   &bintree{_4_alter_consent_record_make_valid_to_optionalDownSql, map[string]*bintree{}},
+	"4_alter_consent_record_make_valid_to_optional.up.sql":     &bintree{_4_alter_consent_record_make_valid_to_optionalUpSql, map[string]*bintree{}},
+	"5_alter_consent_record_add_tombstone.down.sql":            &bintree{_5_alter_consent_record_add_tombstoneDownSql, map[string]*bintree{}},
+	"5_alter_consent_record_add_tombstone.up.sql":              &bintree{_5_alter_consent_record_add_tombstoneUpSql, map[string]*bintree{}},
+	"6_create_table_audit_entry.down.sql":                      &bintree{_6_create_table_audit_entryDownSql, map[string]*bintree{}},
+	"6_create_table_audit_entry.up.sql":                        &bintree{_6_create_table_audit_entryUpSql, map[string]*bintree{}},
+	"7_create_table_webhook_delivery.down.sql":                 &bintree{_7_create_table_webhook_deliveryDownSql, map[string]*bintree{}},
+	"7_create_table_webhook_delivery.up.sql":                   &bintree{_7_create_table_webhook_deliveryUpSql, map[string]*bintree{}},
+	"8_alter_consent_record_add_expiry_notifications.down.sql": &bintree{_8_alter_consent_record_add_expiry_notificationsDownSql, map[string]*bintree{}},
+	"8_alter_consent_record_add_expiry_notifications.up.sql":   &bintree{_8_alter_consent_record_add_expiry_notificationsUpSql, map[string]*bintree{}},
+	"9_alter_consent_record_add_created_at.down.sql":           &bintree{_9_alter_consent_record_add_created_atDownSql, map[string]*bintree{}},
+	"9_alter_consent_record_add_created_at.up.sql":             &bintree{_9_alter_consent_record_add_created_atUpSql, map[string]*bintree{}},
+	"bindata.go":                                               &bintree{bindataGo, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -36,75 +36,90 @@ func (m *MockConsentStoreClient) EXPECT() *MockConsentStoreClientMockRecorder {
 }
 
 // ConsentAuth mocks base method
-func (m *MockConsentStoreClient) ConsentAuth(context context.Context, custodian, subject, actor, dataClass string, checkpoint *time.Time) (bool, error) {
+func (m *MockConsentStoreClient) ConsentAuth(ctx context.Context, custodian, subject, actor, dataClass string, checkpoint *time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConsentAuth", context, custodian, subject, actor, dataClass, checkpoint)
+	ret := m.ctrl.Call(m, "ConsentAuth", ctx, custodian, subject, actor, dataClass, checkpoint)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ConsentAuth indicates an expected call of ConsentAuth
-func (mr *MockConsentStoreClientMockRecorder) ConsentAuth(context, custodian, subject, actor, dataClass, checkpoint interface{}) *gomock.Call {
+func (mr *MockConsentStoreClientMockRecorder) ConsentAuth(ctx, custodian, subject, actor, dataClass, checkpoint interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsentAuth", reflect.TypeOf((*MockConsentStoreClient)(nil).ConsentAuth), context, custodian, subject, actor, dataClass, checkpoint)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsentAuth", reflect.TypeOf((*MockConsentStoreClient)(nil).ConsentAuth), ctx, custodian, subject, actor, dataClass, checkpoint)
 }
 
 // RecordConsent mocks base method
-func (m *MockConsentStoreClient) RecordConsent(context context.Context, consent []pkg.PatientConsent) error {
+func (m *MockConsentStoreClient) RecordConsent(ctx context.Context, consent []pkg.PatientConsent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RecordConsent", context, consent)
+	ret := m.ctrl.Call(m, "RecordConsent", ctx, consent)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RecordConsent indicates an expected call of RecordConsent
-func (mr *MockConsentStoreClientMockRecorder) RecordConsent(context, consent interface{}) *gomock.Call {
+func (mr *MockConsentStoreClientMockRecorder) RecordConsent(ctx, consent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordConsent", reflect.TypeOf((*MockConsentStoreClient)(nil).RecordConsent), context, consent)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordConsent", reflect.TypeOf((*MockConsentStoreClient)(nil).RecordConsent), ctx, consent)
 }
 
 // QueryConsent mocks base method
-func (m *MockConsentStoreClient) QueryConsent(context context.Context, actor, custodian, subject *string, validAt *time.Time) ([]pkg.PatientConsent, error) {
+func (m *MockConsentStoreClient) QueryConsent(ctx context.Context, actor, custodian, subject *string, validAt *time.Time, includeDeleted bool) ([]pkg.PatientConsent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryConsent", context, actor, custodian, subject, validAt)
+	ret := m.ctrl.Call(m, "QueryConsent", ctx, actor, custodian, subject, validAt, includeDeleted)
 	ret0, _ := ret[0].([]pkg.PatientConsent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryConsent indicates an expected call of QueryConsent
-func (mr *MockConsentStoreClientMockRecorder) QueryConsent(context, actor, custodian, subject, validAt interface{}) *gomock.Call {
+func (mr *MockConsentStoreClientMockRecorder) QueryConsent(ctx, actor, custodian, subject, validAt, includeDeleted interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryConsent", reflect.TypeOf((*MockConsentStoreClient)(nil).QueryConsent), context, actor, custodian, subject, validAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryConsent", reflect.TypeOf((*MockConsentStoreClient)(nil).QueryConsent), ctx, actor, custodian, subject, validAt, includeDeleted)
 }
 
 // DeleteConsentRecordByHash mocks base method
-func (m *MockConsentStoreClient) DeleteConsentRecordByHash(context context.Context, consentRecordHash string) (bool, error) {
+func (m *MockConsentStoreClient) DeleteConsentRecordByHash(ctx context.Context, consentRecordHash, reason, deletedBy string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteConsentRecordByHash", context, consentRecordHash)
+	ret := m.ctrl.Call(m, "DeleteConsentRecordByHash", ctx, consentRecordHash, reason, deletedBy)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteConsentRecordByHash indicates an expected call of DeleteConsentRecordByHash
-func (mr *MockConsentStoreClientMockRecorder) DeleteConsentRecordByHash(context, consentRecordHash interface{}) *gomock.Call {
+func (mr *MockConsentStoreClientMockRecorder) DeleteConsentRecordByHash(ctx, consentRecordHash, reason, deletedBy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConsentRecordByHash", reflect.TypeOf((*MockConsentStoreClient)(nil).DeleteConsentRecordByHash), context, consentRecordHash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConsentRecordByHash", reflect.TypeOf((*MockConsentStoreClient)(nil).DeleteConsentRecordByHash), ctx, consentRecordHash, reason, deletedBy)
 }
 
 // FindConsentRecordByHash mocks base method
-func (m *MockConsentStoreClient) FindConsentRecordByHash(context context.Context, consentRecordHash string, latest bool) (pkg.ConsentRecord, error) {
+func (m *MockConsentStoreClient) FindConsentRecordByHash(ctx context.Context, consentRecordHash string, latest bool) (pkg.ConsentRecord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindConsentRecordByHash", context, consentRecordHash, latest)
+	ret := m.ctrl.Call(m, "FindConsentRecordByHash", ctx, consentRecordHash, latest)
 	ret0, _ := ret[0].(pkg.ConsentRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindConsentRecordByHash indicates an expected call of FindConsentRecordByHash
-func (mr *MockConsentStoreClientMockRecorder) FindConsentRecordByHash(context, consentRecordHash, latest interface{}) *gomock.Call {
+func (mr *MockConsentStoreClientMockRecorder) FindConsentRecordByHash(ctx, consentRecordHash, latest interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConsentRecordByHash", reflect.TypeOf((*MockConsentStoreClient)(nil).FindConsentRecordByHash), context, consentRecordHash, latest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConsentRecordByHash", reflect.TypeOf((*MockConsentStoreClient)(nil).FindConsentRecordByHash), ctx, consentRecordHash, latest)
+}
+
+// EraseSubject mocks base method
+func (m *MockConsentStoreClient) EraseSubject(ctx context.Context, subject, erasedBy string) (pkg.ErasureCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseSubject", ctx, subject, erasedBy)
+	ret0, _ := ret[0].(pkg.ErasureCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseSubject indicates an expected call of EraseSubject
+func (mr *MockConsentStoreClientMockRecorder) EraseSubject(ctx, subject, erasedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseSubject", reflect.TypeOf((*MockConsentStoreClient)(nil).EraseSubject), ctx, subject, erasedBy)
 }
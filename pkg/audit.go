@@ -0,0 +1,75 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AuditActionRetentionPurge is the audit action for a consent chain that was purged by the retention policy
+const AuditActionRetentionPurge = "retention.purge"
+
+// AuditActionRetentionArchive is the audit action for a consent chain that was archived and removed by the retention policy
+const AuditActionRetentionArchive = "retention.archive"
+
+// AuditEntry defines struct for the audit_entry table. Details holds a JSON document specific for the action.
+type AuditEntry struct {
+	ID        uint      `gorm:"AUTO_INCREMENT"`
+	Timestamp time.Time `gorm:"not null"`
+	Action    string    `gorm:"not null"`
+	Actor     string
+	Subject   *string
+	Details   string
+}
+
+// TableName returns the SQL table for this type
+func (AuditEntry) TableName() string {
+	return "audit_entry"
+}
+
+// writeAuditEntry adds an entry to the audit trail within the given transaction
+func writeAuditEntry(tx *gorm.DB, action string, actor string, subject *string, details interface{}) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&AuditEntry{
+		Timestamp: time.Now(),
+		Action:    action,
+		Actor:     actor,
+		Subject:   subject,
+		Details:   string(data),
+	}).Error
+}
+
+// AuditEntries returns all audit entries for the given subject, oldest first
+func (cs *ConsentStore) AuditEntries(ctx context.Context, subject string) ([]AuditEntry, error) {
+	var entries []AuditEntry
+
+	if err := cs.db(ctx).Debug().Where("subject = ?", subject).Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
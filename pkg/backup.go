@@ -0,0 +1,202 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gosqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// ErrorMissingBackupDir is returned when a backup is requested without a configured backupDir
+var ErrorMissingBackupDir = errors.New("backupDir must be configured for online backups")
+
+const (
+	backupPrefix     = "consent-store-"
+	backupExtension  = ".db"
+	backupTimeLayout = "20060102-150405.000"
+)
+
+// Backup is a consistent snapshot of the database written by the online backup of SQLite
+type Backup struct {
+	File      string    `json:"file"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Backup writes a consistent snapshot of the live database to the backup directory using the online backup api of SQLite.
+// Writers are only blocked while the pages are copied. Only the configured number of most recent backups is kept.
+func (cs *ConsentStore) Backup(ctx context.Context) (Backup, error) {
+	if cs.Config.BackupDir == "" {
+		return Backup{}, ErrorMissingBackupDir
+	}
+	if cs.sqlDb == nil {
+		return Backup{}, errors.New("backups are only available in server mode")
+	}
+
+	cs.backupMutex.Lock()
+	defer cs.backupMutex.Unlock()
+
+	if err := os.MkdirAll(cs.Config.BackupDir, 0700); err != nil {
+		return Backup{}, err
+	}
+
+	now := time.Now().UTC()
+	file := filepath.Join(cs.Config.BackupDir, backupPrefix+now.Format(backupTimeLayout)+backupExtension)
+	// the backup is written to a temporary file first, so an incomplete backup is never mistaken for a successful one
+	tmp := file + ".tmp"
+
+	if err := cs.backupTo(ctx, tmp); err != nil {
+		os.Remove(tmp)
+		return Backup{}, fmt.Errorf("unable to backup database: %w", err)
+	}
+	// SQLite creates the file readable for everyone, the backup holds the same personal data as the database itself
+	if err := os.Chmod(tmp, 0600); err != nil {
+		os.Remove(tmp)
+		return Backup{}, err
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return Backup{}, err
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return Backup{}, err
+	}
+	backup := Backup{File: file, Size: info.Size(), CreatedAt: now}
+
+	if err := cs.rotateBackups(); err != nil {
+		Logger().Errorf("error removing old backups: %v", err)
+	}
+
+	return backup, nil
+}
+
+// backupTo copies all pages of the database to the given file in a single step
+func (cs *ConsentStore) backupTo(ctx context.Context, file string) error {
+	destDb, err := sql.Open("sqlite3", file)
+	if err != nil {
+		return err
+	}
+	defer destDb.Close()
+
+	dest, err := destDb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	src, err := cs.sqlDb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return dest.Raw(func(destConn interface{}) error {
+		return src.Raw(func(srcConn interface{}) error {
+			d, ok := destConn.(*gosqlite3.SQLiteConn)
+			s, ok2 := srcConn.(*gosqlite3.SQLiteConn)
+			if !ok || !ok2 {
+				return errors.New("online backups are only supported for SQLite")
+			}
+
+			b, err := d.Backup("main", s, "main")
+			if err != nil {
+				return err
+			}
+
+			if _, err := b.Step(-1); err != nil {
+				b.Finish()
+				return err
+			}
+			return b.Finish()
+		})
+	})
+}
+
+// Backups lists the backups in the backup directory, newest first
+func (cs *ConsentStore) Backups() ([]Backup, error) {
+	if cs.Config.BackupDir == "" {
+		return nil, ErrorMissingBackupDir
+	}
+
+	infos, err := ioutil.ReadDir(cs.Config.BackupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupExtension) {
+			continue
+		}
+
+		createdAt, err := time.Parse(backupTimeLayout, strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupExtension))
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, Backup{
+			File:      filepath.Join(cs.Config.BackupDir, name),
+			Size:      info.Size(),
+			CreatedAt: createdAt,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// rotateBackups removes all but the configured number of most recent backups
+func (cs *ConsentStore) rotateBackups() error {
+	retain := cs.Config.BackupRetain
+	if retain <= 0 {
+		retain = ConfigBackupRetainDefault
+	}
+
+	backups, err := cs.Backups()
+	if err != nil || len(backups) <= retain {
+		return err
+	}
+
+	for _, b := range backups[retain:] {
+		if err := os.Remove(b.File); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_Backup(t *testing.T) {
+	t.Run("writes a consistent copy of the database", func(t *testing.T) {
+		dir := testDirectory(t)
+		defer os.RemoveAll(dir)
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.Config.BackupDir = dir
+		client.RecordConsent(context.TODO(), []PatientConsent{endingPatientConsent("subject", "actor", day)})
+
+		backup, err := client.Backup(context.TODO())
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, backup.Size > 0)
+		db, err := sql.Open("sqlite3", backup.File)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer db.Close()
+		var count int
+		assert.NoError(t, db.QueryRow("SELECT count(*) FROM patient_consent").Scan(&count))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("rotates backups", func(t *testing.T) {
+		dir := testDirectory(t)
+		defer os.RemoveAll(dir)
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.Config.BackupDir = dir
+		client.Config.BackupRetain = 2
+
+		var last Backup
+		for i := 0; i < 3; i++ {
+			last, _ = client.Backup(context.TODO())
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		backups, err := client.Backups()
+		if assert.NoError(t, err) && assert.Len(t, backups, 2) {
+			assert.Equal(t, last.File, backups[0].File)
+		}
+	})
+
+	t.Run("requires a backup dir", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+
+		_, err := client.Backup(context.TODO())
+
+		assert.Equal(t, ErrorMissingBackupDir, err)
+	})
+}
+
+func TestBackupDiagnosticResult_String(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, "last: none", backupDiagnosticResult{}.String())
+	})
+
+	t.Run("last", func(t *testing.T) {
+		createdAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+		result := backupDiagnosticResult{backups: []Backup{{Size: 1024, CreatedAt: createdAt}, {}}}
+
+		assert.Equal(t, "last: 2020-01-01T12:00:00Z, size: 1024 bytes, backups: 2", result.String())
+	})
+}
@@ -0,0 +1,86 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuthCacheHint tells a client how long the outcome of a consent check may be cached and identifies the state it's based on
+type AuthCacheHint struct {
+	// MaxAge is the period the outcome may be cached, 0 when it may not be cached
+	MaxAge time.Duration
+	// ETag changes when the outcome or the latest records it's based on change
+	ETag string
+}
+
+// ConsentAuthCacheHint returns the cache hint for the outcome of ConsentAuth for the given combination. When the check is done without a checkpoint,
+// the max age ends when the outcome changes because a record of the data class starts or ends. The max age never exceeds the configured checkMaxAge.
+func (cs *ConsentStore) ConsentAuthCacheHint(ctx context.Context, custodian string, subject string, actor string, dataClass string, checkpoint *time.Time, granted bool, now time.Time) (AuthCacheHint, error) {
+	var records []ConsentRecord
+	if err := cs.db(ctx).Debug().
+		Preload("DataClasses").
+		Where("id IN (?)", latestRecords(cs.db(ctx), PatientConsent{Custodian: custodian, Subject: subject, Actor: actor})).
+		Find(&records).Error; err != nil {
+		return AuthCacheHint{}, err
+	}
+
+	maxAge := cs.checkMaxAge
+	var hashes []string
+	for _, r := range records {
+		hashes = append(hashes, r.Hash)
+
+		if checkpoint != nil || !r.hasDataClass(dataClass) {
+			continue
+		}
+		for _, change := range []*time.Time{&r.ValidFrom, r.ValidTo} {
+			if change != nil && change.After(now) && change.Sub(now) < maxAge {
+				maxAge = change.Sub(now)
+			}
+		}
+	}
+	sort.Strings(hashes)
+
+	tag := sha256.Sum256([]byte(fmt.Sprintf("%t|%s|%s", granted, dataClass, strings.Join(hashes, ","))))
+	return AuthCacheHint{
+		MaxAge: maxAge.Truncate(time.Second),
+		ETag:   fmt.Sprintf("\"%s\"", hex.EncodeToString(tag[:16])),
+	}, nil
+}
+
+// CheckMaxAgePeriod returns the maximum period clients may cache the outcome of a consent check, the default is used when it's not configured.
+// A period of 0 disables caching.
+func (c ConsentStoreConfig) CheckMaxAgePeriod() (time.Duration, error) {
+	maxAge := c.CheckMaxAge
+	if maxAge == "" {
+		maxAge = ConfigCheckMaxAgeDefault
+	}
+
+	d, err := ParseDuration(maxAge)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ConfigCheckMaxAge, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_ConsentAuthCacheHint(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	now := time.Now()
+	hint := func(dataClass string, checkpoint *time.Time, granted bool) AuthCacheHint {
+		h, err := client.ConsentAuthCacheHint(context.TODO(), "custodian", "subject", "actor", dataClass, checkpoint, granted, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	t.Run("no consent can be cached for the maximum", func(t *testing.T) {
+		h := hint("resource", nil, false)
+
+		assert.Equal(t, 5*time.Minute, h.MaxAge)
+		assert.NotEmpty(t, h.ETag)
+	})
+
+	pc := endingPatientConsent("subject", "actor", 2*time.Minute)
+	if err := client.RecordConsent(context.TODO(), []PatientConsent{pc}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("max-age ends when the record ends", func(t *testing.T) {
+		h := hint("resource", nil, true)
+
+		assert.Equal(t, 2*time.Minute, h.MaxAge)
+	})
+
+	t.Run("records of other data classes don't shorten the max-age", func(t *testing.T) {
+		h := hint("other", nil, false)
+
+		assert.Equal(t, 5*time.Minute, h.MaxAge)
+	})
+
+	t.Run("end of the record doesn't matter for a checkpoint", func(t *testing.T) {
+		h := hint("resource", &now, true)
+
+		assert.Equal(t, 5*time.Minute, h.MaxAge)
+	})
+
+	t.Run("max-age ends when a record starts", func(t *testing.T) {
+		other := endingPatientConsent("subject", "actor", day)
+		other.ID = pc.ID
+		other.Records[0].ValidFrom = now.Add(time.Minute)
+		other.Records[0].DataClasses = []DataClass{{Code: "starting"}}
+		if err := client.RecordConsent(context.TODO(), []PatientConsent{other}); err != nil {
+			t.Fatal(err)
+		}
+
+		h := hint("starting", nil, false)
+
+		assert.Equal(t, time.Minute, h.MaxAge)
+	})
+
+	t.Run("ETag changes with the outcome and the records", func(t *testing.T) {
+		before := hint("resource", nil, true)
+		assert.NotEqual(t, before.ETag, hint("resource", nil, false).ETag)
+
+		update := pc
+		update.Records = []ConsentRecord{{
+			ValidFrom:    now,
+			Hash:         random.String(8),
+			PreviousHash: &pc.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+		if err := client.RecordConsent(context.TODO(), []PatientConsent{update}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, before.ETag, hint("resource", nil, true).ETag)
+	})
+
+	t.Run("0 disables caching", func(t *testing.T) {
+		client.checkMaxAge = 0
+
+		assert.Equal(t, time.Duration(0), hint("resource", nil, true).MaxAge)
+	})
+}
+
+func TestConsentStoreConfig_CheckMaxAgePeriod(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		d, err := ConsentStoreConfig{}.CheckMaxAgePeriod()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, d)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ConsentStoreConfig{CheckMaxAge: "soon"}.CheckMaxAgePeriod()
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// configEntry is a single entry of a list valued config key, e.g. caller=gateway;check=100
+type configEntry map[string]string
+
+// parseConfigEntries parses a list valued config key. Entries are separated by a comma,
+// the key=value pairs within an entry by a semicolon. Only the first = of a pair separates the key from the value.
+func parseConfigEntries(value string) ([]configEntry, error) {
+	var entries []configEntry
+
+	for _, e := range strings.Split(value, ",") {
+		if strings.TrimSpace(e) == "" {
+			continue
+		}
+
+		entry := configEntry{}
+		for _, pair := range strings.Split(e, ";") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("invalid config entry [%s], expected key=value pairs", e)
+			}
+			entry[kv[0]] = kv[1]
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
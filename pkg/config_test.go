@@ -0,0 +1,49 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigEntries(t *testing.T) {
+	t.Run("empty value returns no entries", func(t *testing.T) {
+		entries, err := parseConfigEntries("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("multiple entries", func(t *testing.T) {
+		entries, err := parseConfigEntries("caller=CN=gateway;check=10, caller=10.0.0.1;write=5;")
+
+		if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+			assert.Equal(t, configEntry{"caller": "CN=gateway", "check": "10"}, entries[0])
+			assert.Equal(t, configEntry{"caller": "10.0.0.1", "write": "5"}, entries[1])
+		}
+	})
+
+	t.Run("pair without value returns error", func(t *testing.T) {
+		_, err := parseConfigEntries("caller")
+
+		assert.Error(t, err)
+	})
+}
@@ -41,13 +41,52 @@ import (
 	"github.com/nuts-foundation/nuts-consent-store/migrations"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // ConsentStoreConfig holds the config for the consent store
 type ConsentStoreConfig struct {
-	Connectionstring string
-	Mode             string
-	Address          string
+	Connectionstring    string
+	Mode                string
+	Address             string
+	ListenAddress       string
+	GrpcListenAddress   string
+	TlsCertFile         string
+	TlsKeyFile          string
+	TlsCAFile           string
+	RateLimitCheck      int
+	RateLimitQuery      int
+	RateLimitWrite      int
+	RateLimits          string
+	TombstoneRetention  string
+	RetentionPeriod     string
+	RetentionPolicies   string
+	RetentionMode       string
+	RetentionArchiveDir string
+	RetentionInterval   string
+	EventBufferSize     int
+	Webhooks            string
+	WebhookMaxAttempts  int
+	ExpiryHorizon       string
+	ExpiryInterval      string
+	ImportBatchSize     int
+	BackupDir           string
+	BackupRetain        int
+	CheckMaxAge         string
+	// IdempotencyKeyRetention is the period responses are kept for retries with the same Idempotency-Key
+	IdempotencyKeyRetention string
+	// TracingExporter, TracingEndpoint and TracingFile configure where the OpenTelemetry spans are exported to
+	TracingExporter string
+	TracingEndpoint string
+	TracingFile     string
+	// client mode
+	ClientTimeout          string
+	ClientMaxAttempts      int
+	ClientRetryBackoff     string
+	ClientBreakerThreshold int
+	ClientBreakerCooldown  string
+	ClientCacheSize        int
 }
 
 // ConfigConnectionString is the config name for the connection string
@@ -56,17 +95,198 @@ const ConfigConnectionString = "connectionstring"
 // ConfigMode is the config name for the mode of the store (server, client)
 const ConfigMode = "mode"
 
-// ConfigAddress is the config name for the api address when running in client mode
+// ConfigAddress is the config name for the api address when running in client mode, the gRPC api is used when it starts with grpc://
 const ConfigAddress = "address"
 
+// ConfigListenAddress is the config name for the address the standalone server listens on
+const ConfigListenAddress = "listenAddress"
+
+// ConfigGrpcListenAddress is the config name for the address the standalone server listens on for gRPC calls, empty disables the gRPC api
+const ConfigGrpcListenAddress = "grpcListenAddress"
+
+// ConfigTlsCertFile is the config name for the PEM certificate file. The server certificate in server mode, the client certificate in client mode
+const ConfigTlsCertFile = "tlsCertFile"
+
+// ConfigTlsKeyFile is the config name for the PEM private key file belonging to the certificate
+const ConfigTlsKeyFile = "tlsKeyFile"
+
+// ConfigTlsCAFile is the config name for the PEM CA bundle. Used to verify client certificates in server mode (mTLS) and the server certificate in client mode
+const ConfigTlsCAFile = "tlsCAFile"
+
+// ConfigRateLimitCheck is the config name for the default number of consent checks per minute per caller, 0 is unlimited
+const ConfigRateLimitCheck = "rateLimitCheck"
+
+// ConfigRateLimitQuery is the config name for the default number of consent queries per minute per caller, 0 is unlimited
+const ConfigRateLimitQuery = "rateLimitQuery"
+
+// ConfigRateLimitWrite is the config name for the default number of consent writes per minute per caller, 0 is unlimited
+const ConfigRateLimitWrite = "rateLimitWrite"
+
+// ConfigRateLimits is the config name for the per caller rate limits, e.g. caller=gateway;check=600;query=60,caller=10.0.0.1;write=10
+const ConfigRateLimits = "rateLimits"
+
+// ConfigTombstoneRetention is the config name for the period deleted consent records are kept before they can be purged, e.g. 365d or 720h
+const ConfigTombstoneRetention = "tombstoneRetention"
+
+// ConfigRetentionPeriod is the config name for the default period consent chains are kept after their latest record expired, e.g. 3650d. Empty keeps them forever
+const ConfigRetentionPeriod = "retentionPeriod"
+
+// ConfigRetentionPolicies is the config name for the per data class or custodian retention periods, e.g. dataClass=urn:oid:1.3.6.1.4.1.54851.1:MEDICAL;period=3650d,custodian=urn:oid:2.16.840.1.113883.2.4.6.1:00000007;period=1825d
+const ConfigRetentionPolicies = "retentionPolicies"
+
+// ConfigRetentionMode is the config name for what happens with chains past their retention period: purge or archive
+const ConfigRetentionMode = "retentionMode"
+
+// ConfigRetentionArchiveDir is the config name for the directory expired chains are written to in the archive retention mode
+const ConfigRetentionArchiveDir = "retentionArchiveDir"
+
+// ConfigRetentionInterval is the config name for the interval at which the retention policies are applied in the background
+const ConfigRetentionInterval = "retentionInterval"
+
+// ConfigEventBufferSize is the config name for the number of recent events kept for resuming event stream subscribers
+const ConfigEventBufferSize = "eventBufferSize"
+
+// ConfigWebhooks is the config name for the webhooks that receive consent events, e.g. url=https://example.com/hook;secret=s3cr3t;custodian=urn:oid:2.16.840.1.113883.2.4.6.1:00000007
+const ConfigWebhooks = "webhooks"
+
+// ConfigWebhookMaxAttempts is the config name for the number of failed attempts after which a webhook delivery is dead
+const ConfigWebhookMaxAttempts = "webhookMaxAttempts"
+
+// ConfigExpiryHorizon is the config name for the period before the end of a consent record in which a ConsentExpiring event is emitted, e.g. 30d
+const ConfigExpiryHorizon = "expiryHorizon"
+
+// ConfigExpiryInterval is the config name for the interval at which expiring and expired consent records are looked for in the background
+const ConfigExpiryInterval = "expiryInterval"
+
+// ConfigImportBatchSize is the config name for the number of consents of a bulk import that are recorded in a single transaction
+const ConfigImportBatchSize = "importBatchSize"
+
+// ConfigBackupDir is the config name for the directory online backups are written to
+const ConfigBackupDir = "backupDir"
+
+// ConfigBackupRetain is the config name for the number of online backups that are kept
+const ConfigBackupRetain = "backupRetain"
+
+// ConfigCheckMaxAge is the config name for the maximum period clients may cache the outcome of a consent check, 0 disables caching
+const ConfigCheckMaxAge = "checkMaxAge"
+
+// ConfigIdempotencyKeyRetention is the config name for the period the response to a write request is kept for retries with the same Idempotency-Key, e.g. 24h
+const ConfigIdempotencyKeyRetention = "idempotencyKeyRetention"
+
+// ConfigTracingExporter is the config name for where the spans are exported to: none, otlp, stdout or file
+const ConfigTracingExporter = "tracingExporter"
+
+// ConfigTracingEndpoint is the config name for the URL of the OTLP/HTTP collector, e.g. http://localhost:4318
+const ConfigTracingEndpoint = "tracingEndpoint"
+
+// ConfigTracingFile is the config name for the file the spans are written to by the file exporter
+const ConfigTracingFile = "tracingFile"
+
+// ConfigClientTimeout is the config name for the timeout of a single call to the server in client mode
+const ConfigClientTimeout = "clientTimeout"
+
+// ConfigClientMaxAttempts is the config name for the number of attempts of an idempotent call to the server in client mode
+const ConfigClientMaxAttempts = "clientMaxAttempts"
+
+// ConfigClientRetryBackoff is the config name for the base wait time between attempts in client mode, it doubles after every attempt and is jittered
+const ConfigClientRetryBackoff = "clientRetryBackoff"
+
+// ConfigClientBreakerThreshold is the config name for the number of consecutive failed calls after which the client fails fast, 0 disables the circuit breaker
+const ConfigClientBreakerThreshold = "clientBreakerThreshold"
+
+// ConfigClientBreakerCooldown is the config name for the period the client fails fast before the server is tried again
+const ConfigClientBreakerCooldown = "clientBreakerCooldown"
+
+// ConfigClientCacheSize is the config name for the number of consent check outcomes cached in client mode, 0 disables the cache
+const ConfigClientCacheSize = "clientCacheSize"
+
 // ConfigConnectionStringDefault is the default db connection string
 const ConfigConnectionStringDefault = ":memory:"
 
+// ConfigListenAddressDefault is the default listen address for the standalone server
+const ConfigListenAddressDefault = ":1323"
+
+// ConfigTombstoneRetentionDefault is the default period deleted consent records are kept
+const ConfigTombstoneRetentionDefault = "365d"
+
+// ConfigEventBufferSizeDefault is the default number of recent events kept
+const ConfigEventBufferSizeDefault = 1000
+
+// ConfigRetentionIntervalDefault is the default interval for applying the retention policies
+const ConfigRetentionIntervalDefault = "24h"
+
+// ConfigWebhookMaxAttemptsDefault is the default number of attempts of a webhook delivery
+const ConfigWebhookMaxAttemptsDefault = 10
+
+// ConfigExpiryHorizonDefault is the default period in which consent records are considered expiring
+const ConfigExpiryHorizonDefault = "30d"
+
+// ConfigExpiryIntervalDefault is the default interval for looking for expiring consent records
+const ConfigExpiryIntervalDefault = "1h"
+
+// ConfigImportBatchSizeDefault is the default number of consents recorded in a single transaction by a bulk import
+const ConfigImportBatchSizeDefault = 500
+
+// ConfigBackupRetainDefault is the default number of online backups that are kept
+const ConfigBackupRetainDefault = 7
+
+// ConfigCheckMaxAgeDefault is the default maximum period clients may cache the outcome of a consent check
+const ConfigCheckMaxAgeDefault = "5m"
+
+// ConfigIdempotencyKeyRetentionDefault is the default period responses are kept for retries with the same Idempotency-Key
+const ConfigIdempotencyKeyRetentionDefault = "24h"
+
+// ConfigClientTimeoutDefault is the default timeout of a single call to the server in client mode
+const ConfigClientTimeoutDefault = "1s"
+
+// ConfigClientMaxAttemptsDefault is the default number of attempts of an idempotent call in client mode
+const ConfigClientMaxAttemptsDefault = 3
+
+// ConfigClientRetryBackoffDefault is the default base wait time between attempts in client mode
+const ConfigClientRetryBackoffDefault = "100ms"
+
+// ConfigClientBreakerThresholdDefault is the default number of consecutive failed calls after which the circuit breaker opens
+const ConfigClientBreakerThresholdDefault = 5
+
+// ConfigClientBreakerCooldownDefault is the default period the circuit breaker stays open
+const ConfigClientBreakerCooldownDefault = "30s"
+
 // ConsentStore is the main data struct holding the config and references to the DB
 type ConsentStore struct {
 	Db    *gorm.DB
 	sqlDb *sql.DB
 
+	// RateLimiter limits the number of requests per caller on the REST api
+	RateLimiter *RateLimiter
+	// Events distributes consent mutations to subscribers
+	Events *EventBus
+
+	retentionPolicies []RetentionPolicy
+	retentionMode     RetentionMode
+	retentionInterval time.Duration
+	retentionJob      *job
+
+	webhooks   []Webhook
+	webhookJob *job
+
+	expiryHorizon  time.Duration
+	expiryInterval time.Duration
+	expiryJob      *job
+
+	backupMutex sync.Mutex
+
+	// Metrics holds the prometheus metrics of the store operations
+	Metrics *Metrics
+
+	checkMaxAge time.Duration
+
+	idempotencyKeyRetention time.Duration
+
+	tracerProvider *sdktrace.TracerProvider
+
+	// Breaker is the circuit breaker of the HttpClient in client mode, its state is part of the diagnostics
+	Breaker *CircuitBreaker
+
 	ConfigOnce sync.Once
 	Config     ConsentStoreConfig
 }
@@ -77,16 +297,20 @@ var oneEngine sync.Once
 // ConsentStoreClient defines all actions possible through a direct connection, command-line and REST api
 type ConsentStoreClient interface {
 	// ConsentAuth checks if a record exists in the Db for the given combination and returns a bool. Checkpoint is optional and default to time.Now()
-	ConsentAuth(context context.Context, custodian string, subject string, actor string, dataClass string, checkpoint *time.Time) (bool, error)
+	ConsentAuth(ctx context.Context, custodian string, subject string, actor string, dataClass string, checkpoint *time.Time) (bool, error)
 	// RecordConsent records a record in the Db, this is not to be used to create a new distributed consent record. It's only valid for the local node.
 	// It should only be called by the consent logic component (or for development purposes)
-	RecordConsent(context context.Context, consent []PatientConsent) error
-	// QueryConsent can be used to query consent from a custodian/actor point of view.
-	QueryConsent(context context.Context, actor *string, custodian *string, subject *string, validAt *time.Time) ([]PatientConsent, error)
-	// DeleteConsentRecordByHash removes a ConsentRecord from the db. Returns true if the record was found and deleted.
-	DeleteConsentRecordByHash(context context.Context, consentRecordHash string) (bool, error)
+	// Appending to a chain of which the PreviousHash isn't the head returns a ConflictError holding the current head.
+	RecordConsent(ctx context.Context, consent []PatientConsent) error
+	// QueryConsent can be used to query consent from a custodian/actor point of view. Deleted records are only returned when includeDeleted is true, which is meant for audit purposes.
+	QueryConsent(ctx context.Context, actor *string, custodian *string, subject *string, validAt *time.Time, includeDeleted bool) ([]PatientConsent, error)
+	// DeleteConsentRecordByHash replaces a ConsentRecord with a tombstone holding the reason and who deleted it. Returns true if the record was found and deleted.
+	DeleteConsentRecordByHash(ctx context.Context, consentRecordHash string, reason string, deletedBy string) (bool, error)
 	// FindConsentRecordByHash find a consent record given its hash, the latest flag indicates the requirement if the record is the latest in the chain.
-	FindConsentRecordByHash(context context.Context, consentRecordHash string, latest bool) (ConsentRecord, error)
+	FindConsentRecordByHash(ctx context.Context, consentRecordHash string, latest bool) (ConsentRecord, error)
+	// EraseSubject removes all consent data of the subject and pseudonymises its audit trail (GDPR right to erasure).
+	// The returned ErasureCertificate lists the hashes of the removed records.
+	EraseSubject(ctx context.Context, subject string, erasedBy string) (ErasureCertificate, error)
 }
 
 // ConsentStoreInstance returns a singleton consent store
@@ -94,7 +318,26 @@ func ConsentStoreInstance() *ConsentStore {
 	oneEngine.Do(func() {
 		instance = &ConsentStore{
 			Config: ConsentStoreConfig{
-				Connectionstring: ConfigConnectionStringDefault,
+				Connectionstring:   ConfigConnectionStringDefault,
+				ListenAddress:      ConfigListenAddressDefault,
+				TombstoneRetention: ConfigTombstoneRetentionDefault,
+				RetentionMode:      string(RetentionModePurge),
+				RetentionInterval:  ConfigRetentionIntervalDefault,
+				EventBufferSize:    ConfigEventBufferSizeDefault,
+				WebhookMaxAttempts: ConfigWebhookMaxAttemptsDefault,
+				ExpiryHorizon:      ConfigExpiryHorizonDefault,
+				ExpiryInterval:     ConfigExpiryIntervalDefault,
+				ImportBatchSize:    ConfigImportBatchSizeDefault,
+				BackupRetain:       ConfigBackupRetainDefault,
+				CheckMaxAge:        ConfigCheckMaxAgeDefault,
+
+				IdempotencyKeyRetention: ConfigIdempotencyKeyRetentionDefault,
+
+				ClientTimeout:          ConfigClientTimeoutDefault,
+				ClientMaxAttempts:      ConfigClientMaxAttemptsDefault,
+				ClientRetryBackoff:     ConfigClientRetryBackoffDefault,
+				ClientBreakerThreshold: ConfigClientBreakerThresholdDefault,
+				ClientBreakerCooldown:  ConfigClientBreakerCooldownDefault,
 			},
 		}
 	})
@@ -116,6 +359,62 @@ func (cs *ConsentStore) Configure() error {
 	cs.ConfigOnce.Do(func() {
 		cfg := core.NutsConfig()
 		cs.Config.Mode = cfg.GetEngineMode(cs.Config.Mode)
+
+		cs.RateLimiter, err = cs.Config.rateLimiter()
+		if err != nil {
+			return
+		}
+
+		bufferSize := cs.Config.EventBufferSize
+		if bufferSize <= 0 {
+			bufferSize = ConfigEventBufferSizeDefault
+		}
+		cs.Events = NewEventBus(bufferSize)
+		cs.Metrics = NewMetrics(cs)
+
+		if _, err = cs.Config.TombstoneRetentionPeriod(); err != nil {
+			return
+		}
+
+		if cs.retentionPolicies, err = cs.Config.retentionPolicies(); err != nil {
+			return
+		}
+
+		if cs.retentionMode, err = cs.Config.retentionMode(); err != nil {
+			return
+		}
+
+		if cs.retentionInterval, err = cs.Config.retentionInterval(); err != nil {
+			return
+		}
+
+		if cs.webhooks, err = cs.Config.webhooks(); err != nil {
+			return
+		}
+
+		if cs.expiryHorizon, err = cs.Config.ExpiryHorizonPeriod(); err != nil {
+			return
+		}
+
+		if cs.expiryInterval, err = cs.Config.expiryInterval(); err != nil {
+			return
+		}
+
+		if cs.checkMaxAge, err = cs.Config.CheckMaxAgePeriod(); err != nil {
+			return
+		}
+
+		if cs.idempotencyKeyRetention, err = cs.Config.IdempotencyKeyRetentionPeriod(); err != nil {
+			return
+		}
+
+		if cs.tracerProvider, err = cs.Config.tracerProvider(); err != nil {
+			return
+		}
+		if cs.tracerProvider != nil {
+			otel.SetTracerProvider(cs.tracerProvider)
+		}
+
 		if cs.Config.Mode == core.ServerEngineMode {
 			cs.sqlDb, err = sql.Open("sqlite3", cs.Config.Connectionstring)
 			if err != nil {
@@ -139,8 +438,13 @@ func (cs *ConsentStore) Configure() error {
 	return err
 }
 
-//Shutdown closes the db connections
+// Shutdown stops the background jobs and closes the db connections
 func (cs *ConsentStore) Shutdown() error {
+	cs.stopRetentionJob()
+	cs.stopWebhookJob()
+	cs.stopExpiryJob()
+	cs.shutdownTracing()
+
 	if cs.Db != nil {
 		return cs.Db.Close()
 	}
@@ -154,9 +458,26 @@ func (cs *ConsentStore) Start() error {
 	if cs.Config.Mode == core.ServerEngineMode {
 		// gorm db connection
 		cs.Db, err = gorm.Open("sqlite3", cs.sqlDb)
+		if err != nil {
+			return err
+		}
 
 		// logging
 		cs.Db.SetLogger(logrus.StandardLogger())
+
+		// tracing of the statements executed on behalf of a request
+		registerTracingCallbacks(cs.Db)
+
+		// background jobs
+		if len(cs.retentionPolicies) > 0 && cs.retentionInterval > 0 {
+			cs.startRetentionJob(cs.retentionInterval)
+		}
+		if len(cs.webhooks) > 0 {
+			cs.startWebhookJob(webhookPollInterval)
+		}
+		if cs.expiryInterval > 0 {
+			cs.startExpiryJob(cs.expiryInterval)
+		}
 	}
 
 	return err
@@ -195,7 +516,15 @@ func (cs *ConsentStore) RunMigrations(db *sql.DB) error {
 }
 
 // ConsentAuth checks if there is a consent for a given custodian, subject and actor for a certain resource at a given moment in time (checkpoint)
-func (cs *ConsentStore) ConsentAuth(context context.Context, custodian string, subject string, actor string, resourceType string, checkpoint *time.Time) (bool, error) {
+func (cs *ConsentStore) ConsentAuth(ctx context.Context, custodian string, subject string, actor string, resourceType string, checkpoint *time.Time) (granted bool, err error) {
+	ctx, done := cs.startOperation(ctx, operationConsentAuth)
+	defer done(&err)
+	defer func() {
+		if err == nil {
+			cs.Metrics.observeCheck(custodian, granted)
+		}
+	}()
+
 	target := &PatientConsent{}
 
 	cp := time.Now()
@@ -205,20 +534,25 @@ func (cs *ConsentStore) ConsentAuth(context context.Context, custodian string, s
 	}
 
 	// limit to active records
-	expr := cs.Db.Debug().Where("custodian = ? AND subject = ? AND actor = ?", custodian, subject, actor).
+	expr := cs.db(ctx).Debug().Where("custodian = ? AND subject = ? AND actor = ?", custodian, subject, actor).
 		Table("patient_consent").
 		Select("consent_record.id").
-		Joins("left join consent_record on consent_record.patient_consent_id = patient_consent.id").
+		Joins("left join consent_record on consent_record.patient_consent_id = patient_consent.id AND consent_record.deleted_at IS NULL").
 		Group("consent_record.uuid").Having("max(consent_record.version)").QueryExpr()
 
+	// only the data classes of the latest records that are valid at the checkpoint count, not those of previous versions
+	active := func(db *gorm.DB) *gorm.DB {
+		return db.Where("consent_record.id IN (?)", expr).
+			Where("julianday(consent_record.valid_from) <= julianday(?)", cp).
+			Where("consent_record.valid_to IS NULL OR julianday(consent_record.valid_to) > julianday(?)", cp)
+	}
+
 	// this will always fill target, but if a record does not exist, resources will be empty
-	var tdb = cs.Db.Debug().
+	var tdb = active(cs.db(ctx).Debug().
 		Table("patient_consent").
 		Joins("JOIN consent_record ON consent_record.patient_consent_id = patient_consent.id").
-		Preload("Records.DataClasses").
-		Where("consent_record.id IN (?)", expr).
-		Where("julianday(consent_record.valid_from) <= julianday(?)", cp).
-		Where("consent_record.valid_to IS NULL OR julianday(consent_record.valid_to) > julianday(?)", cp)
+		Preload("Records", active).
+		Preload("Records.DataClasses"))
 
 	if err := tdb.FirstOrInit(&target).Error; err != nil {
 		return false, err
@@ -238,10 +572,12 @@ var ErrorInvalidValidTo = errors.New("ConsentRecord validation failed: ValidTo m
 
 // RecordConsent records a list of PatientConsents, their records and their data classes.
 // For consent records that are updates, this function finds the version number and UUID from the previous record
-func (cs *ConsentStore) RecordConsent(context context.Context, consent []PatientConsent) error {
+func (cs *ConsentStore) RecordConsent(ctx context.Context, consent []PatientConsent) (err error) {
+	ctx, done := cs.startOperation(ctx, operationRecordConsent)
+	defer done(&err)
 
 	// start transaction
-	tx := cs.Db.Begin().Debug()
+	tx := cs.db(ctx).Begin().Debug()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -253,87 +589,151 @@ func (cs *ConsentStore) RecordConsent(context context.Context, consent []Patient
 		return err
 	}
 
-	for _, pr := range consent {
-		if pr.ID == "" {
-			return fmt.Errorf("id of patient consent cannot be empty")
-		}
-		tpc := PatientConsent{
-			ID:        pr.ID,
-			Actor:     pr.Actor,
-			Custodian: pr.Custodian,
-			Subject:   pr.Subject,
-		}
+	// events are written to the webhook outbox within the transaction and published after it has been committed
+	var events []Event
+	now := time.Now()
 
-		// first check if a consent record exists for subject, custodian and actor, if not create
-		if err := tx.Where(tpc).Preload("Records").FirstOrCreate(&tpc).Error; err != nil {
+	for _, pr := range consent {
+		e, err := cs.recordPatientConsent(tx, pr, now)
+		if err != nil {
 			tx.Rollback()
 			return err
 		}
+		events = append(events, e...)
+	}
 
-		for _, cr := range pr.Records {
-			tcr := ConsentRecord{
-				PatientConsentID: tpc.ID,
-				Hash:             cr.Hash,
-				ValidFrom:        cr.ValidFrom,
-				ValidTo:          cr.ValidTo,
-				UUID:             uuid.NewV4().String(),
-				Version:          1,
-			}
+	if err := cs.writeOutbox(tx, events); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-			// check if record already exists based on hash
-			var ecr ConsentRecord
-			tx.Where("hash = ?", cr.Hash).First(&ecr)
+	if err := cs.saveIdempotentResponse(ctx, tx, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-			// ignore existing record
-			if ecr.Hash == cr.Hash {
-				continue
-			}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
 
-			// if this is an update to an existing entry, find UUID and version
-			if cr.PreviousHash != nil {
-				var pcr ConsentRecord
-				if err := tx.Where("hash = ?", *cr.PreviousHash).First(&pcr).Error; err != nil {
-					tx.Rollback()
-					if gorm.IsRecordNotFoundError(err) {
-						return ErrorNotFound
-					}
-					return fmt.Errorf("error when finding existing consent record for hash %s: %w", *cr.PreviousHash, err)
+	cs.publish(events)
+	return nil
+}
+
+// recordPatientConsent records a single PatientConsent within the given transaction and returns the events to publish.
+// The caller is responsible for rolling back the transaction when an error is returned.
+func (cs *ConsentStore) recordPatientConsent(tx *gorm.DB, pr PatientConsent, now time.Time) ([]Event, error) {
+	var events []Event
+
+	if pr.ID == "" {
+		return nil, fmt.Errorf("id of patient consent cannot be empty")
+	}
+	tpc := PatientConsent{
+		ID:        pr.ID,
+		Actor:     pr.Actor,
+		Custodian: pr.Custodian,
+		Subject:   pr.Subject,
+	}
+
+	// first check if a consent record exists for subject, custodian and actor, if not create
+	if err := tx.Where(tpc).Preload("Records").FirstOrCreate(&tpc).Error; err != nil {
+		return nil, err
+	}
+
+	for _, cr := range pr.Records {
+		tcr := ConsentRecord{
+			PatientConsentID: tpc.ID,
+			Hash:             cr.Hash,
+			ValidFrom:        cr.ValidFrom,
+			ValidTo:          cr.ValidTo,
+			UUID:             uuid.NewV4().String(),
+			Version:          1,
+		}
+
+		// check if record already exists based on hash, deleted records are not recorded again
+		var ecr ConsentRecord
+		tx.Unscoped().Where("hash = ?", cr.Hash).First(&ecr)
+
+		// ignore existing record
+		if ecr.Hash == cr.Hash {
+			continue
+		}
+
+		// if this is an update to an existing entry, find UUID and version
+		if cr.PreviousHash != nil {
+			var pcr ConsentRecord
+			if err := tx.Where("hash = ?", *cr.PreviousHash).First(&pcr).Error; err != nil {
+				if gorm.IsRecordNotFoundError(err) {
+					return nil, ErrorUnknownPreviousHash
 				}
-				tcr.PreviousHash = cr.PreviousHash
-				tcr.Version = pcr.Version + 1
-				tcr.UUID = pcr.UUID
+				return nil, fmt.Errorf("error when finding existing consent record for hash %s: %w", *cr.PreviousHash, err)
 			}
+			tcr.PreviousHash = cr.PreviousHash
+			tcr.Version = pcr.Version + 1
+			tcr.UUID = pcr.UUID
 
-			if tcr.ValidTo != nil && !tcr.ValidTo.After(tcr.ValidFrom) {
-				tx.Rollback()
-				return ErrorInvalidValidTo
+			// the previous record is the head the caller expects, appending to an older version would fork the chain
+			head, err := chainHead(tx, pcr.UUID)
+			if err != nil {
+				return nil, err
+			}
+			if head.Hash != pcr.Hash || (cr.Version != 0 && cr.Version != tcr.Version) {
+				return nil, &ConflictError{Head: head}
 			}
+		}
 
-			// Save all current resources
-			tcr.DataClasses = cr.DataClasses
-			if err := tx.Save(&tcr).Error; err != nil {
-				tx.Rollback()
-				return err
+		if tcr.ValidTo != nil && !tcr.ValidTo.After(tcr.ValidFrom) {
+			return nil, ErrorInvalidValidTo
+		}
+
+		// the expiry scheduler doesn't have to notify about records that are stored when they already ended
+		if tcr.ValidTo != nil && !tcr.ValidTo.After(now) {
+			tcr.ExpiredNotifiedAt = &now
+		}
+
+		// Save all current resources
+		tcr.DataClasses = cr.DataClasses
+		if err := tx.Save(&tcr).Error; err != nil {
+			// a concurrent writer may have appended the same version in the meantime
+			if tcr.PreviousHash != nil {
+				if head, hErr := chainHead(tx, tcr.UUID); hErr == nil && head.Version >= tcr.Version {
+					return nil, &ConflictError{Head: head}
+				}
 			}
+			return nil, err
+		}
+
+		if tcr.PreviousHash == nil {
+			events = append(events, newEvent(EventConsentRecorded, tpc, tcr))
+		} else {
+			events = append(events, newEvent(EventConsentVersionAppended, tpc, tcr))
+		}
+		if tcr.ValidTo != nil && !tcr.ValidTo.After(now) {
+			events = append(events, newEvent(EventConsentExpired, tpc, tcr))
 		}
 	}
 
-	return tx.Commit().Error
+	return events, nil
 }
 
-func (cs *ConsentStore) patientConsentByConsentRecord(context context.Context, records []uint) ([]PatientConsent, error) {
+func (cs *ConsentStore) patientConsentByConsentRecord(ctx context.Context, records []uint, includeDeleted bool) ([]PatientConsent, error) {
 	var consentMap = make(map[string]*PatientConsent)
 
+	db := cs.db(ctx)
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+
 	for _, ri := range records {
 		var cr ConsentRecord
-		if err := cs.Db.Debug().Where("id = ?", ri).Preload("DataClasses").Find(&cr).Error; err != nil {
+		if err := db.Debug().Where("id = ?", ri).Preload("DataClasses").Find(&cr).Error; err != nil {
 			return nil, err
 		}
 
 		cpc := consentMap[cr.PatientConsentID]
 		if cpc == nil {
 			var pc PatientConsent
-			if err := cs.Db.Debug().Where("id = ?", cr.PatientConsentID).Find(&pc).Error; err != nil {
+			if err := cs.db(ctx).Debug().Where("id = ?", cr.PatientConsentID).Find(&pc).Error; err != nil {
 				return nil, err
 			}
 			cpc = &pc
@@ -352,7 +752,16 @@ func (cs *ConsentStore) patientConsentByConsentRecord(context context.Context, r
 }
 
 // QueryConsent accepts actor, custodian and subject, if these are nil, it's not used in the query.
-func (cs *ConsentStore) QueryConsent(context context.Context, _actor *string, _custodian *string, _subject *string, _validAt *time.Time) ([]PatientConsent, error) {
+// Deleted records are ignored unless includeDeleted is true.
+func (cs *ConsentStore) QueryConsent(ctx context.Context, _actor *string, _custodian *string, _subject *string, _validAt *time.Time, includeDeleted bool) (consent []PatientConsent, err error) {
+	ctx, done := cs.startOperation(ctx, operationQueryConsent)
+	defer done(&err)
+	defer func() {
+		if err == nil {
+			cs.Metrics.observeQuery(len(consent))
+		}
+	}()
+
 	var pc PatientConsent
 
 	validAt := time.Now()
@@ -374,13 +783,18 @@ func (cs *ConsentStore) QueryConsent(context context.Context, _actor *string, _c
 
 	var records []uint
 
-	expr := cs.Db.Debug().Where(pc).
+	join := "left join consent_record on consent_record.patient_consent_id = patient_consent.id"
+	if !includeDeleted {
+		join += " AND consent_record.deleted_at IS NULL"
+	}
+
+	expr := cs.db(ctx).Debug().Where(pc).
 		Table("patient_consent").
 		Select("consent_record.id").
-		Joins("left join consent_record on consent_record.patient_consent_id = patient_consent.id").
+		Joins(join).
 		Group("consent_record.uuid").Having("max(consent_record.version)").QueryExpr()
 
-	rows, err := cs.Db.Debug().
+	rows, err := cs.db(ctx).Debug().
 		Table("consent_record").
 		Select("id").
 		Where("id IN (?)", expr).
@@ -411,38 +825,130 @@ func (cs *ConsentStore) QueryConsent(context context.Context, _actor *string, _c
 	// new queries can only be done after rows has been closed....
 	rows.Close()
 
-	return cs.patientConsentByConsentRecord(context, records)
+	return cs.patientConsentByConsentRecord(ctx, records, includeDeleted)
 }
 
-// DeleteConsentRecordByHash deletes a consent record by its hash. Returns boolean to indicate the success of the operation
-func (cs *ConsentStore) DeleteConsentRecordByHash(context context.Context, consentRecordHash string) (bool, error) {
+// DeleteConsentRecordByHash replaces a consent record by a tombstone. The record and its data classes are kept for audit purposes
+// until they are purged with PurgeTombstones. Returns boolean to indicate the success of the operation
+func (cs *ConsentStore) DeleteConsentRecordByHash(ctx context.Context, consentRecordHash string, reason string, deletedBy string) (deleted bool, err error) {
+	ctx, done := cs.startOperation(ctx, operationDeleteConsentRecordByHash)
+	defer done(&err)
+
 	record := ConsentRecord{}
 
-	if err := cs.Db.Debug().Where("hash = ?", consentRecordHash).First(&record).Error; err != nil {
+	if err := cs.db(ctx).Debug().Where("hash = ?", consentRecordHash).First(&record).Error; err != nil {
 		if gorm.IsRecordNotFoundError(err) {
 			return false, ErrorNotFound
 		}
 		return false, err
 	}
 
-	if err := cs.Db.Debug().Delete(&record).Error; err != nil {
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return false, err
+	}
+
+	tombstone := map[string]interface{}{
+		"deleted_at":     time.Now(),
+		"deleted_reason": reason,
+		"deleted_by":     deletedBy,
+	}
+	if err := tx.Model(&record).Updates(tombstone).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	var pc PatientConsent
+	if err := tx.Where("id = ?", record.PatientConsentID).First(&pc).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Where("consent_record_id = ?", record.ID).Find(&record.DataClasses).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	events := []Event{newEvent(EventConsentDeleted, pc, record)}
+	if err := cs.writeOutbox(tx, events); err != nil {
+		tx.Rollback()
 		return false, err
 	}
 
+	if err := cs.saveIdempotentResponse(ctx, tx, nil); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+
+	cs.publish(events)
 	return true, nil
 }
 
-// FindConsentRecordByHash find a consent record given its hash, the latest flag indicates the requirement if the record is the latest in the chain.
-func (cs *ConsentStore) FindConsentRecordByHash(context context.Context, consentRecordHash string, latest bool) (ConsentRecord, error) {
+// TombstoneRetentionPeriod returns the parsed tombstone retention period, the default is used when it's not configured
+func (c ConsentStoreConfig) TombstoneRetentionPeriod() (time.Duration, error) {
+	retention := c.TombstoneRetention
+	if retention == "" {
+		retention = ConfigTombstoneRetentionDefault
+	}
+
+	d, err := ParseDuration(retention)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ConfigTombstoneRetention, err)
+	}
+	return d, nil
+}
+
+// PurgeTombstones physically removes all consent records, including their data classes, that were deleted before the given time.
+// It returns the hashes of the removed records.
+func (cs *ConsentStore) PurgeTombstones(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	var records []ConsentRecord
+
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Unscoped().Where("deleted_at IS NOT NULL AND julianday(deleted_at) < julianday(?)", deletedBefore).Find(&records).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	var (
-		record ConsentRecord
-		err    error
+		ids    []uint
+		hashes []string
 	)
+	for _, r := range records {
+		ids = append(ids, r.ID)
+		hashes = append(hashes, r.Hash)
+	}
+
+	if len(ids) > 0 {
+		if err := tx.Delete(DataClass{}, "consent_record_id IN (?)", ids).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		if err := tx.Unscoped().Delete(ConsentRecord{}, "id IN (?)", ids).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	return hashes, tx.Commit().Error
+}
+
+// FindConsentRecordByHash find a consent record given its hash, the latest flag indicates the requirement if the record is the latest in the chain.
+func (cs *ConsentStore) FindConsentRecordByHash(ctx context.Context, consentRecordHash string, latest bool) (record ConsentRecord, err error) {
+	ctx, done := cs.startOperation(ctx, operationFindConsentRecordByHash)
+	defer done(&err)
 
 	if latest {
-		err = cs.findConsentRecordByHashGrouped(context, consentRecordHash, &record)
+		err = cs.findConsentRecordByHashGrouped(ctx, consentRecordHash, &record)
 	} else {
-		err = cs.findConsentRecordByHashExact(context, consentRecordHash, &record)
+		err = cs.findConsentRecordByHashExact(ctx, consentRecordHash, &record)
 	}
 
 	if err != nil {
@@ -455,22 +961,48 @@ func (cs *ConsentStore) FindConsentRecordByHash(context context.Context, consent
 	return record, nil
 }
 
+// ErrorConflict is returned when a record is appended to a chain of which the previous record isn't the head (anymore)
+var ErrorConflict = errors.New("consent record chain has been changed")
+
+// ConflictError holds the current head of the chain when an append conflicts, it unwraps to ErrorConflict
+type ConflictError struct {
+	Head ConsentRecord
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v, current head is %s (version %d)", ErrorConflict, e.Head.Hash, e.Head.Version)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrorConflict
+}
+
+// chainHead returns the latest version of the chain with the given UUID, also when it has been deleted
+func chainHead(db *gorm.DB, uuid string) (ConsentRecord, error) {
+	var head ConsentRecord
+	err := db.Unscoped().Preload("DataClasses").Where("uuid = ?", uuid).Order("version desc").First(&head).Error
+	return head, err
+}
+
 // ErrorConsentRecordNotLatest is returned when the latest consent record for a chain is requested but given hash is not the latest
 var ErrorConsentRecordNotLatest = errors.New("consent record for given hash is not the latest in the chain")
 
 // ErrorNotFound is the same as Gorm.IsRecordNotFound
 var ErrorNotFound = errors.New("record not found")
 
-func (cs *ConsentStore) findConsentRecordByHashGrouped(context context.Context, consentRecordHash string, record *ConsentRecord) error {
+// ErrorUnknownPreviousHash is returned when a record is appended to a previous record that doesn't exist, it unwraps to ErrorNotFound
+var ErrorUnknownPreviousHash = fmt.Errorf("previous consent record not found: %w", ErrorNotFound)
+
+func (cs *ConsentStore) findConsentRecordByHashGrouped(ctx context.Context, consentRecordHash string, record *ConsentRecord) error {
 	var id uint
 
 	// sub query broken
 	var cr ConsentRecord
-	if err := cs.Db.Debug().Where("hash = ?", consentRecordHash).First(&cr).Error; err != nil {
+	if err := cs.db(ctx).Debug().Where("hash = ?", consentRecordHash).First(&cr).Error; err != nil {
 		return err
 	}
 
-	rows, err := cs.Db.Debug().Where("uuid = ?", cr.UUID).
+	rows, err := cs.db(ctx).Debug().Where("uuid = ? AND deleted_at IS NULL", cr.UUID).
 		Table("consent_record").
 		Select("id, hash").
 		Group("uuid").Having("max(version)").
@@ -502,9 +1034,9 @@ func (cs *ConsentStore) findConsentRecordByHashGrouped(context context.Context,
 	// new queries can only be done after rows has been closed....
 	rows.Close()
 
-	return cs.Db.Debug().Where("id = ?", id).First(record).Error
+	return cs.db(ctx).Debug().Where("id = ?", id).First(record).Error
 }
 
-func (cs *ConsentStore) findConsentRecordByHashExact(context context.Context, consentRecordHash string, record *ConsentRecord) error {
-	return cs.Db.Debug().Where("hash = ?", consentRecordHash).First(record).Error
+func (cs *ConsentStore) findConsentRecordByHashExact(ctx context.Context, consentRecordHash string, record *ConsentRecord) error {
+	return cs.db(ctx).Debug().Where("hash = ?", consentRecordHash).First(record).Error
 }
@@ -75,7 +75,7 @@ func TestConsentStore_RecordConsent(t *testing.T) {
 		if assert.NoError(t, err) {
 			a := "actor"
 			s := "subject"
-			pcs, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+			pcs, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 			if assert.NoError(t, err) {
 				assert.Equal(t, uint(1), pcs[0].Records[0].Version)
@@ -218,7 +218,7 @@ func TestConsentStore_RecordConsent_AuthConsent(t *testing.T) {
 		err = client.RecordConsent(context.TODO(), rules)
 		if assert.NoError(t, err) {
 			t.Run("and query within new period", func(t *testing.T) {
-				consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil)
+				consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil, false)
 				if assert.NoError(t, err) {
 					assert.Len(t, consent, 1)
 					assert.Len(t, consent[0].Records, 1)
@@ -230,7 +230,7 @@ func TestConsentStore_RecordConsent_AuthConsent(t *testing.T) {
 			// BUG#24
 			t.Run("and query outside new period, inside old period", func(t *testing.T) {
 				tt := time.Now().Add(2 * time.Hour)
-				consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, &tt)
+				consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, &tt, false)
 				if assert.NoError(t, err) {
 					assert.Len(t, consent, 0)
 				}
@@ -250,6 +250,21 @@ func TestConsentStore_RecordConsent_AuthConsent(t *testing.T) {
 					assert.False(t, cg)
 				}
 			})
+
+			t.Run("and check data class removed by new version", func(t *testing.T) {
+				hcp := rules[0].Records[0].Hash
+				rules[0].Records[0].PreviousHash = &hcp
+				rules[0].Records[0].Hash = "234caefh_3"
+				rules[0].Records[0].DataClasses = []DataClass{{Code: "other"}}
+				if !assert.NoError(t, client.RecordConsent(context.TODO(), rules)) {
+					return
+				}
+
+				cg, err := client.ConsentAuth(context.TODO(), c, s, a, "resource", nil)
+				if assert.NoError(t, err) {
+					assert.False(t, cg)
+				}
+			})
 		}
 	})
 
@@ -295,9 +310,52 @@ func TestConsentStore_RecordConsent_AuthConsent(t *testing.T) {
 			rules[0].Records[0].Hash = fmt.Sprintf("%s_3", r)
 			err = client.RecordConsent(context.TODO(), rules)
 			assert.Error(t, err) // unique constraint violation
+
+			var conflict *ConflictError
+			if assert.True(t, errors.As(err, &conflict)) {
+				assert.True(t, errors.Is(err, ErrorConflict))
+				assert.Equal(t, fmt.Sprintf("%s_2", r), conflict.Head.Hash)
+				assert.Equal(t, uint(2), conflict.Head.Version)
+				assert.Len(t, conflict.Head.DataClasses, 1)
+			}
 		}
 	})
 
+	t.Run("Updating consent with expected version", func(t *testing.T) {
+		r := random.String(8)
+		rules := []PatientConsent{
+			{
+				ID:        random.String(8),
+				Actor:     "actor3334",
+				Custodian: "custodian",
+				Subject:   "subject",
+				Records: []ConsentRecord{
+					{
+						ValidFrom:   time.Now().Add(time.Hour * -24),
+						Hash:        r,
+						DataClasses: []DataClass{{Code: "resource"}},
+					},
+				},
+			},
+		}
+		if !assert.NoError(t, client.RecordConsent(context.TODO(), rules)) {
+			return
+		}
+
+		rules[0].Records[0].PreviousHash = &r
+		rules[0].Records[0].Hash = fmt.Sprintf("%s_2", r)
+		rules[0].Records[0].Version = 3
+
+		err := client.RecordConsent(context.TODO(), rules)
+		var conflict *ConflictError
+		if assert.True(t, errors.As(err, &conflict)) {
+			assert.Equal(t, r, conflict.Head.Hash)
+		}
+
+		rules[0].Records[0].Version = 2
+		assert.NoError(t, client.RecordConsent(context.TODO(), rules))
+	})
+
 	t.Run("Updating unknown consent", func(t *testing.T) {
 		r := random.String(8)
 
@@ -478,7 +536,7 @@ func TestConsentStore_QueryConsentForActor(t *testing.T) {
 
 	t.Run("Recorded consent can be found", func(t *testing.T) {
 		a := "actor"
-		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil)
+		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil, false)
 
 		if assert.NoError(t, err) {
 			assert.Len(t, consent, 1)
@@ -491,7 +549,7 @@ func TestConsentStore_QueryConsentForActor(t *testing.T) {
 		beijing := time.FixedZone("Beijing Time", secondsEastOfUTC)
 		va := time.Now().Add(time.Hour * 11).In(beijing)
 		a := "actor"
-		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, &va)
+		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, &va, false)
 
 		if assert.NoError(t, err) {
 			assert.Len(t, consent, 1)
@@ -501,7 +559,7 @@ func TestConsentStore_QueryConsentForActor(t *testing.T) {
 	t.Run("Recorded consent is not found outside time frame", func(t *testing.T) {
 		a := "actor"
 		tt := time.Now().Add(time.Hour * 13)
-		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, &tt)
+		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, &tt, false)
 
 		if assert.NoError(t, err) {
 			assert.Len(t, consent, 0)
@@ -510,7 +568,7 @@ func TestConsentStore_QueryConsentForActor(t *testing.T) {
 
 	t.Run("Non-recorded is not found", func(t *testing.T) {
 		a := "actor3"
-		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil)
+		consent, err := client.QueryConsent(context.TODO(), &a, nil, nil, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%v]", err)
@@ -572,7 +630,7 @@ func TestConsentStore_QueryConsentForActorAndSubject(t *testing.T) {
 	t.Run("Recorded consent can be found", func(t *testing.T) {
 		a := "actor"
 		s := "subject"
-		consent, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+		consent, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%v]", err)
@@ -586,7 +644,7 @@ func TestConsentStore_QueryConsentForActorAndSubject(t *testing.T) {
 	t.Run("Non-recorded is not found", func(t *testing.T) {
 		a := "actor"
 		s := "subject2"
-		consent, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil)
+		consent, err := client.QueryConsent(context.TODO(), &a, nil, &s, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%v]", err)
@@ -728,7 +786,7 @@ func TestConsentStore_QueryConsent(t *testing.T) {
 	t.Run("Recorded consent can be found by subject", func(t *testing.T) {
 		subject := "subject"
 
-		consent, err := client.QueryConsent(context.TODO(), nil, nil, &subject, nil)
+		consent, err := client.QueryConsent(context.TODO(), nil, nil, &subject, nil, false)
 
 		if assert.NoError(t, err) {
 			assert.Len(t, consent, 2)
@@ -741,7 +799,7 @@ func TestConsentStore_QueryConsent(t *testing.T) {
 	t.Run("Recorded consent can be found by custodian", func(t *testing.T) {
 		custodian := "custodian2"
 
-		consent, err := client.QueryConsent(context.TODO(), nil, &custodian, nil, nil)
+		consent, err := client.QueryConsent(context.TODO(), nil, &custodian, nil, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error, got [%v]", err)
@@ -789,7 +847,7 @@ func TestConsentStore_DeleteConsentRecordByHash(t *testing.T) {
 	}
 
 	t.Run("Not found returns false", func(t *testing.T) {
-		val, _ := client.DeleteConsentRecordByHash(context.TODO(), "unknown")
+		val, _ := client.DeleteConsentRecordByHash(context.TODO(), "unknown", "", "")
 
 		if val {
 			t.Error("Expected record to not be deleted")
@@ -797,13 +855,111 @@ func TestConsentStore_DeleteConsentRecordByHash(t *testing.T) {
 	})
 
 	t.Run("Record is deleted", func(t *testing.T) {
-		val, _ := client.DeleteConsentRecordByHash(context.TODO(), hash)
+		val, _ := client.DeleteConsentRecordByHash(context.TODO(), hash, "revoked", "gateway")
 
 		if !val {
 			t.Error("Expected record to be deleted")
 		}
 
-		client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+		auth, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+		if assert.NoError(t, err) {
+			assert.False(t, auth)
+		}
+	})
+
+	t.Run("Deleted record is ignored by reads", func(t *testing.T) {
+		actor := "actor"
+
+		consent, err := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		assert.NoError(t, err)
+		assert.Empty(t, consent)
+
+		_, err = client.FindConsentRecordByHash(context.TODO(), hash, false)
+		assert.True(t, errors.Is(err, ErrorNotFound))
+
+		_, err = client.DeleteConsentRecordByHash(context.TODO(), hash, "", "")
+		assert.True(t, errors.Is(err, ErrorNotFound))
+	})
+
+	t.Run("Deleted record is returned as tombstone when requested", func(t *testing.T) {
+		actor := "actor"
+
+		consent, err := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, true)
+		if assert.NoError(t, err) && assert.Len(t, consent, 1) && assert.Len(t, consent[0].Records, 1) {
+			record := consent[0].Records[0]
+			assert.True(t, record.IsDeleted())
+			assert.Equal(t, "revoked", *record.DeletedReason)
+			assert.Equal(t, "gateway", *record.DeletedBy)
+			assert.Len(t, record.DataClasses, 1)
+		}
+	})
+
+	t.Run("Deleted record is not recorded again", func(t *testing.T) {
+		if assert.NoError(t, client.RecordConsent(context.TODO(), rules)) {
+			auth, _ := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+			assert.False(t, auth)
+		}
+	})
+}
+
+func TestConsentStore_PurgeTombstones(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	pcs := patientConsent()
+	hash := pcs[0].Records[0].Hash
+	if err := client.RecordConsent(context.TODO(), pcs); err != nil {
+		t.Fatal(err)
+	}
+	other := patientConsent()
+	other[0].Subject = "subject2"
+	if err := client.RecordConsent(context.TODO(), other); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteConsentRecordByHash(context.TODO(), hash, "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("tombstones within retention are kept", func(t *testing.T) {
+		hashes, err := client.PurgeTombstones(context.TODO(), time.Now().Add(-time.Hour))
+
+		assert.NoError(t, err)
+		assert.Empty(t, hashes)
+	})
+
+	t.Run("tombstones are purged with their data classes", func(t *testing.T) {
+		hashes, err := client.PurgeTombstones(context.TODO(), time.Now().Add(time.Hour))
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{hash}, hashes)
+		}
+
+		var count int
+		client.Db.Unscoped().Model(&ConsentRecord{}).Where("hash = ?", hash).Count(&count)
+		assert.Equal(t, 0, count)
+		client.Db.Model(&DataClass{}).Count(&count)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("active records are not purged", func(t *testing.T) {
+		_, err := client.FindConsentRecordByHash(context.TODO(), other[0].Records[0].Hash, false)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestConsentStoreConfig_TombstoneRetentionPeriod(t *testing.T) {
+	t.Run("uses default when not configured", func(t *testing.T) {
+		d, err := ConsentStoreConfig{}.TombstoneRetentionPeriod()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 365*24*time.Hour, d)
+	})
+
+	t.Run("returns error for invalid period", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TombstoneRetention: "a year"}.TombstoneRetentionPeriod()
+
+		assert.Error(t, err)
 	})
 }
 
@@ -20,6 +20,7 @@ package pkg
 
 import (
 	"fmt"
+	"time"
 
 	core "github.com/nuts-foundation/nuts-go-core"
 )
@@ -42,13 +43,90 @@ func (ddr dbDiagnosticResult) String() string {
 	return fmt.Sprintf("ping: false, error: %v", ddr.pingError)
 }
 
+type rateLimiterDiagnosticResult struct {
+	state RateLimiterState
+}
+
+// Name returns the name of the rateLimiterDiagnosticResult
+func (rdr rateLimiterDiagnosticResult) Name() string {
+	return "Rate limiter"
+}
+
+// String returns the outcome of the rateLimiterDiagnosticResult
+func (rdr rateLimiterDiagnosticResult) String() string {
+	if !rdr.state.Enabled {
+		return "enabled: false"
+	}
+
+	return fmt.Sprintf("enabled: true, callers: %d, throttled check: %d, throttled query: %d, throttled write: %d",
+		rdr.state.Callers,
+		rdr.state.Throttled[OperationCheck],
+		rdr.state.Throttled[OperationQuery],
+		rdr.state.Throttled[OperationWrite])
+}
+
+type backupDiagnosticResult struct {
+	backups []Backup
+	err     error
+}
+
+// Name returns the name of the backupDiagnosticResult
+func (bdr backupDiagnosticResult) Name() string {
+	return "Backup"
+}
+
+// String returns the outcome of the backupDiagnosticResult
+func (bdr backupDiagnosticResult) String() string {
+	if bdr.err != nil {
+		return fmt.Sprintf("error: %v", bdr.err)
+	}
+	if len(bdr.backups) == 0 {
+		return "last: none"
+	}
+
+	last := bdr.backups[0]
+	return fmt.Sprintf("last: %s, size: %d bytes, backups: %d", last.CreatedAt.Format(time.RFC3339), last.Size, len(bdr.backups))
+}
+
+type breakerDiagnosticResult struct {
+	status BreakerStatus
+}
+
+// Name returns the name of the breakerDiagnosticResult
+func (bdr breakerDiagnosticResult) Name() string {
+	return "Circuit breaker"
+}
+
+// String returns the outcome of the breakerDiagnosticResult
+func (bdr breakerDiagnosticResult) String() string {
+	if bdr.status.State == BreakerClosed {
+		return fmt.Sprintf("state: %s, failures: %d", bdr.status.State, bdr.status.Failures)
+	}
+
+	return fmt.Sprintf("state: %s, failures: %d, opened: %s", bdr.status.State, bdr.status.Failures, bdr.status.OpenedAt.Format(time.RFC3339))
+}
+
 // Diagnostics returns the slice of DiagnosticResults indicating the state of this engine
 func (cs *ConsentStore) Diagnostics() []core.DiagnosticResult {
-	dbState := dbDiagnosticResult{
-		pingError: cs.sqlDb.Ping(),
+	var results []core.DiagnosticResult
+
+	// in client mode there's no db, but there's a circuit breaker
+	if cs.sqlDb != nil {
+		results = append(results, dbDiagnosticResult{pingError: cs.sqlDb.Ping()})
+	}
+
+	if cs.Breaker != nil {
+		results = append(results, breakerDiagnosticResult{status: cs.Breaker.Status()})
 	}
 
-	return []core.DiagnosticResult{
-		dbState,
+	if cs.RateLimiter != nil {
+		results = append(results, rateLimiterDiagnosticResult{state: cs.RateLimiter.State()})
 	}
+
+	if cs.Config.BackupDir != "" {
+		backups, err := cs.Backups()
+		results = append(results, backupDiagnosticResult{backups: backups, err: err})
+	}
+
+	return results
 }
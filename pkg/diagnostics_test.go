@@ -20,6 +20,7 @@ package pkg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -28,10 +29,10 @@ func TestEventOctopus_Diagnostics(t *testing.T) {
 	client := defaultConsentStore()
 	client.Configure()
 
-	t.Run("Diagnostics returns 1 report", func(t *testing.T) {
+	t.Run("Diagnostics returns 2 reports", func(t *testing.T) {
 		results := client.Diagnostics()
 
-		assert.Len(t, results, 1)
+		assert.Len(t, results, 2)
 	})
 
 	t.Run("Diagnostics returns DB info", func(t *testing.T) {
@@ -47,6 +48,19 @@ func TestEventOctopus_Diagnostics(t *testing.T) {
 		assert.True(t, found)
 	})
 
+	t.Run("Diagnostics returns rate limiter info", func(t *testing.T) {
+		found := false
+		results := client.Diagnostics()
+		for _, r := range results {
+			if r.Name() == "Rate limiter" {
+				found = true
+				assert.Equal(t, "enabled: false", r.String())
+			}
+		}
+
+		assert.True(t, found)
+	})
+
 	client.Shutdown()
 
 	t.Run("Diagnostics returns DB info when down", func(t *testing.T) {
@@ -62,3 +76,33 @@ func TestEventOctopus_Diagnostics(t *testing.T) {
 		assert.True(t, found)
 	})
 }
+
+func TestRateLimiterDiagnosticResult_String(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{Check: 1}, nil)
+	rl.Allow("caller", OperationCheck)
+	rl.Allow("caller", OperationCheck)
+
+	assert.Equal(t, "enabled: true, callers: 1, throttled check: 1, throttled query: 0, throttled write: 0", rateLimiterDiagnosticResult{state: rl.State()}.String())
+}
+
+func TestConsentStore_Diagnostics_Breaker(t *testing.T) {
+	t.Run("client mode returns breaker state without DB info", func(t *testing.T) {
+		cs := ConsentStore{Breaker: NewCircuitBreaker(1, time.Minute)}
+
+		results := cs.Diagnostics()
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "Circuit breaker", results[0].Name())
+			assert.Equal(t, "state: closed, failures: 0", results[0].String())
+		}
+	})
+
+	t.Run("open breaker", func(t *testing.T) {
+		b := NewCircuitBreaker(1, time.Minute)
+		openedAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+		b.now = func() time.Time { return openedAt }
+		b.Failure()
+
+		assert.Equal(t, "state: open, failures: 1, opened: 2020-01-01T12:00:00Z", breakerDiagnosticResult{status: b.Status()}.String())
+	})
+}
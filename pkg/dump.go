@@ -0,0 +1,251 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DumpFormatVersion is the version of the Dump format written by Dump, Restore only accepts this version
+const DumpFormatVersion = 1
+
+// AuditActionStoreRestore is the audit action for a restore of a Dump into an empty store
+const AuditActionStoreRestore = "store.restore"
+
+// ErrorStoreNotEmpty is returned when restoring a Dump into a store that already holds consent
+var ErrorStoreNotEmpty = errors.New("a dump can only be restored into an empty store")
+
+// ErrorInvalidDump is returned when a Dump has an unknown version or doesn't match its checksum
+var ErrorInvalidDump = errors.New("invalid dump")
+
+// Dump is a logical backup of the complete store, it holds every version of every chain, including deleted records.
+// Checksum is the SHA-256 of the JSON encoding of Consents, it's verified on restore.
+type Dump struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Checksum  string          `json:"checksum"`
+	Consents  []DumpedConsent `json:"consents"`
+}
+
+// DumpedConsent is the dump representation of a PatientConsent
+type DumpedConsent struct {
+	ID        string         `json:"id"`
+	Subject   string         `json:"subject"`
+	Actor     string         `json:"actor"`
+	Custodian string         `json:"custodian"`
+	Records   []DumpedRecord `json:"records"`
+}
+
+// DumpedRecord is the dump representation of a single version of a ConsentRecord, including the fields that remain internal
+type DumpedRecord struct {
+	UUID               string     `json:"uuid"`
+	Version            uint       `json:"version"`
+	Hash               string     `json:"hash"`
+	PreviousHash       *string    `json:"previousHash,omitempty"`
+	ValidFrom          time.Time  `json:"validFrom"`
+	ValidTo            *time.Time `json:"validTo,omitempty"`
+	DataClasses        []string   `json:"dataClasses"`
+	DeletedAt          *time.Time `json:"deletedAt,omitempty"`
+	DeletedReason      *string    `json:"deletedReason,omitempty"`
+	DeletedBy          *string    `json:"deletedBy,omitempty"`
+	ExpiringNotifiedAt *time.Time `json:"expiringNotifiedAt,omitempty"`
+	ExpiredNotifiedAt  *time.Time `json:"expiredNotifiedAt,omitempty"`
+	CreatedAt          *time.Time `json:"createdAt,omitempty"`
+}
+
+// checksum calculates the checksum over the consents of the dump
+func (d Dump) checksum() (string, error) {
+	data, err := json.Marshal(d.Consents)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Verify checks the version and the checksum of the dump
+func (d Dump) Verify() error {
+	if d.Version != DumpFormatVersion {
+		return fmt.Errorf("%w: unsupported version %d, expected %d", ErrorInvalidDump, d.Version, DumpFormatVersion)
+	}
+
+	checksum, err := d.checksum()
+	if err != nil {
+		return err
+	}
+	if checksum != d.Checksum {
+		return fmt.Errorf("%w: checksum mismatch", ErrorInvalidDump)
+	}
+
+	return nil
+}
+
+// Dump creates a logical backup of the complete store within a single transaction. Consents are ordered by ID, records by chain and version.
+func (cs *ConsentStore) Dump(ctx context.Context) (Dump, error) {
+	dump := Dump{
+		Version:   DumpFormatVersion,
+		CreatedAt: time.Now(),
+		Consents:  []DumpedConsent{},
+	}
+
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return dump, err
+	}
+	defer tx.Rollback()
+
+	var pcs []PatientConsent
+	if err := tx.Order("id").Find(&pcs).Error; err != nil {
+		return dump, err
+	}
+
+	for _, pc := range pcs {
+		var records []ConsentRecord
+		if err := tx.Unscoped().Preload("DataClasses").
+			Where("patient_consent_id = ?", pc.ID).
+			Order("uuid").Order("version").Find(&records).Error; err != nil {
+			return dump, err
+		}
+
+		dc := DumpedConsent{
+			ID:        pc.ID,
+			Subject:   pc.Subject,
+			Actor:     pc.Actor,
+			Custodian: pc.Custodian,
+			Records:   []DumpedRecord{},
+		}
+		for _, r := range records {
+			dc.Records = append(dc.Records, dumpRecord(r))
+		}
+		dump.Consents = append(dump.Consents, dc)
+	}
+
+	checksum, err := dump.checksum()
+	dump.Checksum = checksum
+
+	return dump, err
+}
+
+func dumpRecord(r ConsentRecord) DumpedRecord {
+	dr := DumpedRecord{
+		UUID:               r.UUID,
+		Version:            r.Version,
+		Hash:               r.Hash,
+		PreviousHash:       r.PreviousHash,
+		ValidFrom:          r.ValidFrom,
+		ValidTo:            r.ValidTo,
+		DataClasses:        []string{},
+		DeletedAt:          r.DeletedAt,
+		DeletedReason:      r.DeletedReason,
+		DeletedBy:          r.DeletedBy,
+		ExpiringNotifiedAt: r.ExpiringNotifiedAt,
+		ExpiredNotifiedAt:  r.ExpiredNotifiedAt,
+	}
+
+	if !r.CreatedAt.IsZero() {
+		createdAt := r.CreatedAt
+		dr.CreatedAt = &createdAt
+	}
+
+	for _, dc := range r.DataClasses {
+		dr.DataClasses = append(dr.DataClasses, dc.Code)
+	}
+
+	return dr
+}
+
+// Restore loads a verified dump into an empty store in a single transaction, UUIDs, versions and hashes are preserved.
+// No consent events are emitted, the restore itself is recorded in the audit trail with the given restoredBy as actor.
+// It returns the number of restored records.
+func (cs *ConsentStore) Restore(ctx context.Context, dump Dump, restoredBy string) (int, error) {
+	if err := dump.Verify(); err != nil {
+		return 0, err
+	}
+
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := tx.Model(&PatientConsent{}).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if count > 0 {
+		tx.Rollback()
+		return 0, ErrorStoreNotEmpty
+	}
+
+	records := 0
+	for _, dc := range dump.Consents {
+		pc := PatientConsent{
+			ID:        dc.ID,
+			Subject:   dc.Subject,
+			Actor:     dc.Actor,
+			Custodian: dc.Custodian,
+		}
+		if err := tx.Create(&pc).Error; err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("unable to restore patient consent %s: %w", dc.ID, err)
+		}
+
+		for _, dr := range dc.Records {
+			cr := ConsentRecord{
+				PatientConsentID:   dc.ID,
+				UUID:               dr.UUID,
+				Version:            dr.Version,
+				Hash:               dr.Hash,
+				PreviousHash:       dr.PreviousHash,
+				ValidFrom:          dr.ValidFrom,
+				ValidTo:            dr.ValidTo,
+				DataClasses:        DataClassesFromStrings(dr.DataClasses),
+				DeletedAt:          dr.DeletedAt,
+				DeletedReason:      dr.DeletedReason,
+				DeletedBy:          dr.DeletedBy,
+				ExpiringNotifiedAt: dr.ExpiringNotifiedAt,
+				ExpiredNotifiedAt:  dr.ExpiredNotifiedAt,
+			}
+			// dumps of records without a creation time get the time of the restore
+			if dr.CreatedAt != nil {
+				cr.CreatedAt = *dr.CreatedAt
+			}
+			if err := tx.Create(&cr).Error; err != nil {
+				tx.Rollback()
+				return 0, fmt.Errorf("unable to restore consent record %s: %w", dr.Hash, err)
+			}
+			records++
+		}
+	}
+
+	details := map[string]interface{}{"consents": len(dump.Consents), "records": records, "checksum": dump.Checksum}
+	if err := writeAuditEntry(tx, AuditActionStoreRestore, restoredBy, nil, details); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return records, tx.Commit().Error
+}
@@ -0,0 +1,144 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+// dumpedStore returns a store with a chain of two versions, a deleted record and an expired record
+func dumpedStore(t *testing.T) *ConsentStore {
+	client := defaultConsentStore()
+
+	first := endingPatientConsent("subject", "actor", day)
+	second := first
+	validTo := time.Now().Add(2 * day)
+	second.Records = []ConsentRecord{{
+		ValidFrom:    time.Now(),
+		ValidTo:      &validTo,
+		Hash:         random.String(8),
+		PreviousHash: &first.Records[0].Hash,
+		DataClasses:  []DataClass{{Code: "a"}, {Code: "b"}},
+	}}
+	deleted := endingPatientConsent("deleted", "actor", day)
+	expired := endingPatientConsent("expired", "actor", -time.Hour)
+
+	for _, pc := range []PatientConsent{first, second, deleted, expired} {
+		if err := client.RecordConsent(context.TODO(), []PatientConsent{pc}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.DeleteConsentRecordByHash(context.TODO(), deleted.Records[0].Hash, "test", "reason"); err != nil {
+		t.Fatal(err)
+	}
+
+	return &client
+}
+
+func TestConsentStore_Dump(t *testing.T) {
+	client := dumpedStore(t)
+	defer client.Shutdown()
+
+	dump, err := client.Dump(context.TODO())
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, DumpFormatVersion, dump.Version)
+	assert.NoError(t, dump.Verify())
+	if assert.Len(t, dump.Consents, 3) {
+		var chain, deleted DumpedConsent
+		for _, dc := range dump.Consents {
+			switch dc.Subject {
+			case "subject":
+				chain = dc
+			case "deleted":
+				deleted = dc
+			}
+		}
+		if assert.Len(t, chain.Records, 2) {
+			assert.Equal(t, chain.Records[0].UUID, chain.Records[1].UUID)
+			assert.Equal(t, uint(2), chain.Records[1].Version)
+			assert.Equal(t, []string{"a", "b"}, chain.Records[1].DataClasses)
+		}
+		if assert.Len(t, deleted.Records, 1) {
+			assert.NotNil(t, deleted.Records[0].DeletedAt)
+		}
+	}
+}
+
+func TestConsentStore_Restore(t *testing.T) {
+	source := dumpedStore(t)
+	defer source.Shutdown()
+	dump, _ := source.Dump(context.TODO())
+
+	t.Run("preserves everything", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		sub, _, _ := client.Events.Subscribe(0)
+
+		n, err := client.Restore(context.TODO(), dump, "test")
+
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 4, n)
+		assert.Empty(t, sub.C)
+
+		restored, _ := client.Dump(context.TODO())
+		assert.Equal(t, dump.Checksum, restored.Checksum)
+
+		auth, _ := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "b", nil)
+		assert.True(t, auth)
+	})
+
+	t.Run("store must be empty", func(t *testing.T) {
+		_, err := source.Restore(context.TODO(), dump, "test")
+
+		assert.Equal(t, ErrorStoreNotEmpty, err)
+	})
+
+	t.Run("checksum must match", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		tampered := dump
+		tampered.Consents = dump.Consents[1:]
+
+		_, err := client.Restore(context.TODO(), tampered, "test")
+
+		assert.True(t, errors.Is(err, ErrorInvalidDump))
+	})
+
+	t.Run("version must be supported", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		future := dump
+		future.Version = DumpFormatVersion + 1
+
+		_, err := client.Restore(context.TODO(), future, "test")
+
+		assert.True(t, errors.Is(err, ErrorInvalidDump))
+	})
+}
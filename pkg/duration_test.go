@@ -0,0 +1,54 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	t.Run("parses days", func(t *testing.T) {
+		d, err := ParseDuration("30d")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 30*24*time.Hour, d)
+	})
+
+	t.Run("parses go durations", func(t *testing.T) {
+		d, err := ParseDuration("1h30m")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, d)
+	})
+
+	t.Run("invalid days returns error", func(t *testing.T) {
+		_, err := ParseDuration("xd")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid duration returns error", func(t *testing.T) {
+		_, err := ParseDuration("30x")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,306 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AuditActionSubjectErase is the audit action for the erasure of all consent data of a subject
+const AuditActionSubjectErase = "subject.erase"
+
+// ErasureCertificate lists everything that was removed for a subject, so the distributed consent layer can be told which chains are gone.
+// The subject itself is not included, audit entries refer to it by the Pseudonym.
+type ErasureCertificate struct {
+	Pseudonym         string    `json:"pseudonym"`
+	ErasedAt          time.Time `json:"erasedAt"`
+	ErasedBy          string    `json:"erasedBy"`
+	PatientConsentIDs []string  `json:"patientConsentIds"`
+	RecordHashes      []string  `json:"recordHashes"`
+}
+
+// PseudonymPrefix is the prefix of the pseudonyms that replace an erased subject
+const PseudonymPrefix = "pseudonym:"
+
+// pseudonym returns an irreversible pseudonym for the subject. A random salt is used, which is discarded afterwards.
+func pseudonym(subject string) (string, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(append(salt, []byte(subject)...))
+	return PseudonymPrefix + hex.EncodeToString(digest[:]), nil
+}
+
+// EraseSubject removes every PatientConsent, ConsentRecord (including tombstones and older versions) and DataClass of the subject in one transaction.
+// References to the subject in the audit trail, the webhook outbox and the buffered events are replaced by an irreversible pseudonym and
+// stored idempotent responses that mention the subject are removed. Erasing an unknown subject is not an error.
+// A ConsentDeleted event with the pseudonym as subject is emitted for every record that wasn't deleted yet, so subscribers drop the erased grants.
+func (cs *ConsentStore) EraseSubject(ctx context.Context, subject string, erasedBy string) (certificate ErasureCertificate, err error) {
+	ctx, done := cs.startOperation(ctx, operationEraseSubject)
+	defer done(&err)
+
+	p, err := pseudonym(subject)
+	if err != nil {
+		return ErasureCertificate{}, err
+	}
+
+	certificate = ErasureCertificate{
+		Pseudonym:         p,
+		ErasedAt:          time.Now(),
+		ErasedBy:          erasedBy,
+		PatientConsentIDs: []string{},
+		RecordHashes:      []string{},
+	}
+
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return ErasureCertificate{}, err
+	}
+
+	if err := tx.Model(&PatientConsent{}).Where("subject = ?", subject).Order("id").Pluck("id", &certificate.PatientConsentIDs).Error; err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	var events []Event
+	if len(certificate.PatientConsentIDs) > 0 {
+		if events, err = erasureEvents(tx, certificate.PatientConsentIDs); err != nil {
+			tx.Rollback()
+			return ErasureCertificate{}, err
+		}
+
+		var ids []uint
+		if err := tx.Unscoped().Model(&ConsentRecord{}).Where("patient_consent_id IN (?)", certificate.PatientConsentIDs).Order("id").Pluck("id", &ids).Error; err != nil {
+			tx.Rollback()
+			return ErasureCertificate{}, err
+		}
+		if err := tx.Unscoped().Model(&ConsentRecord{}).Where("patient_consent_id IN (?)", certificate.PatientConsentIDs).Order("id").Pluck("hash", &certificate.RecordHashes).Error; err != nil {
+			tx.Rollback()
+			return ErasureCertificate{}, err
+		}
+
+		if len(ids) > 0 {
+			if err := tx.Delete(DataClass{}, "consent_record_id IN (?)", ids).Error; err != nil {
+				tx.Rollback()
+				return ErasureCertificate{}, err
+			}
+			if err := tx.Unscoped().Delete(ConsentRecord{}, "id IN (?)", ids).Error; err != nil {
+				tx.Rollback()
+				return ErasureCertificate{}, err
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM patient_consent WHERE subject = ?", subject).Error; err != nil {
+			tx.Rollback()
+			return ErasureCertificate{}, err
+		}
+	}
+
+	if err := pseudonymiseAuditEntries(tx, subject, p); err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	if err := pseudonymiseWebhookOutbox(tx, subject, p); err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	// bulk import reports can list the subject
+	if err := tx.Where("instr(body, ?) > 0", subject).Delete(IdempotentResponse{}).Error; err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	for i := range events {
+		events[i].Subject = p
+	}
+	if err := cs.writeOutbox(tx, events); err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	if err := writeAuditEntry(tx, AuditActionSubjectErase, erasedBy, &p, certificate); err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	if err := cs.saveIdempotentResponse(ctx, tx, certificate); err != nil {
+		tx.Rollback()
+		return ErasureCertificate{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return ErasureCertificate{}, err
+	}
+
+	if cs.Events != nil {
+		cs.Events.Pseudonymise(subject, p)
+	}
+	cs.publish(events)
+	return certificate, nil
+}
+
+// erasureEvents returns a ConsentDeleted event for every record of the patient consents that isn't deleted yet
+func erasureEvents(tx *gorm.DB, patientConsentIDs []string) ([]Event, error) {
+	var pcs []PatientConsent
+	if err := tx.Where("id IN (?)", patientConsentIDs).Order("id").Find(&pcs).Error; err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, pc := range pcs {
+		var records []ConsentRecord
+		if err := tx.Preload("DataClasses").Where("patient_consent_id = ?", pc.ID).Order("id").Find(&records).Error; err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			events = append(events, newEvent(EventConsentDeleted, pc, r))
+		}
+	}
+
+	return events, nil
+}
+
+// pseudonymiseWebhookOutbox replaces the subject of the events in the webhook outbox, pending deliveries are sent with the pseudonym
+func pseudonymiseWebhookOutbox(tx *gorm.DB, subject string, p string) error {
+	var entries []WebhookOutboxEntry
+	if err := tx.Where("instr(payload, ?) > 0", subject).Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		var e Event
+		if err := json.Unmarshal([]byte(entry.Payload), &e); err != nil {
+			return err
+		}
+		if e.Subject != subject {
+			continue
+		}
+
+		e.Subject = p
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&entry).Update("payload", string(payload)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pseudonymiseAuditEntries replaces all references to the subject in the audit trail. Only whole values are replaced,
+// so subjects that merely share a prefix with the erased subject are left intact.
+func pseudonymiseAuditEntries(tx *gorm.DB, subject string, p string) error {
+	var entries []AuditEntry
+	if err := tx.Where("subject = ? OR actor = ? OR instr(details, ?) > 0", subject, subject, subject).Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		update := map[string]interface{}{}
+		if e.Subject != nil && *e.Subject == subject {
+			update["subject"] = p
+		}
+		if e.Actor == subject {
+			update["actor"] = p
+		}
+		details, err := pseudonymiseDetails(e.Details, subject, p)
+		if err != nil {
+			return err
+		}
+		if details != e.Details {
+			update["details"] = details
+		}
+
+		if len(update) == 0 {
+			continue
+		}
+		if err := tx.Model(&e).Updates(update).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pseudonymiseDetails replaces every string value in the JSON details that equals the subject
+func pseudonymiseDetails(details string, subject string, p string) (string, error) {
+	if details == "" {
+		return details, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(details))
+	// keep numbers as they are, instead of converting them to floats
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return "", err
+	}
+
+	v, changed := replaceValue(v, subject, p)
+	if !changed {
+		return details, nil
+	}
+
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// replaceValue replaces the strings equal to value in v, including those nested in objects and arrays
+func replaceValue(v interface{}, value string, replacement string) (interface{}, bool) {
+	switch t := v.(type) {
+	case string:
+		if t == value {
+			return replacement, true
+		}
+	case []interface{}:
+		changed := false
+		for i, item := range t {
+			var c bool
+			t[i], c = replaceValue(item, value, replacement)
+			changed = changed || c
+		}
+		return t, changed
+	case map[string]interface{}:
+		changed := false
+		for k, item := range t {
+			var c bool
+			t[k], c = replaceValue(item, value, replacement)
+			changed = changed || c
+		}
+		return t, changed
+	}
+	return v, false
+}
@@ -0,0 +1,208 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_EraseSubject(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	client.webhooks = []Webhook{{URL: "http://localhost", Secret: "secret"}}
+
+	pcs := patientConsent()
+	other := expiredPatientConsent("other", "custodian", "resource", day)
+	if err := client.RecordConsent(context.TODO(), []PatientConsent{pcs[0], other}); err != nil {
+		t.Fatal(err)
+	}
+	update := pcs[0]
+	update.Records = []ConsentRecord{{
+		ValidFrom:    time.Now(),
+		Hash:         random.String(8),
+		PreviousHash: &pcs[0].Records[0].Hash,
+		DataClasses:  []DataClass{{Code: "resource"}},
+	}}
+	if err := client.RecordConsent(context.TODO(), []PatientConsent{update}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteConsentRecordByHash(context.TODO(), update.Records[0].Hash, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ExportSubject(context.TODO(), "subject", "dpo"); err != nil {
+		t.Fatal(err)
+	}
+
+	report := IdempotentResponse{Key: "import", Caller: "importer", Fingerprint: "f", Status: 200, Body: `{"errors":[{"line":1,"error":"subject"}]}`, CreatedAt: time.Now()}
+	if err := client.Db.Create(&report).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	sub, _, _ := client.Events.Subscribe(0)
+
+	certificate, err := client.EraseSubject(context.TODO(), "subject", "dpo")
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("certificate lists all removed records", func(t *testing.T) {
+		assert.Equal(t, []string{pcs[0].ID}, certificate.PatientConsentIDs)
+		assert.Equal(t, []string{pcs[0].Records[0].Hash, update.Records[0].Hash}, certificate.RecordHashes)
+		assert.Equal(t, "dpo", certificate.ErasedBy)
+		assert.True(t, strings.HasPrefix(certificate.Pseudonym, "pseudonym:"))
+	})
+
+	t.Run("all rows of the subject are removed", func(t *testing.T) {
+		var count int
+		client.Db.Model(&PatientConsent{}).Where("subject = ?", "subject").Count(&count)
+		assert.Equal(t, 0, count)
+		client.Db.Unscoped().Model(&ConsentRecord{}).Count(&count)
+		assert.Equal(t, 1, count)
+		client.Db.Model(&DataClass{}).Count(&count)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("audit trail is pseudonymised", func(t *testing.T) {
+		entries, _ := client.AuditEntries(context.TODO(), "subject")
+		assert.Empty(t, entries)
+
+		entries, _ = client.AuditEntries(context.TODO(), certificate.Pseudonym)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, AuditActionSubjectExport, entries[0].Action)
+			assert.Equal(t, AuditActionSubjectErase, entries[1].Action)
+			assert.Contains(t, entries[1].Details, update.Records[0].Hash)
+		}
+	})
+
+	t.Run("deletion of the live record is published", func(t *testing.T) {
+		if assert.Len(t, sub.C, 1) {
+			e := <-sub.C
+			assert.Equal(t, EventConsentDeleted, e.Type)
+			assert.Equal(t, certificate.Pseudonym, e.Subject)
+			assert.Equal(t, pcs[0].Records[0].Hash, e.RecordHash)
+		}
+	})
+
+	t.Run("buffered events are pseudonymised", func(t *testing.T) {
+		_, missed, _ := client.Events.Subscribe(1)
+
+		if assert.NotEmpty(t, missed) {
+			for _, e := range missed {
+				assert.NotEqual(t, "subject", e.Subject)
+			}
+		}
+	})
+
+	t.Run("idempotent responses mentioning the subject are removed", func(t *testing.T) {
+		var count int
+		client.Db.Model(&IdempotentResponse{}).Where("key = ?", "import").Count(&count)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("webhook outbox is pseudonymised", func(t *testing.T) {
+		var entries []WebhookOutboxEntry
+		client.Db.Order("id").Find(&entries)
+
+		var erased []Event
+		for _, entry := range entries {
+			var e Event
+			json.Unmarshal([]byte(entry.Payload), &e)
+			if e.PatientConsentID == pcs[0].ID {
+				erased = append(erased, e)
+			} else {
+				assert.Equal(t, "other", e.Subject)
+			}
+		}
+		// recorded, appended, deleted and the deletion by the erasure
+		if assert.Len(t, erased, 4) {
+			for _, e := range erased {
+				assert.Equal(t, certificate.Pseudonym, e.Subject)
+			}
+			assert.Equal(t, EventConsentDeleted, erased[3].Type)
+		}
+	})
+
+	t.Run("other subjects are untouched", func(t *testing.T) {
+		_, err := client.FindConsentRecordByHash(context.TODO(), other.Records[0].Hash, false)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("erasing an unknown subject returns an empty certificate", func(t *testing.T) {
+		certificate, err := client.EraseSubject(context.TODO(), "unknown", "dpo")
+
+		if assert.NoError(t, err) {
+			assert.Empty(t, certificate.RecordHashes)
+		}
+	})
+}
+
+func TestPseudonymiseAuditEntries(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	subject := "urn:oid:2.16.840.1.113883.2.4.6.3:12"
+	prefixed := subject + "3"
+	details := map[string]interface{}{"subject": subject, "other": prefixed, "subjects": []string{subject, prefixed}, "count": 12345678901234567}
+	if err := writeAuditEntry(client.Db, AuditActionSubjectExport, subject, &subject, details); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeAuditEntry(client.Db, AuditActionSubjectExport, prefixed, &prefixed, details); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pseudonymiseAuditEntries(client.Db, subject, "pseudonym"); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []AuditEntry
+	client.Db.Order("id").Find(&entries)
+	if !assert.Len(t, entries, 2) {
+		return
+	}
+	expected := `{"count":12345678901234567,"other":"urn:oid:2.16.840.1.113883.2.4.6.3:123","subject":"pseudonym","subjects":["pseudonym","urn:oid:2.16.840.1.113883.2.4.6.3:123"]}`
+
+	t.Run("whole values are replaced", func(t *testing.T) {
+		assert.Equal(t, "pseudonym", *entries[0].Subject)
+		assert.Equal(t, "pseudonym", entries[0].Actor)
+		assert.JSONEq(t, expected, entries[0].Details)
+	})
+
+	t.Run("subjects sharing a prefix are left intact", func(t *testing.T) {
+		assert.Equal(t, prefixed, *entries[1].Subject)
+		assert.Equal(t, prefixed, entries[1].Actor)
+		assert.JSONEq(t, expected, entries[1].Details)
+	})
+}
+
+func TestPseudonym(t *testing.T) {
+	p1, _ := pseudonym("subject")
+	p2, _ := pseudonym("subject")
+
+	assert.NotEqual(t, p1, p2)
+	assert.NotContains(t, p1, "subject")
+}
@@ -0,0 +1,213 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of consent mutation
+type EventType string
+
+const (
+	// EventConsentRecorded is emitted when the first version of a consent record is stored
+	EventConsentRecorded EventType = "ConsentRecorded"
+	// EventConsentVersionAppended is emitted when a new version is added to an existing chain
+	EventConsentVersionAppended EventType = "ConsentVersionAppended"
+	// EventConsentDeleted is emitted when a consent record is replaced by a tombstone
+	EventConsentDeleted EventType = "ConsentDeleted"
+	// EventConsentExpiring is emitted by the expiry scheduler when the ValidTo of the latest version falls within the configured horizon
+	EventConsentExpiring EventType = "ConsentExpiring"
+	// EventConsentExpired is emitted by the expiry scheduler when the latest version has ended. It's also emitted, next to the recorded or appended event,
+	// when a stored version ends the consent: its ValidTo is not after the moment of recording
+	EventConsentExpired EventType = "ConsentExpired"
+)
+
+// subscriptionBufferSize is the number of events a subscriber may lag behind before it's dropped
+const subscriptionBufferSize = 64
+
+// Event describes a single change to a consent record
+type Event struct {
+	ID                 uint64     `json:"id"`
+	Type               EventType  `json:"type"`
+	Timestamp          time.Time  `json:"timestamp"`
+	PatientConsentID   string     `json:"patientConsentId"`
+	Subject            string     `json:"subject"`
+	Custodian          string     `json:"custodian"`
+	Actor              string     `json:"actor"`
+	RecordHash         string     `json:"recordHash"`
+	PreviousRecordHash *string    `json:"previousRecordHash,omitempty"`
+	Version            uint       `json:"version"`
+	DataClasses        []string   `json:"dataClasses"`
+	ValidFrom          time.Time  `json:"validFrom"`
+	ValidTo            *time.Time `json:"validTo,omitempty"`
+}
+
+// newEvent creates an event for the given consent and record, the ID is assigned when it's published
+func newEvent(eventType EventType, pc PatientConsent, cr ConsentRecord) Event {
+	e := Event{
+		Type:               eventType,
+		Timestamp:          time.Now(),
+		PatientConsentID:   pc.ID,
+		Subject:            pc.Subject,
+		Custodian:          pc.Custodian,
+		Actor:              pc.Actor,
+		RecordHash:         cr.Hash,
+		PreviousRecordHash: cr.PreviousHash,
+		Version:            cr.Version,
+		DataClasses:        []string{},
+		ValidFrom:          cr.ValidFrom,
+		ValidTo:            cr.ValidTo,
+	}
+	for _, dc := range cr.DataClasses {
+		e.DataClasses = append(e.DataClasses, dc.Code)
+	}
+	return e
+}
+
+// EventBus distributes events to in-process subscribers and keeps the most recent events in a ring buffer,
+// so subscribers can resume from the last event they've seen.
+// Event IDs are increasing and start at the creation time of the bus in nanoseconds, so IDs of a previous run are always lower.
+type EventBus struct {
+	mutex       sync.Mutex
+	lastID      uint64
+	buffer      []Event
+	next        int
+	full        bool
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives published events on C. When the subscriber can't keep up, C is closed and the subscriber
+// has to subscribe again with the ID of the last event it received.
+type Subscription struct {
+	C  <-chan Event
+	ch chan Event
+}
+
+// NewEventBus creates an EventBus that keeps the given number of events for resuming subscribers
+func NewEventBus(capacity int) *EventBus {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &EventBus{
+		lastID:      uint64(time.Now().UnixNano()),
+		buffer:      make([]Event, capacity),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish assigns IDs to the events and delivers them to all subscribers
+func (b *EventBus) Publish(events ...Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, e := range events {
+		b.lastID++
+		e.ID = b.lastID
+
+		b.buffer[b.next] = e
+		b.next = (b.next + 1) % len(b.buffer)
+		if b.next == 0 {
+			b.full = true
+		}
+
+		for s := range b.subscribers {
+			select {
+			case s.ch <- e:
+			default:
+				// slow subscriber, it'll have to resume
+				delete(b.subscribers, s)
+				close(s.ch)
+			}
+		}
+	}
+}
+
+// Subscribe registers a new subscriber. When afterID is not 0, the buffered events after that ID are returned,
+// these are not sent on the subscription. complete is false when events after afterID are no longer buffered.
+func (b *EventBus) Subscribe(afterID uint64) (sub *Subscription, missed []Event, complete bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	complete = true
+	if afterID != 0 {
+		buffered := b.buffered()
+		for _, e := range buffered {
+			if e.ID > afterID {
+				missed = append(missed, e)
+			}
+		}
+		// the event directly after afterID must still be available
+		if afterID < b.lastID && (len(missed) == 0 || missed[0].ID != afterID+1) {
+			complete = false
+		}
+	}
+
+	ch := make(chan Event, subscriptionBufferSize)
+	sub = &Subscription{C: ch, ch: ch}
+	b.subscribers[sub] = struct{}{}
+
+	return sub, missed, complete
+}
+
+// Unsubscribe removes the subscriber, C is closed
+func (b *EventBus) Unsubscribe(sub *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Pseudonymise replaces the subject in the buffered events, so resuming subscribers don't receive an erased subject
+func (b *EventBus) Pseudonymise(subject string, pseudonym string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for i := range b.buffer {
+		if b.buffer[i].Subject == subject {
+			b.buffer[i].Subject = pseudonym
+		}
+	}
+}
+
+// buffered returns the buffered events, oldest first
+func (b *EventBus) buffered() []Event {
+	if !b.full {
+		return append([]Event{}, b.buffer[:b.next]...)
+	}
+	return append(append([]Event{}, b.buffer[b.next:]...), b.buffer[:b.next]...)
+}
+
+// publish sends the events to the EventBus, if there is one, and wakes up the webhook worker to deliver the outbox
+func (cs *ConsentStore) publish(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	if cs.Events != nil {
+		cs.Events.Publish(events...)
+	}
+	if cs.webhookJob != nil {
+		cs.webhookJob.Trigger()
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus(t *testing.T) {
+	t.Run("subscriber receives published events with increasing IDs", func(t *testing.T) {
+		bus := NewEventBus(10)
+		sub, missed, complete := bus.Subscribe(0)
+
+		bus.Publish(Event{Type: EventConsentRecorded}, Event{Type: EventConsentDeleted})
+
+		assert.Empty(t, missed)
+		assert.True(t, complete)
+		e1 := <-sub.C
+		e2 := <-sub.C
+		assert.Equal(t, EventConsentRecorded, e1.Type)
+		assert.Equal(t, e1.ID+1, e2.ID)
+	})
+
+	t.Run("resuming subscriber receives missed events", func(t *testing.T) {
+		bus := NewEventBus(10)
+		sub, _, _ := bus.Subscribe(0)
+		bus.Publish(Event{Type: EventConsentRecorded}, Event{Type: EventConsentVersionAppended}, Event{Type: EventConsentDeleted})
+		first := <-sub.C
+
+		_, missed, complete := bus.Subscribe(first.ID)
+
+		assert.True(t, complete)
+		if assert.Len(t, missed, 2) {
+			assert.Equal(t, EventConsentVersionAppended, missed[0].Type)
+			assert.Equal(t, EventConsentDeleted, missed[1].Type)
+		}
+	})
+
+	t.Run("resuming after the buffer was overwritten is incomplete", func(t *testing.T) {
+		bus := NewEventBus(2)
+		sub, _, _ := bus.Subscribe(0)
+		bus.Publish(Event{}, Event{}, Event{}, Event{})
+		first := <-sub.C
+
+		_, missed, complete := bus.Subscribe(first.ID)
+
+		assert.False(t, complete)
+		assert.Len(t, missed, 2)
+	})
+
+	t.Run("resuming with an ID of a previous run is incomplete", func(t *testing.T) {
+		bus := NewEventBus(2)
+		bus.Publish(Event{})
+
+		_, _, complete := bus.Subscribe(1)
+
+		assert.False(t, complete)
+	})
+
+	t.Run("slow subscriber is dropped", func(t *testing.T) {
+		bus := NewEventBus(10)
+		sub, _, _ := bus.Subscribe(0)
+
+		for i := 0; i <= subscriptionBufferSize; i++ {
+			bus.Publish(Event{})
+		}
+
+		for range sub.C {
+		}
+		bus.Unsubscribe(sub)
+	})
+}
+
+func TestConsentStore_events(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	sub, _, _ := client.Events.Subscribe(0)
+
+	pcs := patientConsent()
+	if err := client.RecordConsent(context.TODO(), pcs); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("recording a new record emits ConsentRecorded", func(t *testing.T) {
+		e := <-sub.C
+
+		assert.Equal(t, EventConsentRecorded, e.Type)
+		assert.Equal(t, pcs[0].Records[0].Hash, e.RecordHash)
+		assert.Equal(t, "subject", e.Subject)
+		assert.Equal(t, []string{"resource"}, e.DataClasses)
+	})
+
+	t.Run("ending consent emits ConsentVersionAppended and ConsentExpired", func(t *testing.T) {
+		validTo := time.Now()
+		update := pcs[0]
+		update.Records = []ConsentRecord{{
+			ValidFrom:    validTo.Add(-time.Hour),
+			ValidTo:      &validTo,
+			Hash:         random.String(8),
+			PreviousHash: &pcs[0].Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+		if err := client.RecordConsent(context.TODO(), []PatientConsent{update}); err != nil {
+			t.Fatal(err)
+		}
+
+		e1 := <-sub.C
+		e2 := <-sub.C
+		assert.Equal(t, EventConsentVersionAppended, e1.Type)
+		assert.Equal(t, uint(2), e1.Version)
+		assert.Equal(t, EventConsentExpired, e2.Type)
+		assert.Equal(t, update.Records[0].Hash, e2.RecordHash)
+
+		if _, err := client.DeleteConsentRecordByHash(context.TODO(), update.Records[0].Hash, "", ""); err != nil {
+			t.Fatal(err)
+		}
+		e3 := <-sub.C
+		assert.Equal(t, EventConsentDeleted, e3.Type)
+		assert.Equal(t, []string{"resource"}, e3.DataClasses)
+	})
+
+	t.Run("failed transaction emits nothing", func(t *testing.T) {
+		unknown := "unknown"
+		update := pcs[0]
+		update.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			Hash:         random.String(8),
+			PreviousHash: &unknown,
+		}}
+		assert.Error(t, client.RecordConsent(context.TODO(), []PatientConsent{update}))
+
+		select {
+		case e := <-sub.C:
+			t.Errorf("unexpected event %v", e)
+		default:
+		}
+	})
+}
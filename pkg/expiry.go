@@ -0,0 +1,200 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// latestRecords returns a sub query selecting the IDs of the latest, not deleted, version of every chain matching the given consent
+func latestRecords(db *gorm.DB, pc PatientConsent) interface{} {
+	return db.Where(pc).
+		Table("patient_consent").
+		Select("consent_record.id").
+		Joins("left join consent_record on consent_record.patient_consent_id = patient_consent.id AND consent_record.deleted_at IS NULL").
+		Group("consent_record.uuid").Having("max(consent_record.version)").QueryExpr()
+}
+
+// ExpiringConsent returns the consent of which the latest record is still valid at now, but ends within the given period.
+// Actor and custodian are optional filters. The records are ordered by their ValidTo, the consent by its first ending record.
+func (cs *ConsentStore) ExpiringConsent(ctx context.Context, actor *string, custodian *string, now time.Time, within time.Duration) ([]PatientConsent, error) {
+	var pc PatientConsent
+	if actor != nil {
+		pc.Actor = *actor
+	}
+	if custodian != nil {
+		pc.Custodian = *custodian
+	}
+
+	var ids []uint
+	if err := cs.db(ctx).Debug().
+		Table("consent_record").
+		Where("id IN (?)", latestRecords(cs.db(ctx), pc)).
+		Where("julianday(valid_from) <= julianday(?)", now).
+		Where("valid_to IS NOT NULL AND julianday(valid_to) > julianday(?) AND julianday(valid_to) <= julianday(?)", now, now.Add(within)).
+		Order("valid_to").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	consent, err := cs.patientConsentByConsentRecord(ctx, ids, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// records are added in order of ValidTo, so the first record of a consent ends first
+	sort.Slice(consent, func(i, j int) bool {
+		return consent[i].Records[0].ValidTo.Before(*consent[j].Records[0].ValidTo)
+	})
+
+	return consent, nil
+}
+
+// ScanExpiry emits a ConsentExpiring event for the latest records that end within the configured horizon and a ConsentExpired event
+// for those that ended, both only once per record. It's called by the background job and returns the emitted events.
+func (cs *ConsentStore) ScanExpiry(ctx context.Context, now time.Time) ([]Event, error) {
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	var events []Event
+
+	expiring, err := cs.notifyExpiry(tx, EventConsentExpiring, "expiring_notified_at", now,
+		"julianday(valid_to) > julianday(?) AND julianday(valid_to) <= julianday(?)", now, now.Add(cs.expiryHorizon))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	events = append(events, expiring...)
+
+	expired, err := cs.notifyExpiry(tx, EventConsentExpired, "expired_notified_at", now,
+		"julianday(valid_to) <= julianday(?)", now)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	events = append(events, expired...)
+
+	if err := cs.writeOutbox(tx, events); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	cs.publish(events)
+	return events, nil
+}
+
+// notifyExpiry creates events for the latest records matching the condition that haven't been notified yet and marks them as notified
+func (cs *ConsentStore) notifyExpiry(tx *gorm.DB, eventType EventType, column string, now time.Time, condition string, args ...interface{}) ([]Event, error) {
+	var records []ConsentRecord
+
+	if err := tx.Preload("DataClasses").
+		Where("id IN (?)", latestRecords(tx, PatientConsent{})).
+		Where("valid_to IS NOT NULL AND "+column+" IS NULL").
+		Where(condition, args...).
+		Order("valid_to").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	var (
+		events []Event
+		ids    []uint
+	)
+	for _, r := range records {
+		var pc PatientConsent
+		if err := tx.Where("id = ?", r.PatientConsentID).First(&pc).Error; err != nil {
+			return nil, err
+		}
+		events = append(events, newEvent(eventType, pc, r))
+		ids = append(ids, r.ID)
+	}
+
+	if len(ids) > 0 {
+		if err := tx.Table(ConsentRecord{}.TableName()).Where("id IN (?)", ids).Update(column, now).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// ExpiryHorizonPeriod returns the period before the end of a consent record in which the ConsentExpiring event is emitted,
+// the default is used when it's not configured
+func (c ConsentStoreConfig) ExpiryHorizonPeriod() (time.Duration, error) {
+	horizon := c.ExpiryHorizon
+	if horizon == "" {
+		horizon = ConfigExpiryHorizonDefault
+	}
+
+	d, err := ParseDuration(horizon)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ConfigExpiryHorizon, err)
+	}
+	return d, nil
+}
+
+// expiryInterval returns the interval of the background job, the default is used when it's not configured.
+// An interval of 0 disables the background job.
+func (c ConsentStoreConfig) expiryInterval() (time.Duration, error) {
+	interval := c.ExpiryInterval
+	if interval == "" {
+		interval = ConfigExpiryIntervalDefault
+	}
+
+	d, err := ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ConfigExpiryInterval, err)
+	}
+	return d, nil
+}
+
+// startExpiryJob periodically scans for expiring and expired consent in the background, it's stopped by Shutdown.
+// The first scan takes place after the interval, it also picks up what expired while the node was down.
+func (cs *ConsentStore) startExpiryJob(interval time.Duration) {
+	cs.expiryJob = startJob(interval, func() {
+		events, err := cs.ScanExpiry(context.Background(), time.Now())
+		if err != nil {
+			Logger().Errorf("error scanning for expiring consent: %v", err)
+			return
+		}
+		if len(events) > 0 {
+			Logger().Infof("emitted %d consent expiry events", len(events))
+		}
+	})
+}
+
+// stopExpiryJob stops the background job and waits for a running scan to finish
+func (cs *ConsentStore) stopExpiryJob() {
+	if cs.expiryJob == nil {
+		return
+	}
+
+	cs.expiryJob.Stop()
+	cs.expiryJob = nil
+}
@@ -0,0 +1,184 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+// endingPatientConsent returns a consent with a single record that ends after the given period
+func endingPatientConsent(subject string, actor string, endsIn time.Duration) PatientConsent {
+	validTo := time.Now().Add(endsIn)
+	return PatientConsent{
+		ID:        random.String(8),
+		Actor:     actor,
+		Custodian: "custodian",
+		Subject:   subject,
+		Records: []ConsentRecord{{
+			ValidFrom:   time.Now().Add(-day),
+			ValidTo:     &validTo,
+			Hash:        random.String(8),
+			DataClasses: []DataClass{{Code: "resource"}},
+		}},
+	}
+}
+
+func TestConsentStore_ExpiringConsent(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	client.RecordConsent(context.TODO(), []PatientConsent{
+		endingPatientConsent("later", "actor", 20*day),
+		endingPatientConsent("soon", "actor", 2*day),
+		endingPatientConsent("other actor", "other", 3*day),
+		endingPatientConsent("not within", "actor", 60*day),
+		endingPatientConsent("ended", "actor", -time.Hour),
+	})
+
+	t.Run("returns consent ending within the period, first ending first", func(t *testing.T) {
+		consent, err := client.ExpiringConsent(context.TODO(), nil, nil, time.Now(), 30*day)
+
+		if assert.NoError(t, err) && assert.Len(t, consent, 3) {
+			assert.Equal(t, "soon", consent[0].Subject)
+			assert.Equal(t, "other actor", consent[1].Subject)
+			assert.Equal(t, "later", consent[2].Subject)
+		}
+	})
+
+	t.Run("filters on actor", func(t *testing.T) {
+		actor := "other"
+
+		consent, err := client.ExpiringConsent(context.TODO(), &actor, nil, time.Now(), 30*day)
+
+		if assert.NoError(t, err) && assert.Len(t, consent, 1) {
+			assert.Equal(t, "other actor", consent[0].Subject)
+		}
+	})
+
+	t.Run("extended consent is not expiring", func(t *testing.T) {
+		consent, _ := client.ExpiringConsent(context.TODO(), nil, nil, time.Now(), 30*day)
+		soon := consent[0]
+		validTo := time.Now().Add(365 * day)
+		soon.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			ValidTo:      &validTo,
+			Hash:         random.String(8),
+			PreviousHash: &soon.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+		if err := client.RecordConsent(context.TODO(), []PatientConsent{soon}); err != nil {
+			t.Fatal(err)
+		}
+
+		consent, _ = client.ExpiringConsent(context.TODO(), nil, nil, time.Now(), 30*day)
+
+		assert.Len(t, consent, 2)
+	})
+}
+
+func TestConsentStore_ScanExpiry(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	client.expiryHorizon = 30 * day
+
+	client.RecordConsent(context.TODO(), []PatientConsent{
+		endingPatientConsent("soon", "actor", 2*day),
+		endingPatientConsent("not within", "actor", 60*day),
+		endingPatientConsent("ended", "actor", -time.Hour),
+	})
+	sub, _, _ := client.Events.Subscribe(0)
+
+	t.Run("emits expiring events once", func(t *testing.T) {
+		events, err := client.ScanExpiry(context.TODO(), time.Now())
+
+		if assert.NoError(t, err) && assert.Len(t, events, 1) {
+			assert.Equal(t, EventConsentExpiring, events[0].Type)
+			assert.Equal(t, "soon", events[0].Subject)
+			assert.Equal(t, EventConsentExpiring, (<-sub.C).Type)
+		}
+
+		events, _ = client.ScanExpiry(context.TODO(), time.Now())
+		assert.Empty(t, events)
+	})
+
+	t.Run("emits expired events once, records that were stored after they ended are skipped", func(t *testing.T) {
+		events, err := client.ScanExpiry(context.TODO(), time.Now().Add(3*day))
+
+		if assert.NoError(t, err) && assert.Len(t, events, 1) {
+			assert.Equal(t, EventConsentExpired, events[0].Type)
+			assert.Equal(t, "soon", events[0].Subject)
+		}
+
+		events, _ = client.ScanExpiry(context.TODO(), time.Now().Add(3*day))
+		assert.Empty(t, events)
+	})
+
+	t.Run("deleted records are skipped", func(t *testing.T) {
+		consent, _ := client.ExpiringConsent(context.TODO(), nil, nil, time.Now().Add(31*day), 30*day)
+		if !assert.Len(t, consent, 1) {
+			return
+		}
+		client.DeleteConsentRecordByHash(context.TODO(), consent[0].Records[0].Hash, "", "")
+
+		events, err := client.ScanExpiry(context.TODO(), time.Now().Add(31*day))
+
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
+
+func TestConsentStoreConfig_ExpiryHorizonPeriod(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		d, err := ConsentStoreConfig{}.ExpiryHorizonPeriod()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 30*day, d)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ConsentStoreConfig{ExpiryHorizon: "soon"}.ExpiryHorizonPeriod()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConsentStore_expiryJob(t *testing.T) {
+	client := defaultConsentStore()
+	client.expiryHorizon = 30 * day
+	client.RecordConsent(context.TODO(), []PatientConsent{endingPatientConsent("soon", "actor", 2*day)})
+	sub, _, _ := client.Events.Subscribe(0)
+
+	client.stopExpiryJob()
+	client.startExpiryJob(10 * time.Millisecond)
+
+	select {
+	case e := <-sub.C:
+		assert.Equal(t, EventConsentExpiring, e.Type)
+	case <-time.After(time.Second):
+		t.Error("expected ConsentExpiring event")
+	}
+
+	client.Shutdown()
+	assert.Nil(t, client.expiryJob)
+}
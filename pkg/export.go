@@ -0,0 +1,150 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditActionSubjectExport is the audit action for an export of all consent data of a subject
+const AuditActionSubjectExport = "subject.export"
+
+// SubjectExport holds all consent data about a single subject, including inactive, deleted and older versions of records
+type SubjectExport struct {
+	Subject      string               `json:"subject"`
+	ExportedAt   time.Time            `json:"exportedAt"`
+	Consents     []ExportedConsent    `json:"consents"`
+	AuditEntries []ExportedAuditEntry `json:"auditEntries"`
+}
+
+// ExportedConsent is the export representation of a PatientConsent
+type ExportedConsent struct {
+	ID        string           `json:"id"`
+	Actor     string           `json:"actor"`
+	Custodian string           `json:"custodian"`
+	Records   []ExportedRecord `json:"records"`
+}
+
+// ExportedRecord is the export representation of a single version of a ConsentRecord
+type ExportedRecord struct {
+	Hash          string     `json:"hash"`
+	PreviousHash  *string    `json:"previousHash,omitempty"`
+	Version       uint       `json:"version"`
+	ValidFrom     time.Time  `json:"validFrom"`
+	ValidTo       *time.Time `json:"validTo,omitempty"`
+	DataClasses   []string   `json:"dataClasses"`
+	Active        bool       `json:"active"`
+	DeletedAt     *time.Time `json:"deletedAt,omitempty"`
+	DeletedReason *string    `json:"deletedReason,omitempty"`
+	DeletedBy     *string    `json:"deletedBy,omitempty"`
+}
+
+// ExportedAuditEntry is the export representation of an AuditEntry
+type ExportedAuditEntry struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	Details   json.RawMessage `json:"details,omitempty"`
+}
+
+// ExportSubject gathers all consent data and audit entries of the given subject. Every version of every chain is included,
+// regardless if it's still active. The export itself is recorded in the audit trail with the given exportedBy as actor.
+func (cs *ConsentStore) ExportSubject(ctx context.Context, subject string, exportedBy string) (SubjectExport, error) {
+	now := time.Now()
+	export := SubjectExport{
+		Subject:      subject,
+		ExportedAt:   now,
+		Consents:     []ExportedConsent{},
+		AuditEntries: []ExportedAuditEntry{},
+	}
+
+	var pcs []PatientConsent
+	if err := cs.db(ctx).Debug().Where("subject = ?", subject).Order("id").Find(&pcs).Error; err != nil {
+		return export, err
+	}
+
+	for _, pc := range pcs {
+		var records []ConsentRecord
+		if err := cs.db(ctx).Debug().Unscoped().Preload("DataClasses").
+			Where("patient_consent_id = ?", pc.ID).
+			Order("uuid").Order("version").Find(&records).Error; err != nil {
+			return export, err
+		}
+
+		ec := ExportedConsent{
+			ID:        pc.ID,
+			Actor:     pc.Actor,
+			Custodian: pc.Custodian,
+			Records:   []ExportedRecord{},
+		}
+		for i, r := range records {
+			ec.Records = append(ec.Records, exportRecord(r, isLatest(records, i), now))
+		}
+		export.Consents = append(export.Consents, ec)
+	}
+
+	entries, err := cs.AuditEntries(ctx, subject)
+	if err != nil {
+		return export, err
+	}
+	for _, e := range entries {
+		ee := ExportedAuditEntry{
+			Timestamp: e.Timestamp,
+			Action:    e.Action,
+			Actor:     e.Actor,
+		}
+		if e.Details != "" {
+			ee.Details = json.RawMessage(e.Details)
+		}
+		export.AuditEntries = append(export.AuditEntries, ee)
+	}
+
+	err = writeAuditEntry(cs.db(ctx), AuditActionSubjectExport, exportedBy, &subject, map[string]int{"consents": len(export.Consents)})
+
+	return export, err
+}
+
+// isLatest returns true if the record at index i is the latest version of its chain. records must be ordered by uuid and version.
+func isLatest(records []ConsentRecord, i int) bool {
+	return i == len(records)-1 || records[i+1].UUID != records[i].UUID
+}
+
+func exportRecord(r ConsentRecord, latest bool, now time.Time) ExportedRecord {
+	er := ExportedRecord{
+		Hash:          r.Hash,
+		PreviousHash:  r.PreviousHash,
+		Version:       r.Version,
+		ValidFrom:     r.ValidFrom,
+		ValidTo:       r.ValidTo,
+		DataClasses:   []string{},
+		DeletedAt:     r.DeletedAt,
+		DeletedReason: r.DeletedReason,
+		DeletedBy:     r.DeletedBy,
+	}
+
+	for _, dc := range r.DataClasses {
+		er.DataClasses = append(er.DataClasses, dc.Code)
+	}
+
+	er.Active = latest && !r.IsDeleted() && !r.ValidFrom.After(now) && (r.ValidTo == nil || r.ValidTo.After(now))
+
+	return er
+}
@@ -0,0 +1,108 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_ExportSubject(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	active := patientConsent()
+	expired := expiredPatientConsent("subject", "custodian2", "resource", day)
+	other := expiredPatientConsent("other", "custodian", "resource", day)
+	if err := client.RecordConsent(context.TODO(), []PatientConsent{active[0], expired, other}); err != nil {
+		t.Fatal(err)
+	}
+
+	// add a version and delete it, so the chain has an active first version and a deleted second version
+	update := active[0]
+	update.Records = []ConsentRecord{{
+		ValidFrom:    time.Now(),
+		Hash:         random.String(8),
+		PreviousHash: &active[0].Records[0].Hash,
+		DataClasses:  []DataClass{{Code: "resource2"}},
+	}}
+	if err := client.RecordConsent(context.TODO(), []PatientConsent{update}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteConsentRecordByHash(context.TODO(), update.Records[0].Hash, "mistake", "gateway"); err != nil {
+		t.Fatal(err)
+	}
+
+	export, err := client.ExportSubject(context.TODO(), "subject", "dpo")
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("contains all consents of the subject", func(t *testing.T) {
+		assert.Equal(t, "subject", export.Subject)
+		assert.Len(t, export.Consents, 2)
+	})
+
+	t.Run("contains every version including tombstones", func(t *testing.T) {
+		var records []ExportedRecord
+		for _, c := range export.Consents {
+			if c.ID == active[0].ID {
+				records = c.Records
+			}
+		}
+
+		if assert.Len(t, records, 2) {
+			assert.Equal(t, uint(1), records[0].Version)
+			assert.False(t, records[0].Active)
+			assert.Equal(t, uint(2), records[1].Version)
+			assert.Equal(t, "mistake", *records[1].DeletedReason)
+			assert.Equal(t, []string{"resource2"}, records[1].DataClasses)
+		}
+	})
+
+	t.Run("expired records are marked inactive", func(t *testing.T) {
+		for _, c := range export.Consents {
+			if c.ID == expired.ID {
+				assert.False(t, c.Records[0].Active)
+			}
+		}
+	})
+
+	t.Run("export is recorded in the audit trail", func(t *testing.T) {
+		entries, _ := client.AuditEntries(context.TODO(), "subject")
+
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, AuditActionSubjectExport, entries[0].Action)
+			assert.Equal(t, "dpo", entries[0].Actor)
+		}
+	})
+
+	t.Run("second export contains the audit entry of the first", func(t *testing.T) {
+		export, err := client.ExportSubject(context.TODO(), "subject", "dpo")
+
+		if assert.NoError(t, err) && assert.Len(t, export.AuditEntries, 1) {
+			assert.JSONEq(t, `{"consents":2}`, string(export.AuditEntries[0].Details))
+		}
+	})
+}
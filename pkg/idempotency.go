@@ -0,0 +1,141 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ErrorIdempotencyKeyReused is returned when an idempotency key is sent again with a different request
+var ErrorIdempotencyKeyReused = errors.New("idempotency key has been used for a different request")
+
+// ErrorIdempotencyKeyInUse is returned by a write when a concurrent request with the same idempotency key was recorded first.
+// The write is rolled back, a retry gets the response of the other request.
+var ErrorIdempotencyKeyInUse = errors.New("idempotency key is used by a concurrent request")
+
+// IdempotentResponse is the outcome of a write request that was sent with an idempotency key.
+// A retry of the request with the same key gets the same response without recording anything again.
+// Keys are scoped to the caller, the fingerprint identifies the request that was sent with the key.
+// Body holds the result of the write as JSON, it's empty for writes without a result.
+type IdempotentResponse struct {
+	Key         string    `gorm:"primary_key"`
+	Caller      string    `gorm:"primary_key"`
+	Fingerprint string    `gorm:"not null"`
+	Status      int       `gorm:"not null"`
+	Body        string    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the SQL table for this type
+func (IdempotentResponse) TableName() string {
+	return "idempotency_key"
+}
+
+type idempotentResponseContextKey struct{}
+
+// WithIdempotentResponse returns a context for a write request sent with an idempotency key. The write saves the response
+// in its own transaction, so the key is only kept when the write is recorded and a write is never recorded without its key.
+func WithIdempotentResponse(ctx context.Context, response IdempotentResponse) context.Context {
+	return context.WithValue(ctx, idempotentResponseContextKey{}, response)
+}
+
+// idempotentResponse returns the response set by WithIdempotentResponse, false when the context has none
+func idempotentResponse(ctx context.Context) (IdempotentResponse, bool) {
+	response, ok := ctx.Value(idempotentResponseContextKey{}).(IdempotentResponse)
+	return response, ok
+}
+
+// FindIdempotentResponse returns the response to an earlier request with the key, nil when the key is unknown or has expired.
+// ErrorIdempotencyKeyReused is returned when the earlier request has a different fingerprint.
+func (cs *ConsentStore) FindIdempotentResponse(ctx context.Context, caller string, key string, fingerprint string) (*IdempotentResponse, error) {
+	return findIdempotentResponse(cs.db(ctx).Debug(), caller, key, fingerprint, time.Now().Add(-cs.idempotencyKeyRetention))
+}
+
+func findIdempotentResponse(db *gorm.DB, caller string, key string, fingerprint string, expiry time.Time) (*IdempotentResponse, error) {
+	var response IdempotentResponse
+	err := db.Where("key = ? AND caller = ? AND julianday(created_at) >= julianday(?)", key, caller, expiry).First(&response).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Fingerprint != fingerprint {
+		return nil, ErrorIdempotencyKeyReused
+	}
+	return &response, nil
+}
+
+// saveIdempotentResponse stores the response of the context, if it has one, in the transaction of the write and removes the expired keys.
+// The result of the write is stored as the body of the response. When a concurrent request with the same key was saved first,
+// ErrorIdempotencyKeyInUse or ErrorIdempotencyKeyReused is returned and the write must be rolled back.
+func (cs *ConsentStore) saveIdempotentResponse(ctx context.Context, tx *gorm.DB, result interface{}) error {
+	response, ok := idempotentResponse(ctx)
+	if !ok {
+		return nil
+	}
+	response.CreatedAt = time.Now()
+	expiry := response.CreatedAt.Add(-cs.idempotencyKeyRetention)
+
+	if result != nil {
+		body, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		response.Body = string(body)
+	}
+
+	if err := tx.Where("julianday(created_at) < julianday(?)", expiry).Delete(&IdempotentResponse{}).Error; err != nil {
+		return err
+	}
+
+	existing, err := findIdempotentResponse(tx, response.Caller, response.Key, response.Fingerprint, expiry)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrorIdempotencyKeyInUse
+	}
+
+	return tx.Create(&response).Error
+}
+
+// IdempotencyKeyRetentionPeriod returns the period a response is kept for retries with the same idempotency key, the default is used when it's not configured
+func (c ConsentStoreConfig) IdempotencyKeyRetentionPeriod() (time.Duration, error) {
+	retention := c.IdempotencyKeyRetention
+	if retention == "" {
+		retention = ConfigIdempotencyKeyRetentionDefault
+	}
+
+	d, err := ParseDuration(retention)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ConfigIdempotencyKeyRetention, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid %s: must be positive", ConfigIdempotencyKeyRetention)
+	}
+	return d, nil
+}
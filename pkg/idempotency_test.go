@@ -0,0 +1,190 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_IdempotentResponse(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	save := func(key string, caller string, fingerprint string, status int) error {
+		ctx := WithIdempotentResponse(context.TODO(), IdempotentResponse{Key: key, Caller: caller, Fingerprint: fingerprint, Status: status})
+		tx := client.Db.Begin()
+		if err := client.saveIdempotentResponse(ctx, tx, nil); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
+	}
+	if err := save("key", "caller", "fingerprint", 201); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		r, err := client.FindIdempotentResponse(context.TODO(), "caller", "other", "fingerprint")
+
+		assert.NoError(t, err)
+		assert.Nil(t, r)
+	})
+
+	t.Run("same request", func(t *testing.T) {
+		r, err := client.FindIdempotentResponse(context.TODO(), "caller", "key", "fingerprint")
+
+		if assert.NoError(t, err) && assert.NotNil(t, r) {
+			assert.Equal(t, 201, r.Status)
+		}
+	})
+
+	t.Run("different request", func(t *testing.T) {
+		_, err := client.FindIdempotentResponse(context.TODO(), "caller", "key", "other")
+
+		assert.True(t, errors.Is(err, ErrorIdempotencyKeyReused))
+	})
+
+	t.Run("keys are scoped to the caller", func(t *testing.T) {
+		r, err := client.FindIdempotentResponse(context.TODO(), "other", "key", "other")
+
+		assert.NoError(t, err)
+		assert.Nil(t, r)
+	})
+
+	t.Run("response of the first request is kept", func(t *testing.T) {
+		assert.Equal(t, ErrorIdempotencyKeyInUse, save("key", "caller", "fingerprint", 202))
+		assert.Equal(t, ErrorIdempotencyKeyReused, save("key", "caller", "other", 202))
+
+		r, err := client.FindIdempotentResponse(context.TODO(), "caller", "key", "fingerprint")
+
+		if assert.NoError(t, err) && assert.NotNil(t, r) {
+			assert.Equal(t, 201, r.Status)
+		}
+	})
+
+	t.Run("expired keys are ignored and removed", func(t *testing.T) {
+		save("expired", "caller", "fingerprint", 201)
+		if err := client.Db.Model(&IdempotentResponse{}).Where("key = ?", "expired").Update("created_at", time.Now().Add(-25*time.Hour)).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := client.FindIdempotentResponse(context.TODO(), "caller", "expired", "other")
+
+		assert.NoError(t, err)
+		assert.Nil(t, r)
+
+		assert.NoError(t, save("new", "caller", "fingerprint", 201))
+		var count int
+		client.Db.Model(&IdempotentResponse{}).Where("key = ?", "expired").Count(&count)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("expiry compares the time instead of its text", func(t *testing.T) {
+		save("local", "caller", "fingerprint", 201)
+		// a time in another zone sorts before the expiry as text, while it's after it
+		createdAt := time.Now().Add(-23 * time.Hour).In(time.FixedZone("UTC-12", -12*60*60))
+		if err := client.Db.Model(&IdempotentResponse{}).Where("key = ?", "local").Update("created_at", createdAt).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := client.FindIdempotentResponse(context.TODO(), "caller", "local", "fingerprint")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, r)
+	})
+}
+
+func TestConsentStore_saveIdempotentResponse(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	response := IdempotentResponse{Key: "key", Caller: "caller", Fingerprint: "fingerprint", Status: 201}
+	ctx := WithIdempotentResponse(context.TODO(), response)
+
+	t.Run("key is saved with the write", func(t *testing.T) {
+		err := client.RecordConsent(ctx, []PatientConsent{endingPatientConsent("subject", "actor", day)})
+
+		if assert.NoError(t, err) {
+			r, _ := client.FindIdempotentResponse(context.TODO(), "caller", "key", "fingerprint")
+			assert.NotNil(t, r)
+		}
+	})
+
+	t.Run("write is rolled back when the key was saved by a concurrent request", func(t *testing.T) {
+		pc := endingPatientConsent("other", "actor", day)
+
+		err := client.RecordConsent(ctx, []PatientConsent{pc})
+
+		assert.Equal(t, ErrorIdempotencyKeyInUse, err)
+		_, err = client.FindConsentRecordByHash(context.TODO(), pc.Records[0].Hash, false)
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("key isn't saved when the write fails", func(t *testing.T) {
+		ctx := WithIdempotentResponse(context.TODO(), IdempotentResponse{Key: "failed", Caller: "caller", Fingerprint: "fingerprint", Status: 201})
+		pc := endingPatientConsent("failed", "actor", day)
+		unknown := "unknown"
+		pc.Records[0].PreviousHash = &unknown
+
+		assert.Error(t, client.RecordConsent(ctx, []PatientConsent{pc}))
+
+		r, _ := client.FindIdempotentResponse(context.TODO(), "caller", "failed", "fingerprint")
+		assert.Nil(t, r)
+	})
+
+	t.Run("result is saved as body", func(t *testing.T) {
+		ctx := WithIdempotentResponse(context.TODO(), IdempotentResponse{Key: "erase", Caller: "caller", Fingerprint: "fingerprint", Status: 200})
+
+		certificate, err := client.EraseSubject(ctx, "subject", "dpo")
+
+		if assert.NoError(t, err) {
+			r, _ := client.FindIdempotentResponse(context.TODO(), "caller", "erase", "fingerprint")
+			if assert.NotNil(t, r) {
+				assert.Contains(t, r.Body, certificate.Pseudonym)
+			}
+		}
+	})
+}
+
+func TestConsentStoreConfig_IdempotencyKeyRetentionPeriod(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		d, err := ConsentStoreConfig{}.IdempotencyKeyRetentionPeriod()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, d)
+	})
+
+	t.Run("days", func(t *testing.T) {
+		d, err := ConsentStoreConfig{IdempotencyKeyRetention: "2d"}.IdempotencyKeyRetentionPeriod()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 48*time.Hour, d)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ConsentStoreConfig{IdempotencyKeyRetention: "0"}.IdempotencyKeyRetentionPeriod()
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,249 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ImportLine is a single consent read from an import file. Err is set when the line couldn't be parsed or is invalid, it's reported and skipped.
+type ImportLine struct {
+	Line    int
+	Consent PatientConsent
+	Err     error
+}
+
+// ImportError is the error of a single line of an import
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportReport summarizes an import. In a dry run, Recorded holds the number of consents that would have been recorded.
+type ImportReport struct {
+	DryRun   bool          `json:"dryRun"`
+	Lines    int           `json:"lines"`
+	Recorded int           `json:"recorded"`
+	Failed   int           `json:"failed"`
+	Errors   []ImportError `json:"errors"`
+}
+
+func (r *ImportReport) fail(line int, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportError{Line: line, Error: err.Error()})
+}
+
+// ValidateImport checks if the consent holds everything needed to record it
+func ValidateImport(pc PatientConsent) error {
+	switch {
+	case pc.ID == "":
+		return errors.New("missing id")
+	case pc.Subject == "":
+		return errors.New("missing subject")
+	case pc.Custodian == "":
+		return errors.New("missing custodian")
+	case pc.Actor == "":
+		return errors.New("missing actor")
+	case len(pc.Records) == 0:
+		return errors.New("missing records")
+	}
+
+	for _, r := range pc.Records {
+		switch {
+		case r.Hash == "":
+			return errors.New("missing recordHash")
+		case r.ValidFrom.IsZero():
+			return errors.New("missing validFrom")
+		case len(r.DataClasses) == 0:
+			return errors.New("missing dataClasses")
+		}
+	}
+
+	return nil
+}
+
+// ImportConsent records the consent of the given lines in batches, every batch is recorded in its own transaction.
+// When a line of a batch fails, the whole batch is rolled back and every line of the batch is reported. A dry run records all batches in a single
+// transaction that is rolled back at the end, so the lines are validated against the current state of the store without writing anything.
+// An error is only returned when the import couldn't continue, the report then holds the results so far.
+// A request with an idempotency key in the context gets the report as the body of its response, it's saved with the last batch.
+func (cs *ConsentStore) ImportConsent(ctx context.Context, lines []ImportLine, batchSize int, dryRun bool) (report ImportReport, err error) {
+	ctx, done := cs.startOperation(ctx, operationImportConsent)
+	defer done(&err)
+
+	if batchSize <= 0 {
+		batchSize = ConfigImportBatchSizeDefault
+	}
+
+	report = ImportReport{
+		DryRun: dryRun,
+		Lines:  len(lines),
+		Errors: []ImportError{},
+	}
+
+	// the last valid line closes the last batch
+	errs := make([]error, len(lines))
+	last := -1
+	for i, l := range lines {
+		if errs[i] = l.Err; errs[i] == nil {
+			errs[i] = ValidateImport(l.Consent)
+		}
+		if errs[i] == nil {
+			last = i
+		}
+	}
+
+	var dryRunTx *gorm.DB
+	if dryRun {
+		dryRunTx = cs.db(ctx).Begin().Debug()
+		if err := dryRunTx.Error; err != nil {
+			return report, err
+		}
+	}
+
+	var batch []ImportLine
+	for i, l := range lines {
+		if errs[i] != nil {
+			report.fail(l.Line, errs[i])
+			continue
+		}
+
+		batch = append(batch, l)
+		if len(batch) < batchSize && i != last {
+			continue
+		}
+
+		if dryRun {
+			_, err = cs.importBatch(dryRunTx, batch, &report)
+		} else {
+			err = cs.commitBatch(ctx, batch, i == last, &report)
+		}
+		if err != nil {
+			if dryRun {
+				dryRunTx.Rollback()
+			}
+			return report, err
+		}
+		batch = nil
+	}
+
+	if dryRun {
+		if err := dryRunTx.Rollback().Error; err != nil {
+			return report, err
+		}
+	}
+	if dryRun || last == -1 {
+		// nothing has been recorded, so the response is saved on its own
+		return report, cs.saveImportResponse(ctx, report)
+	}
+	return report, nil
+}
+
+// commitBatch records the batch in its own transaction. The last batch is committed together with the response of a request with an idempotency key.
+// The report is left as it was when the transaction isn't committed.
+func (cs *ConsentStore) commitBatch(ctx context.Context, batch []ImportLine, last bool, report *ImportReport) error {
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	before := *report
+	rollback := func(err error) error {
+		tx.Rollback()
+		*report = before
+		return err
+	}
+
+	events, err := cs.importBatch(tx, batch, report)
+	if err != nil {
+		return rollback(err)
+	}
+
+	if last {
+		if err := cs.saveIdempotentResponse(ctx, tx, *report); err != nil {
+			return rollback(err)
+		}
+	}
+
+	if err := cs.writeOutbox(tx, events); err != nil {
+		return rollback(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		*report = before
+		return err
+	}
+
+	cs.publish(events)
+	return nil
+}
+
+// importBatch records the batch in the transaction and adds the results to the report. When a line fails, the transaction is rolled back
+// to the savepoint before the batch, so it can still be used for the next batches.
+func (cs *ConsentStore) importBatch(tx *gorm.DB, batch []ImportLine, report *ImportReport) ([]Event, error) {
+	if err := tx.Exec("SAVEPOINT import_batch").Error; err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	now := time.Now()
+
+	for _, l := range batch {
+		e, err := cs.recordPatientConsent(tx, l.Consent, now)
+		if err != nil {
+			if err := tx.Exec("ROLLBACK TO import_batch").Error; err != nil {
+				return nil, err
+			}
+			for _, other := range batch {
+				if other.Line == l.Line {
+					report.fail(l.Line, err)
+				} else {
+					report.fail(other.Line, fmt.Errorf("batch rolled back because of line %d", l.Line))
+				}
+			}
+			return nil, tx.Exec("RELEASE import_batch").Error
+		}
+		events = append(events, e...)
+	}
+
+	report.Recorded += len(batch)
+	return events, tx.Exec("RELEASE import_batch").Error
+}
+
+// saveImportResponse saves the report as response of a request with an idempotency key, for an import that didn't record anything
+func (cs *ConsentStore) saveImportResponse(ctx context.Context, report ImportReport) error {
+	if _, ok := idempotentResponse(ctx); !ok {
+		return nil
+	}
+
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if err := cs.saveIdempotentResponse(ctx, tx, report); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
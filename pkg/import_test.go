@@ -0,0 +1,214 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func importLines(consent ...PatientConsent) []ImportLine {
+	var lines []ImportLine
+	for i, pc := range consent {
+		lines = append(lines, ImportLine{Line: i + 1, Consent: pc})
+	}
+	return lines
+}
+
+func TestValidateImport(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, ValidateImport(endingPatientConsent("subject", "actor", day)))
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		pc := endingPatientConsent("", "actor", day)
+
+		assert.EqualError(t, ValidateImport(pc), "missing subject")
+	})
+
+	t.Run("missing dataClasses", func(t *testing.T) {
+		pc := endingPatientConsent("subject", "actor", day)
+		pc.Records[0].DataClasses = nil
+
+		assert.EqualError(t, ValidateImport(pc), "missing dataClasses")
+	})
+}
+
+func TestConsentStore_ImportConsent(t *testing.T) {
+	actor := "actor"
+
+	t.Run("records all valid lines", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		lines := importLines(
+			endingPatientConsent("1", "actor", day),
+			endingPatientConsent("", "actor", day),
+			endingPatientConsent("3", "actor", day),
+		)
+		lines = append(lines, ImportLine{Line: 4, Err: errors.New("unparsable")})
+
+		report, err := client.ImportConsent(context.TODO(), lines, 10, false)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 4, report.Lines)
+			assert.Equal(t, 2, report.Recorded)
+			assert.Equal(t, 2, report.Failed)
+			assert.Equal(t, []ImportError{{Line: 2, Error: "missing subject"}, {Line: 4, Error: "unparsable"}}, report.Errors)
+		}
+		consent, _ := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		assert.Len(t, consent, 2)
+	})
+
+	t.Run("failing line rolls back its batch only", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		unknown := random.String(8)
+		failing := endingPatientConsent("2", "actor", day)
+		failing.Records[0].PreviousHash = &unknown
+		lines := importLines(
+			endingPatientConsent("1", "actor", day),
+			failing,
+			endingPatientConsent("3", "actor", day),
+		)
+
+		report, err := client.ImportConsent(context.TODO(), lines, 2, false)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, report.Recorded)
+			assert.Equal(t, 2, report.Failed)
+			assert.Equal(t, []ImportError{
+				{Line: 1, Error: "batch rolled back because of line 2"},
+				{Line: 2, Error: ErrorUnknownPreviousHash.Error()},
+			}, report.Errors)
+		}
+		consent, _ := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		if assert.Len(t, consent, 1) {
+			assert.Equal(t, "3", consent[0].Subject)
+		}
+	})
+
+	t.Run("later lines can refer to earlier lines of the same batch", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		first := endingPatientConsent("subject", "actor", day)
+		second := first
+		validTo := time.Now().Add(2 * day)
+		second.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			ValidTo:      &validTo,
+			Hash:         random.String(8),
+			PreviousHash: &first.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+
+		report, err := client.ImportConsent(context.TODO(), importLines(first, second), 10, false)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, report.Recorded)
+			assert.Empty(t, report.Errors)
+		}
+	})
+
+	t.Run("dry run doesn't write", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		sub, _, _ := client.Events.Subscribe(0)
+
+		report, err := client.ImportConsent(context.TODO(), importLines(endingPatientConsent("1", "actor", day)), 10, true)
+
+		if assert.NoError(t, err) {
+			assert.True(t, report.DryRun)
+			assert.Equal(t, 1, report.Recorded)
+		}
+		consent, _ := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		assert.Empty(t, consent)
+		assert.Empty(t, sub.C)
+	})
+	t.Run("dry run lines can refer to earlier batches", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		first := endingPatientConsent("subject", "actor", day)
+		second := first
+		validTo := time.Now().Add(2 * day)
+		second.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			ValidTo:      &validTo,
+			Hash:         random.String(8),
+			PreviousHash: &first.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+
+		report, err := client.ImportConsent(context.TODO(), importLines(first, second), 1, true)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, report.Recorded)
+			assert.Empty(t, report.Errors)
+		}
+		consent, _ := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		assert.Empty(t, consent)
+	})
+
+	t.Run("dry run keeps earlier batches when a batch fails", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		first := endingPatientConsent("subject", "actor", day)
+		unknown := random.String(8)
+		failing := endingPatientConsent("failing", "actor", day)
+		failing.Records[0].PreviousHash = &unknown
+		third := first
+		validTo := time.Now().Add(2 * day)
+		third.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			ValidTo:      &validTo,
+			Hash:         random.String(8),
+			PreviousHash: &first.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+
+		report, err := client.ImportConsent(context.TODO(), importLines(first, failing, third), 1, true)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, report.Recorded)
+			assert.Equal(t, []ImportError{{Line: 2, Error: ErrorUnknownPreviousHash.Error()}}, report.Errors)
+		}
+	})
+
+	t.Run("response is saved with the last batch", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		ctx := WithIdempotentResponse(context.TODO(), IdempotentResponse{Key: "import", Caller: "caller", Fingerprint: "fingerprint", Status: 200})
+		// a concurrent request saved the key first
+		client.Db.Create(&IdempotentResponse{Key: "import", Caller: "caller", Fingerprint: "fingerprint", Status: 200, CreatedAt: time.Now()})
+		lines := importLines(endingPatientConsent("1", "actor", day), endingPatientConsent("2", "actor", day))
+
+		report, err := client.ImportConsent(ctx, lines, 1, false)
+
+		assert.Equal(t, ErrorIdempotencyKeyInUse, err)
+		assert.Equal(t, 1, report.Recorded)
+		consent, _ := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+		if assert.Len(t, consent, 1) {
+			assert.Equal(t, "1", consent[0].Subject)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"sync"
+	"time"
+)
+
+// job runs a function periodically in the background until it's stopped
+type job struct {
+	stop    chan struct{}
+	trigger chan struct{}
+	done    sync.WaitGroup
+}
+
+// startJob runs fn every interval, or earlier when the job is triggered
+func startJob(interval time.Duration, fn func()) *job {
+	j := &job{
+		stop:    make(chan struct{}),
+		trigger: make(chan struct{}, 1),
+	}
+	j.done.Add(1)
+
+	go func() {
+		defer j.done.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-j.stop:
+				return
+			case <-ticker.C:
+				fn()
+			case <-j.trigger:
+				fn()
+			}
+		}
+	}()
+
+	return j
+}
+
+// Trigger makes the job run as soon as possible, it doesn't block
+func (j *job) Trigger() {
+	select {
+	case j.trigger <- struct{}{}:
+	default:
+		// already triggered
+	}
+}
+
+// Stop stops the job and waits for a running iteration to finish
+func (j *job) Stop() {
+	close(j.stop)
+	j.done.Wait()
+}
@@ -0,0 +1,274 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	core "github.com/nuts-foundation/nuts-go-core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsPrefix is the prefix of all consent store metrics
+const metricsPrefix = core.NutsMetricsPrefix + "consent_store_"
+
+// maxCustodianLabels is the number of distinct custodians used as label value, others are counted as otherCustodian.
+// It keeps the number of series bounded, since the custodian of a consent check is chosen by the caller.
+const maxCustodianLabels = 100
+
+// otherCustodian is the label value for custodians beyond maxCustodianLabels
+const otherCustodian = "other"
+
+// the operations of the ConsentStoreClient and the bulk import, used as label value
+const (
+	operationConsentAuth               = "ConsentAuth"
+	operationRecordConsent             = "RecordConsent"
+	operationQueryConsent              = "QueryConsent"
+	operationDeleteConsentRecordByHash = "DeleteConsentRecordByHash"
+	operationFindConsentRecordByHash   = "FindConsentRecordByHash"
+	operationEraseSubject              = "EraseSubject"
+	operationImportConsent             = "ImportConsent"
+)
+
+// the results of an operation, used as label value
+const (
+	resultSuccess = "success"
+	// resultRejected is the result of operations that failed because of the request, like an unknown hash or a conflicting append
+	resultRejected = "rejected"
+	resultError    = "error"
+)
+
+// rejections are the errors caused by the request rather than the store
+var rejections = []error{ErrorNotFound, ErrorConsentRecordNotLatest, ErrorInvalidValidTo, ErrorConflict}
+
+// Metrics holds the prometheus metrics of the consent store. The gauges are read from the database when the metrics are collected.
+// All methods can be called on a nil Metrics, which doesn't record anything.
+type Metrics struct {
+	cs *ConsentStore
+
+	operations *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+	dbErrors   *prometheus.CounterVec
+	checks     *prometheus.CounterVec
+	results    prometheus.Histogram
+
+	patientConsents *prometheus.Desc
+	activeRecords   *prometheus.Desc
+	chains          *prometheus.Desc
+	dbSize          *prometheus.Desc
+
+	custodianMutex sync.Mutex
+	custodians     map[string]bool
+}
+
+// NewMetrics creates the metrics of the consent store, they still have to be registered
+func NewMetrics(cs *ConsentStore) *Metrics {
+	return &Metrics{
+		cs: cs,
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "operations_total",
+			Help: "Number of consent store operations by result: success, rejected because of the request or error",
+		}, []string{"operation", "result"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "operation_duration_seconds",
+			Help:    "Duration of consent store operations",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation"}),
+		dbErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "db_errors_total",
+			Help: "Number of consent store operations that failed with a database error",
+		}, []string{"operation"}),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "checks_total",
+			Help: "Number of consent checks by outcome: granted or denied",
+		}, []string{"custodian", "outcome"}),
+		results: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "query_results",
+			Help:    "Number of patient consents returned by a consent query",
+			Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		}),
+		patientConsents: prometheus.NewDesc(metricsPrefix+"patient_consents", "Number of patient consents", []string{"custodian"}, nil),
+		activeRecords:   prometheus.NewDesc(metricsPrefix+"active_records", "Number of consent records that are the latest of their chain, not deleted and valid now", []string{"custodian"}, nil),
+		chains:          prometheus.NewDesc(metricsPrefix+"chains", "Number of consent record chains with records that aren't deleted", []string{"custodian"}, nil),
+		dbSize:          prometheus.NewDesc(metricsPrefix+"db_size_bytes", "Size of the database", nil, nil),
+		custodians:      map[string]bool{},
+	}
+}
+
+// Register registers the metrics, registering them again is not an error
+func (m *Metrics) Register(registerer prometheus.Registerer) error {
+	if err := registerer.Register(m); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe implements prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.operations.Describe(ch)
+	m.durations.Describe(ch)
+	m.dbErrors.Describe(ch)
+	m.checks.Describe(ch)
+	m.results.Describe(ch)
+	ch <- m.patientConsents
+	ch <- m.activeRecords
+	ch <- m.chains
+	ch <- m.dbSize
+}
+
+// Collect implements prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.operations.Collect(ch)
+	m.durations.Collect(ch)
+	m.dbErrors.Collect(ch)
+	m.checks.Collect(ch)
+	m.results.Collect(ch)
+
+	// the gauges are only available when the store has a database
+	if m.cs == nil || m.cs.Db == nil {
+		return
+	}
+
+	now := time.Now()
+	for _, g := range []struct {
+		desc  *prometheus.Desc
+		query string
+		args  []interface{}
+	}{
+		{m.patientConsents, "SELECT custodian, COUNT(*) FROM patient_consent GROUP BY custodian", nil},
+		{m.chains, `SELECT pc.custodian, COUNT(DISTINCT cr.uuid) FROM consent_record cr JOIN patient_consent pc ON cr.patient_consent_id = pc.id
+			WHERE cr.deleted_at IS NULL GROUP BY pc.custodian`, nil},
+		// the chain is looked up by patient consent and uuid, so the latest version is found with the uniq_record_version index
+		{m.activeRecords, `SELECT pc.custodian, COUNT(*) FROM consent_record cr JOIN patient_consent pc ON cr.patient_consent_id = pc.id
+			WHERE cr.deleted_at IS NULL
+			AND cr.version = (SELECT MAX(latest.version) FROM consent_record latest
+				WHERE latest.patient_consent_id = cr.patient_consent_id AND latest.uuid = cr.uuid AND latest.deleted_at IS NULL)
+			AND julianday(cr.valid_from) <= julianday(?) AND (cr.valid_to IS NULL OR julianday(cr.valid_to) > julianday(?))
+			GROUP BY pc.custodian`, []interface{}{now, now}},
+	} {
+		counts, err := m.countByCustodian(g.query, g.args...)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(g.desc, err)
+			continue
+		}
+		for custodian, count := range counts {
+			ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, count, custodian)
+		}
+	}
+
+	var pageCount, pageSize float64
+	db := m.cs.Db.DB()
+	if err := db.QueryRow("PRAGMA page_count").Scan(&pageCount); err != nil {
+		ch <- prometheus.NewInvalidMetric(m.dbSize, err)
+		return
+	}
+	if err := db.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		ch <- prometheus.NewInvalidMetric(m.dbSize, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(m.dbSize, prometheus.GaugeValue, pageCount*pageSize)
+}
+
+// countByCustodian runs a query returning a custodian and a count per row, the counts of custodians that don't get their own label are added up
+func (m *Metrics) countByCustodian(query string, args ...interface{}) (map[string]float64, error) {
+	rows, err := m.cs.Db.DB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]float64{}
+	for rows.Next() {
+		var (
+			custodian string
+			count     float64
+		)
+		if err := rows.Scan(&custodian, &count); err != nil {
+			return nil, err
+		}
+		counts[m.custodianLabel(custodian)] += count
+	}
+	return counts, rows.Err()
+}
+
+// custodianLabel returns the label value for the custodian, otherCustodian when the maximum number of custodians has been reached
+func (m *Metrics) custodianLabel(custodian string) string {
+	m.custodianMutex.Lock()
+	defer m.custodianMutex.Unlock()
+
+	if m.custodians[custodian] {
+		return custodian
+	}
+	if len(m.custodians) >= maxCustodianLabels {
+		return otherCustodian
+	}
+	m.custodians[custodian] = true
+	return custodian
+}
+
+// observe records the result and duration of an operation that started at the given time, it's meant to be deferred
+func (m *Metrics) observe(operation string, start time.Time, err *error) {
+	if m == nil {
+		return
+	}
+
+	result := resultSuccess
+	if *err != nil {
+		result = resultError
+		for _, r := range rejections {
+			if errors.Is(*err, r) {
+				result = resultRejected
+				break
+			}
+		}
+	}
+
+	m.operations.WithLabelValues(operation, result).Inc()
+	m.durations.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if result == resultError {
+		m.dbErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// observeCheck counts the outcome of a consent check
+func (m *Metrics) observeCheck(custodian string, granted bool) {
+	if m == nil {
+		return
+	}
+
+	outcome := "denied"
+	if granted {
+		outcome = "granted"
+	}
+	m.checks.WithLabelValues(m.custodianLabel(custodian), outcome).Inc()
+}
+
+// observeQuery records the number of patient consents returned by a query
+func (m *Metrics) observeQuery(results int) {
+	if m == nil {
+		return
+	}
+
+	m.results.Observe(float64(results))
+}
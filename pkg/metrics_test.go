@@ -0,0 +1,171 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_operations(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	m := client.Metrics
+
+	pc := endingPatientConsent("subject", "actor", day)
+	if err := client.RecordConsent(context.TODO(), []PatientConsent{pc}); err != nil {
+		t.Fatal(err)
+	}
+	client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+	client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "other", nil)
+	client.QueryConsent(context.TODO(), &pc.Actor, nil, nil, nil, false)
+	client.FindConsentRecordByHash(context.TODO(), "unknown", false)
+
+	t.Run("operations are counted by result", func(t *testing.T) {
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.operations.WithLabelValues(operationRecordConsent, resultSuccess)))
+		assert.Equal(t, float64(2), testutil.ToFloat64(m.operations.WithLabelValues(operationConsentAuth, resultSuccess)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.operations.WithLabelValues(operationFindConsentRecordByHash, resultRejected)))
+		assert.Equal(t, float64(0), testutil.ToFloat64(m.dbErrors.WithLabelValues(operationFindConsentRecordByHash)))
+	})
+
+	t.Run("check outcomes are counted by custodian", func(t *testing.T) {
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.checks.WithLabelValues("custodian", "granted")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.checks.WithLabelValues("custodian", "denied")))
+	})
+
+	t.Run("query result sizes", func(t *testing.T) {
+		expected := `
+# HELP nuts_consent_store_query_results Number of patient consents returned by a consent query
+# TYPE nuts_consent_store_query_results histogram
+nuts_consent_store_query_results_bucket{le="0"} 0
+nuts_consent_store_query_results_bucket{le="1"} 1
+nuts_consent_store_query_results_bucket{le="2"} 1
+nuts_consent_store_query_results_bucket{le="5"} 1
+nuts_consent_store_query_results_bucket{le="10"} 1
+nuts_consent_store_query_results_bucket{le="20"} 1
+nuts_consent_store_query_results_bucket{le="50"} 1
+nuts_consent_store_query_results_bucket{le="100"} 1
+nuts_consent_store_query_results_bucket{le="200"} 1
+nuts_consent_store_query_results_bucket{le="500"} 1
+nuts_consent_store_query_results_bucket{le="1000"} 1
+nuts_consent_store_query_results_bucket{le="+Inf"} 1
+nuts_consent_store_query_results_sum 1
+nuts_consent_store_query_results_count 1
+`
+		assert.NoError(t, testutil.CollectAndCompare(m.results, strings.NewReader(expected)))
+	})
+
+	t.Run("database errors", func(t *testing.T) {
+		err := fmt.Errorf("database is locked")
+		m.observe(operationRecordConsent, time.Now(), &err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.operations.WithLabelValues(operationRecordConsent, resultError)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.dbErrors.WithLabelValues(operationRecordConsent)))
+	})
+}
+
+func TestMetrics_Collect(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	v1 := endingPatientConsent("subject", "actor", day)
+	v2 := endingPatientConsent("subject", "actor", day)
+	v2.ID = v1.ID
+	v2.Records[0].PreviousHash = &v1.Records[0].Hash
+	deleted := endingPatientConsent("deleted", "actor", day)
+	other := endingPatientConsent("subject", "actor", 2*day)
+	other.Custodian = "other custodian"
+	other.Records[0].ValidFrom = time.Now().Add(day)
+	for _, pc := range [][]PatientConsent{{v1, deleted, other}, {v2}} {
+		if err := client.RecordConsent(context.TODO(), pc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.DeleteConsentRecordByHash(context.TODO(), deleted.Records[0].Hash, "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP nuts_consent_store_active_records Number of consent records that are the latest of their chain, not deleted and valid now
+# TYPE nuts_consent_store_active_records gauge
+nuts_consent_store_active_records{custodian="custodian"} 1
+# HELP nuts_consent_store_chains Number of consent record chains with records that aren't deleted
+# TYPE nuts_consent_store_chains gauge
+nuts_consent_store_chains{custodian="custodian"} 1
+nuts_consent_store_chains{custodian="other custodian"} 1
+# HELP nuts_consent_store_patient_consents Number of patient consents
+# TYPE nuts_consent_store_patient_consents gauge
+nuts_consent_store_patient_consents{custodian="custodian"} 2
+nuts_consent_store_patient_consents{custodian="other custodian"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(client.Metrics, strings.NewReader(expected),
+		metricsPrefix+"active_records", metricsPrefix+"chains", metricsPrefix+"patient_consents"))
+
+	t.Run("database size", func(t *testing.T) {
+		registry := prometheus.NewPedanticRegistry()
+		if !assert.NoError(t, client.Metrics.Register(registry)) {
+			return
+		}
+
+		families, err := registry.Gather()
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, f := range families {
+			if f.GetName() == metricsPrefix+"db_size_bytes" {
+				assert.True(t, f.GetMetric()[0].GetGauge().GetValue() > 0)
+				return
+			}
+		}
+		t.Error("db_size_bytes not collected")
+	})
+}
+
+func TestMetrics_Register(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	assert.NoError(t, NewMetrics(nil).Register(registry))
+	assert.NoError(t, NewMetrics(nil).Register(registry))
+}
+
+func TestMetrics_custodianLabel(t *testing.T) {
+	m := NewMetrics(nil)
+	for i := 0; i < maxCustodianLabels; i++ {
+		assert.Equal(t, fmt.Sprintf("custodian %d", i), m.custodianLabel(fmt.Sprintf("custodian %d", i)))
+	}
+
+	assert.Equal(t, otherCustodian, m.custodianLabel("one too many"))
+	assert.Equal(t, "custodian 0", m.custodianLabel("custodian 0"))
+}
+
+func TestMetrics_nil(t *testing.T) {
+	var m *Metrics
+	err := ErrorNotFound
+
+	m.observe(operationConsentAuth, time.Now(), &err)
+	m.observeCheck("custodian", true)
+	m.observeQuery(1)
+}
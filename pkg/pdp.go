@@ -0,0 +1,180 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Decision is the outcome of an AuthorizationRequest
+type Decision string
+
+const (
+	// DecisionPermit means consent has been given for the request
+	DecisionPermit Decision = "Permit"
+	// DecisionDeny means no consent has been given for the request
+	DecisionDeny Decision = "Deny"
+	// DecisionNotApplicable means the request is not about something consent applies to, like an unsupported action
+	DecisionNotApplicable Decision = "NotApplicable"
+	// DecisionIndeterminate means no decision could be made, the status tells why
+	DecisionIndeterminate Decision = "Indeterminate"
+)
+
+// Attribute IDs of an AuthorizationRequest, per category
+const (
+	// AttributeSubjectID is the subject attribute holding the actor requesting access
+	AttributeSubjectID = "id"
+	// AttributeResourcePatient is the resource attribute holding the subject of the consent
+	AttributeResourcePatient = "patient"
+	// AttributeResourceCustodian is the resource attribute holding the custodian of the data
+	AttributeResourceCustodian = "custodian"
+	// AttributeResourceDataClass is the resource attribute holding the data class of the data
+	AttributeResourceDataClass = "dataClass"
+	// AttributeActionID is the action attribute holding the action
+	AttributeActionID = "id"
+	// AttributeEnvironmentTime is the environment attribute holding the moment, in RFC3339, the decision is made for. Defaults to now.
+	AttributeEnvironmentTime = "time"
+)
+
+// Status codes of an Indeterminate decision, from XACML
+const (
+	// StatusMissingAttribute means a required attribute is missing from the request
+	StatusMissingAttribute = "urn:oasis:names:tc:xacml:1.0:status:missing-attribute"
+	// StatusSyntaxError means an attribute has an invalid value
+	StatusSyntaxError = "urn:oasis:names:tc:xacml:1.0:status:syntax-error"
+	// StatusProcessingError means consent couldn't be checked
+	StatusProcessingError = "urn:oasis:names:tc:xacml:1.0:status:processing-error"
+)
+
+const (
+	// ObligationAudit obliges the enforcement point to record the access in its audit log
+	ObligationAudit = "urn:nuts:consent-store:obligation:audit"
+	// AdviceNoConsent advises the enforcement point why access was denied
+	AdviceNoConsent = "urn:nuts:consent-store:advice:no-consent"
+	// AdviceUnsupportedAction advises the enforcement point that consent doesn't apply to the action
+	AdviceUnsupportedAction = "urn:nuts:consent-store:advice:unsupported-action"
+)
+
+// consentActions are the actions consent applies to, consent only governs reading data
+var consentActions = []string{"read", "search"}
+
+// AuthorizationRequest is an attribute based authorization request, as used by OPA and XACML style gateways.
+// Every category holds attributes by their ID.
+type AuthorizationRequest struct {
+	Subject     map[string]string `json:"subject"`
+	Resource    map[string]string `json:"resource"`
+	Action      map[string]string `json:"action"`
+	Environment map[string]string `json:"environment,omitempty"`
+}
+
+// AuthorizationResponse holds the decision with the obligations the enforcement point must fulfill and advice it may use
+type AuthorizationResponse struct {
+	Decision    Decision          `json:"decision"`
+	Status      *DecisionStatus   `json:"status,omitempty"`
+	Obligations []PolicyDirective `json:"obligations,omitempty"`
+	Advice      []PolicyDirective `json:"advice,omitempty"`
+}
+
+// DecisionStatus tells why a decision is Indeterminate
+type DecisionStatus struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PolicyDirective is an obligation or advice with its attributes
+type PolicyDirective struct {
+	ID         string            `json:"id"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+func indeterminate(code string, format string, args ...interface{}) AuthorizationResponse {
+	return AuthorizationResponse{
+		Decision: DecisionIndeterminate,
+		Status:   &DecisionStatus{Code: code, Message: fmt.Sprintf(format, args...)},
+	}
+}
+
+// Decide answers an authorization request by mapping it onto ConsentAuth. Errors result in an Indeterminate decision.
+// A Permit obliges the enforcement point to audit the access.
+func (cs *ConsentStore) Decide(ctx context.Context, request AuthorizationRequest) AuthorizationResponse {
+	action := request.Action[AttributeActionID]
+	if action == "" {
+		return indeterminate(StatusMissingAttribute, "missing action attribute %s", AttributeActionID)
+	}
+
+	supported := false
+	for _, a := range consentActions {
+		supported = supported || strings.EqualFold(a, action)
+	}
+	if !supported {
+		return AuthorizationResponse{
+			Decision: DecisionNotApplicable,
+			Advice: []PolicyDirective{{
+				ID:         AdviceUnsupportedAction,
+				Attributes: map[string]string{"action": action, "supported": strings.Join(consentActions, ",")},
+			}},
+		}
+	}
+
+	attributes := map[string]string{
+		"actor":     request.Subject[AttributeSubjectID],
+		"patient":   request.Resource[AttributeResourcePatient],
+		"custodian": request.Resource[AttributeResourceCustodian],
+		"dataClass": request.Resource[AttributeResourceDataClass],
+	}
+	for _, a := range []struct{ category, id, value string }{
+		{"subject", AttributeSubjectID, attributes["actor"]},
+		{"resource", AttributeResourcePatient, attributes["patient"]},
+		{"resource", AttributeResourceCustodian, attributes["custodian"]},
+		{"resource", AttributeResourceDataClass, attributes["dataClass"]},
+	} {
+		if a.value == "" {
+			return indeterminate(StatusMissingAttribute, "missing %s attribute %s", a.category, a.id)
+		}
+	}
+
+	checkpoint := time.Now()
+	if t, ok := request.Environment[AttributeEnvironmentTime]; ok {
+		var err error
+		if checkpoint, err = time.Parse(time.RFC3339, t); err != nil {
+			return indeterminate(StatusSyntaxError, "invalid environment attribute %s, required: %s", AttributeEnvironmentTime, time.RFC3339)
+		}
+	}
+	attributes["time"] = checkpoint.Format(time.RFC3339)
+
+	auth, err := cs.ConsentAuth(ctx, attributes["custodian"], attributes["patient"], attributes["actor"], attributes["dataClass"], &checkpoint)
+	if err != nil {
+		return indeterminate(StatusProcessingError, "unable to check consent: %v", err)
+	}
+
+	if !auth {
+		return AuthorizationResponse{
+			Decision: DecisionDeny,
+			Advice:   []PolicyDirective{{ID: AdviceNoConsent, Attributes: attributes}},
+		}
+	}
+
+	return AuthorizationResponse{
+		Decision:    DecisionPermit,
+		Obligations: []PolicyDirective{{ID: ObligationAudit, Attributes: attributes}},
+	}
+}
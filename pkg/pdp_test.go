@@ -0,0 +1,102 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_Decide(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	pc := endingPatientConsent("subject", "actor", day)
+	client.RecordConsent(context.TODO(), []PatientConsent{pc})
+
+	request := func() AuthorizationRequest {
+		return AuthorizationRequest{
+			Subject:  map[string]string{AttributeSubjectID: "actor"},
+			Resource: map[string]string{AttributeResourcePatient: "subject", AttributeResourceCustodian: "custodian", AttributeResourceDataClass: "resource"},
+			Action:   map[string]string{AttributeActionID: "read"},
+		}
+	}
+
+	t.Run("Permit with audit obligation", func(t *testing.T) {
+		response := client.Decide(context.TODO(), request())
+
+		assert.Equal(t, DecisionPermit, response.Decision)
+		if assert.Len(t, response.Obligations, 1) {
+			assert.Equal(t, ObligationAudit, response.Obligations[0].ID)
+			assert.Equal(t, "actor", response.Obligations[0].Attributes["actor"])
+		}
+	})
+
+	t.Run("Deny with advice for another data class", func(t *testing.T) {
+		r := request()
+		r.Resource[AttributeResourceDataClass] = "other"
+
+		response := client.Decide(context.TODO(), r)
+
+		assert.Equal(t, DecisionDeny, response.Decision)
+		if assert.Len(t, response.Advice, 1) {
+			assert.Equal(t, AdviceNoConsent, response.Advice[0].ID)
+		}
+	})
+
+	t.Run("Deny at a time the consent has ended", func(t *testing.T) {
+		r := request()
+		r.Environment = map[string]string{AttributeEnvironmentTime: time.Now().Add(2 * day).Format(time.RFC3339)}
+
+		assert.Equal(t, DecisionDeny, client.Decide(context.TODO(), r).Decision)
+	})
+
+	t.Run("NotApplicable for other actions", func(t *testing.T) {
+		r := request()
+		r.Action[AttributeActionID] = "delete"
+
+		response := client.Decide(context.TODO(), r)
+
+		assert.Equal(t, DecisionNotApplicable, response.Decision)
+		assert.Equal(t, AdviceUnsupportedAction, response.Advice[0].ID)
+	})
+
+	t.Run("Indeterminate on missing attribute", func(t *testing.T) {
+		r := request()
+		delete(r.Resource, AttributeResourceCustodian)
+
+		response := client.Decide(context.TODO(), r)
+
+		assert.Equal(t, DecisionIndeterminate, response.Decision)
+		assert.Equal(t, StatusMissingAttribute, response.Status.Code)
+		assert.Equal(t, "missing resource attribute custodian", response.Status.Message)
+	})
+
+	t.Run("Indeterminate on invalid time", func(t *testing.T) {
+		r := request()
+		r.Environment = map[string]string{AttributeEnvironmentTime: "now"}
+
+		response := client.Decide(context.TODO(), r)
+
+		assert.Equal(t, DecisionIndeterminate, response.Decision)
+		assert.Equal(t, StatusSyntaxError, response.Status.Code)
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Operation identifies the budget a request is counted against
+type Operation string
+
+const (
+	// OperationCheck is the budget for consent checks
+	OperationCheck Operation = "check"
+	// OperationQuery is the budget for consent queries and lookups
+	OperationQuery Operation = "query"
+	// OperationWrite is the budget for recording and deleting consent
+	OperationWrite Operation = "write"
+)
+
+var operations = []Operation{OperationCheck, OperationQuery, OperationWrite}
+
+// ErrorRateLimited is returned when the caller exceeded its budget for an operation
+var ErrorRateLimited = errors.New("rate limit exceeded")
+
+// RateLimit holds the allowed number of requests per minute for each operation. A value of 0 means unlimited.
+type RateLimit struct {
+	Check int
+	Query int
+	Write int
+}
+
+func (r RateLimit) perMinute(op Operation) int {
+	switch op {
+	case OperationCheck:
+		return r.Check
+	case OperationQuery:
+		return r.Query
+	case OperationWrite:
+		return r.Write
+	}
+	return 0
+}
+
+func (r RateLimit) enabled() bool {
+	return r.Check > 0 || r.Query > 0 || r.Write > 0
+}
+
+// idleCallerTimeout is the time after which the state of an idle caller is dropped
+const idleCallerTimeout = 10 * time.Minute
+
+// RateLimiter keeps a token bucket per caller and operation
+type RateLimiter struct {
+	defaults  RateLimit
+	overrides map[string]RateLimit
+
+	mutex     sync.Mutex
+	callers   map[string]*callerState
+	throttled map[Operation]uint64
+	lastPrune time.Time
+}
+
+type callerState struct {
+	limiters map[Operation]*rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter creates a RateLimiter with the given default limits and per caller overrides
+func NewRateLimiter(defaults RateLimit, overrides map[string]RateLimit) *RateLimiter {
+	if overrides == nil {
+		overrides = map[string]RateLimit{}
+	}
+
+	return &RateLimiter{
+		defaults:  defaults,
+		overrides: overrides,
+		callers:   map[string]*callerState{},
+		throttled: map[Operation]uint64{},
+		lastPrune: time.Now(),
+	}
+}
+
+// Enabled returns true if any limit is configured
+func (rl *RateLimiter) Enabled() bool {
+	if rl.defaults.enabled() {
+		return true
+	}
+	for _, o := range rl.overrides {
+		if o.enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// Allow counts a request of the caller against the budget of the operation.
+// When the budget is exhausted it returns false and the time after which the request can be retried.
+func (rl *RateLimiter) Allow(caller string, op Operation) (bool, time.Duration) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := time.Now()
+	rl.prune(now)
+
+	limiter := rl.limiter(caller, op, now)
+	if limiter == nil {
+		return true, 0
+	}
+
+	r := limiter.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		rl.throttled[op]++
+		return false, delay
+	}
+
+	return true, 0
+}
+
+func (rl *RateLimiter) limiter(caller string, op Operation, now time.Time) *rate.Limiter {
+	state, ok := rl.callers[caller]
+	if !ok {
+		state = &callerState{limiters: map[Operation]*rate.Limiter{}}
+		rl.callers[caller] = state
+	}
+	state.lastSeen = now
+
+	if l, ok := state.limiters[op]; ok {
+		return l
+	}
+
+	perMinute := rl.defaults.perMinute(op)
+	if o, ok := rl.overrides[caller]; ok && o.perMinute(op) > 0 {
+		perMinute = o.perMinute(op)
+	}
+	if perMinute <= 0 {
+		return nil
+	}
+
+	l := rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)
+	state.limiters[op] = l
+	return l
+}
+
+// prune removes callers that have been idle for a while, so the state doesn't grow indefinitely
+func (rl *RateLimiter) prune(now time.Time) {
+	if now.Sub(rl.lastPrune) < time.Minute {
+		return
+	}
+	rl.lastPrune = now
+
+	for c, s := range rl.callers {
+		if now.Sub(s.lastSeen) > idleCallerTimeout {
+			delete(rl.callers, c)
+		}
+	}
+}
+
+// RateLimiterState is a snapshot of the state of the RateLimiter
+type RateLimiterState struct {
+	Enabled   bool
+	Callers   int
+	Throttled map[Operation]uint64
+}
+
+// State returns a snapshot of the current state
+func (rl *RateLimiter) State() RateLimiterState {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	throttled := map[Operation]uint64{}
+	for _, op := range operations {
+		throttled[op] = rl.throttled[op]
+	}
+
+	return RateLimiterState{
+		Enabled:   rl.Enabled(),
+		Callers:   len(rl.callers),
+		Throttled: throttled,
+	}
+}
+
+// rateLimiter creates the RateLimiter from the config
+func (c ConsentStoreConfig) rateLimiter() (*RateLimiter, error) {
+	entries, err := parseConfigEntries(c.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]RateLimit{}
+	for _, e := range entries {
+		caller, ok := e["caller"]
+		if !ok || caller == "" {
+			return nil, fmt.Errorf("missing caller in rate limit entry %v", e)
+		}
+
+		var limit RateLimit
+		for key, target := range map[string]*int{"check": &limit.Check, "query": &limit.Query, "write": &limit.Write} {
+			v, ok := e[key]
+			if !ok {
+				continue
+			}
+			if *target, err = strconv.Atoi(v); err != nil || *target < 0 {
+				return nil, fmt.Errorf("invalid %s limit for caller %s: %s", key, caller, v)
+			}
+		}
+		overrides[caller] = limit
+	}
+
+	return NewRateLimiter(RateLimit{
+		Check: c.RateLimitCheck,
+		Query: c.RateLimitQuery,
+		Write: c.RateLimitWrite,
+	}, overrides), nil
+}
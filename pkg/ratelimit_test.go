@@ -0,0 +1,99 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	t.Run("unlimited when nothing is configured", func(t *testing.T) {
+		rl := NewRateLimiter(RateLimit{}, nil)
+
+		for i := 0; i < 100; i++ {
+			ok, _ := rl.Allow("caller", OperationCheck)
+			assert.True(t, ok)
+		}
+		assert.False(t, rl.Enabled())
+	})
+
+	t.Run("exhausted budget returns retry after", func(t *testing.T) {
+		rl := NewRateLimiter(RateLimit{Check: 2}, nil)
+
+		ok1, _ := rl.Allow("caller", OperationCheck)
+		ok2, _ := rl.Allow("caller", OperationCheck)
+		ok3, retryAfter := rl.Allow("caller", OperationCheck)
+
+		assert.True(t, ok1)
+		assert.True(t, ok2)
+		assert.False(t, ok3)
+		assert.True(t, retryAfter > 0 && retryAfter <= 30*time.Second)
+	})
+
+	t.Run("budgets are separate per operation and caller", func(t *testing.T) {
+		rl := NewRateLimiter(RateLimit{Check: 1, Query: 1}, nil)
+
+		ok1, _ := rl.Allow("caller", OperationCheck)
+		ok2, _ := rl.Allow("caller", OperationQuery)
+		ok3, _ := rl.Allow("other", OperationCheck)
+		ok4, _ := rl.Allow("caller", OperationWrite)
+
+		assert.True(t, ok1)
+		assert.True(t, ok2)
+		assert.True(t, ok3)
+		assert.True(t, ok4)
+	})
+
+	t.Run("override replaces default for caller", func(t *testing.T) {
+		rl := NewRateLimiter(RateLimit{Check: 1}, map[string]RateLimit{"gateway": {Check: 3}})
+
+		for i := 0; i < 3; i++ {
+			ok, _ := rl.Allow("gateway", OperationCheck)
+			assert.True(t, ok)
+		}
+		ok, _ := rl.Allow("gateway", OperationCheck)
+		assert.False(t, ok)
+	})
+}
+
+func TestConsentStoreConfig_rateLimiter(t *testing.T) {
+	t.Run("parses overrides", func(t *testing.T) {
+		rl, err := ConsentStoreConfig{RateLimitCheck: 10, RateLimits: "caller=gateway;check=100;query=5"}.rateLimiter()
+
+		if assert.NoError(t, err) {
+			assert.True(t, rl.Enabled())
+			assert.Equal(t, RateLimit{Check: 100, Query: 5}, rl.overrides["gateway"])
+		}
+	})
+
+	t.Run("missing caller returns error", func(t *testing.T) {
+		_, err := ConsentStoreConfig{RateLimits: "check=100"}.rateLimiter()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid limit returns error", func(t *testing.T) {
+		_, err := ConsentStoreConfig{RateLimits: "caller=gateway;check=many"}.rateLimiter()
+
+		assert.Error(t, err)
+	})
+}
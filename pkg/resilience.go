@@ -0,0 +1,198 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrorCircuitOpen is returned by the HttpClient without calling the server while the circuit breaker is open
+var ErrorCircuitOpen = errors.New("circuit breaker is open, consent store is unavailable")
+
+// RetryPolicy defines how often an idempotent call to the consent store is attempted and how long to wait in between
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, 1 or less means a call is not retried
+	MaxAttempts int
+	// Backoff is the base wait time after the first failed attempt, it doubles after every next attempt
+	Backoff time.Duration
+}
+
+// Wait returns the jittered wait time after the given failed attempt, counting from 1.
+// The wait time is between half and the whole of the exponential backoff, so clients that failed at the same time don't retry at the same time.
+func (p RetryPolicy) Wait(attempt int) time.Duration {
+	if p.Backoff <= 0 || attempt < 1 {
+		return 0
+	}
+
+	backoff := p.Backoff
+	for i := 1; i < attempt && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+
+	half := int64(backoff / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// BreakerState is the state of a CircuitBreaker
+type BreakerState string
+
+const (
+	// BreakerClosed lets all calls through
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen fails calls fast until the cooldown has passed
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single call through to probe whether the server is back
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// BreakerStatus is a snapshot of a CircuitBreaker, used in the diagnostics
+type BreakerStatus struct {
+	State    BreakerState
+	Failures int
+	OpenedAt time.Time
+}
+
+// CircuitBreaker opens after a number of consecutive failed calls. While open calls fail fast, after the cooldown a single call
+// is let through: when it succeeds the breaker closes again, otherwise it stays open for another cooldown.
+// A nil CircuitBreaker lets all calls through.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mutex    sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker, a threshold of 0 or less disables it and returns nil
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// Allow returns ErrorCircuitOpen when a call may not be made. When the cooldown has passed the breaker becomes half-open and
+// allows the calling call as probe, other calls fail until its outcome is reported by Success or Failure.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrorCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		return nil
+	case BreakerHalfOpen:
+		return ErrorCircuitOpen
+	}
+	return nil
+}
+
+// Success reports a call that reached the server, it closes the breaker
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+}
+
+// Failure reports a call that failed because the server is unavailable, it opens the breaker when the threshold is reached or the probe failed
+func (b *CircuitBreaker) Failure() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Status returns a snapshot of the breaker
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return BreakerStatus{State: b.state, Failures: b.failures, OpenedAt: b.openedAt}
+}
+
+// ClientTimeoutPeriod returns the timeout of a single call of the HttpClient, the default is used when it's not configured
+func (c ConsentStoreConfig) ClientTimeoutPeriod() (time.Duration, error) {
+	return c.clientDuration(ConfigClientTimeout, c.ClientTimeout, ConfigClientTimeoutDefault)
+}
+
+// ClientRetryPolicy returns the retry policy for idempotent calls of the HttpClient
+func (c ConsentStoreConfig) ClientRetryPolicy() (RetryPolicy, error) {
+	backoff, err := c.clientDuration(ConfigClientRetryBackoff, c.ClientRetryBackoff, ConfigClientRetryBackoffDefault)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+
+	return RetryPolicy{MaxAttempts: c.ClientMaxAttempts, Backoff: backoff}, nil
+}
+
+// ClientCircuitBreaker returns a new circuit breaker for the HttpClient, nil when the breaker threshold is 0
+func (c ConsentStoreConfig) ClientCircuitBreaker() (*CircuitBreaker, error) {
+	cooldown, err := c.clientDuration(ConfigClientBreakerCooldown, c.ClientBreakerCooldown, ConfigClientBreakerCooldownDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCircuitBreaker(c.ClientBreakerThreshold, cooldown), nil
+}
+
+func (c ConsentStoreConfig) clientDuration(name string, value string, def string) (time.Duration, error) {
+	if value == "" {
+		value = def
+	}
+
+	d, err := ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return d, nil
+}
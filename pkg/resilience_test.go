@@ -0,0 +1,135 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Wait(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, Backoff: 100 * time.Millisecond}
+
+	t.Run("doubles and jitters the backoff", func(t *testing.T) {
+		for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+			for i := 0; i < 20; i++ {
+				wait := p.Wait(attempt)
+				assert.True(t, wait >= max/2 && wait <= max, "wait %s of attempt %d out of bounds", wait, attempt)
+			}
+		}
+	})
+
+	t.Run("no backoff", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), RetryPolicy{}.Wait(1))
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	t.Run("stays closed below the threshold", func(t *testing.T) {
+		b.Failure()
+
+		assert.NoError(t, b.Allow())
+		assert.Equal(t, BreakerClosed, b.Status().State)
+	})
+
+	t.Run("success resets the failures", func(t *testing.T) {
+		b.Success()
+		b.Failure()
+
+		assert.NoError(t, b.Allow())
+		assert.Equal(t, 1, b.Status().Failures)
+	})
+
+	t.Run("opens at the threshold and fails fast", func(t *testing.T) {
+		b.Failure()
+
+		assert.Equal(t, ErrorCircuitOpen, b.Allow())
+		assert.Equal(t, BreakerOpen, b.Status().State)
+		assert.Equal(t, now, b.Status().OpenedAt)
+	})
+
+	t.Run("lets a single probe through after the cooldown", func(t *testing.T) {
+		now = now.Add(time.Minute)
+
+		assert.NoError(t, b.Allow())
+		assert.Equal(t, BreakerHalfOpen, b.Status().State)
+		assert.Equal(t, ErrorCircuitOpen, b.Allow())
+	})
+
+	t.Run("failed probe opens the breaker again", func(t *testing.T) {
+		b.Failure()
+
+		assert.Equal(t, ErrorCircuitOpen, b.Allow())
+		assert.Equal(t, now, b.Status().OpenedAt)
+	})
+
+	t.Run("successful probe closes the breaker", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.NoError(t, b.Allow())
+
+		b.Success()
+
+		assert.NoError(t, b.Allow())
+		assert.Equal(t, BreakerStatus{State: BreakerClosed, OpenedAt: now.Add(-time.Minute)}, b.Status())
+	})
+
+	t.Run("nil breaker allows everything", func(t *testing.T) {
+		var nb *CircuitBreaker
+
+		nb.Failure()
+		nb.Success()
+
+		assert.NoError(t, nb.Allow())
+		assert.Nil(t, NewCircuitBreaker(0, time.Minute))
+	})
+}
+
+func TestConsentStoreConfig_Client(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		timeout, err := ConsentStoreConfig{}.ClientTimeoutPeriod()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, timeout)
+
+		retry, err := ConsentStoreConfig{ClientMaxAttempts: 3}.ClientRetryPolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, RetryPolicy{MaxAttempts: 3, Backoff: 100 * time.Millisecond}, retry)
+
+		breaker, err := ConsentStoreConfig{ClientBreakerThreshold: 5}.ClientCircuitBreaker()
+		if assert.NoError(t, err) && assert.NotNil(t, breaker) {
+			assert.Equal(t, 30*time.Second, breaker.cooldown)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ConsentStoreConfig{ClientTimeout: "soon"}.ClientTimeoutPeriod()
+		assert.EqualError(t, err, "invalid clientTimeout: time: invalid duration \"soon\"")
+
+		_, err = ConsentStoreConfig{ClientRetryBackoff: "soon"}.ClientRetryPolicy()
+		assert.Error(t, err)
+
+		_, err = ConsentStoreConfig{ClientBreakerCooldown: "soon"}.ClientCircuitBreaker()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,471 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// RetentionMode defines what happens with consent chains that are past their retention period
+type RetentionMode string
+
+const (
+	// RetentionModePurge removes eligible chains
+	RetentionModePurge RetentionMode = "purge"
+	// RetentionModeArchive writes eligible chains to the archive directory before removing them
+	RetentionModeArchive RetentionMode = "archive"
+)
+
+// retentionActor is recorded as actor in the audit trail
+const retentionActor = "retention"
+
+// ErrorMissingArchiveDir is returned when the archive retention mode is configured without a directory
+var ErrorMissingArchiveDir = errors.New("retentionArchiveDir must be configured for the archive retention mode")
+
+// RetentionPolicy defines how long a consent chain is kept after its latest record expired.
+// A policy without DataClass and Custodian applies to all chains.
+type RetentionPolicy struct {
+	DataClass string
+	Custodian string
+	Period    time.Duration
+}
+
+// applies returns true if the policy applies to the chain of the given consent and latest record
+func (p RetentionPolicy) applies(pc PatientConsent, latest ConsentRecord) bool {
+	if p.Custodian != "" && p.Custodian != pc.Custodian {
+		return false
+	}
+
+	if p.DataClass == "" {
+		return true
+	}
+
+	for _, dc := range latest.DataClasses {
+		if dc.Code == p.DataClass {
+			return true
+		}
+	}
+	return false
+}
+
+func (p RetentionPolicy) String() string {
+	switch {
+	case p.DataClass != "" && p.Custodian != "":
+		return fmt.Sprintf("dataClass=%s;custodian=%s;period=%s", p.DataClass, p.Custodian, p.Period)
+	case p.DataClass != "":
+		return fmt.Sprintf("dataClass=%s;period=%s", p.DataClass, p.Period)
+	case p.Custodian != "":
+		return fmt.Sprintf("custodian=%s;period=%s", p.Custodian, p.Period)
+	}
+	return fmt.Sprintf("period=%s", p.Period)
+}
+
+// RetentionCandidate is a consent chain that is past its retention period
+type RetentionCandidate struct {
+	PatientConsentID string
+	Custodian        string
+	Subject          string
+	Actor            string
+	UUID             string
+	ValidTo          time.Time
+	Hashes           []string
+	Policy           string
+	ArchiveFile      string `json:",omitempty"`
+
+	// version is the latest version of the chain when it was selected
+	version uint
+}
+
+// RetentionReport lists the consent chains that were (or in case of a dry run would be) removed
+type RetentionReport struct {
+	Mode       RetentionMode
+	DryRun     bool
+	Candidates []RetentionCandidate
+}
+
+// retentionPolicies parses the default retention period and the per data class/custodian policies from the config
+func (c ConsentStoreConfig) retentionPolicies() ([]RetentionPolicy, error) {
+	var policies []RetentionPolicy
+
+	if c.RetentionPeriod != "" {
+		period, err := ParseDuration(c.RetentionPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ConfigRetentionPeriod, err)
+		}
+		policies = append(policies, RetentionPolicy{Period: period})
+	}
+
+	entries, err := parseConfigEntries(c.RetentionPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		p := RetentionPolicy{
+			DataClass: e["dataClass"],
+			Custodian: e["custodian"],
+		}
+		if p.DataClass == "" && p.Custodian == "" {
+			return nil, fmt.Errorf("missing dataClass or custodian in retention policy %v", e)
+		}
+		if p.Period, err = ParseDuration(e["period"]); err != nil {
+			return nil, fmt.Errorf("invalid period in retention policy %v: %w", e, err)
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+// retentionMode returns the configured retention mode, purge by default
+func (c ConsentStoreConfig) retentionMode() (RetentionMode, error) {
+	switch RetentionMode(c.RetentionMode) {
+	case "", RetentionModePurge:
+		return RetentionModePurge, nil
+	case RetentionModeArchive:
+		if c.RetentionArchiveDir == "" {
+			return "", ErrorMissingArchiveDir
+		}
+		return RetentionModeArchive, nil
+	}
+	return "", fmt.Errorf("invalid %s: %s", ConfigRetentionMode, c.RetentionMode)
+}
+
+// ApplyRetention removes all consent chains of which the latest record expired longer than the retention period ago.
+// When multiple policies apply to a chain, the longest period is used. Chains without an applicable policy are kept.
+// Every removed chain is recorded in the audit trail and a ConsentDeleted event is emitted for each of its records that wasn't deleted yet.
+// With dryRun, only the report is returned.
+func (cs *ConsentStore) ApplyRetention(ctx context.Context, now time.Time, dryRun bool) (RetentionReport, error) {
+	report := RetentionReport{Mode: cs.retentionMode, DryRun: dryRun}
+
+	if len(cs.retentionPolicies) == 0 {
+		return report, nil
+	}
+
+	candidates, err := cs.retentionCandidates(ctx, now)
+	if err != nil {
+		return report, err
+	}
+
+	if dryRun || len(candidates) == 0 {
+		report.Candidates = candidates
+		return report, nil
+	}
+
+	report.Candidates, err = cs.removeRetentionCandidates(ctx, candidates)
+	return report, err
+}
+
+// removeRetentionCandidates removes (or archives) the chains in one transaction and returns the removed chains. The candidates are selected
+// outside the transaction, so a chain that got a new version in the meantime is kept until the next run.
+func (cs *ConsentStore) removeRetentionCandidates(ctx context.Context, candidates []RetentionCandidate) ([]RetentionCandidate, error) {
+	tx := cs.db(ctx).Begin().Debug()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	// the archives are written to temporary files, they only get their name when the chains are removed
+	var archives []pendingArchive
+	var events []Event
+	var removed []RetentionCandidate
+	rollback := func(err error) ([]RetentionCandidate, error) {
+		tx.Rollback()
+		removeArchives(archives)
+		return nil, err
+	}
+
+	for _, c := range candidates {
+		head, err := chainHead(tx, c.UUID)
+		if err != nil {
+			return rollback(err)
+		}
+		if head.Hash != c.Hashes[len(c.Hashes)-1] || head.Version != c.version {
+			Logger().Infof("Consent chain %s changed after it was selected for retention, it's kept", c.UUID)
+			continue
+		}
+
+		action := AuditActionRetentionPurge
+		if cs.retentionMode == RetentionModeArchive {
+			archive, err := cs.archiveChain(tx, c)
+			if err != nil {
+				return rollback(err)
+			}
+			archives = append(archives, archive)
+			c.ArchiveFile = archive.file
+			action = AuditActionRetentionArchive
+		}
+
+		chainEvents, err := chainDeletedEvents(tx, c.PatientConsentID, c.UUID)
+		if err != nil {
+			return rollback(err)
+		}
+		events = append(events, chainEvents...)
+
+		if err := removeChain(tx, c.PatientConsentID, c.UUID); err != nil {
+			return rollback(err)
+		}
+
+		subject := c.Subject
+		if err := writeAuditEntry(tx, action, retentionActor, &subject, c); err != nil {
+			return rollback(err)
+		}
+
+		removed = append(removed, c)
+	}
+
+	if err := cs.writeOutbox(tx, events); err != nil {
+		return rollback(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		removeArchives(archives)
+		return nil, err
+	}
+
+	cs.publish(events)
+
+	for _, a := range archives {
+		if err := os.Rename(a.temp, a.file); err != nil {
+			return removed, fmt.Errorf("chain is removed but its archive %s is left at %s: %w", a.file, a.temp, err)
+		}
+	}
+
+	return removed, nil
+}
+
+// retentionCandidates finds all chains that are past their retention period
+func (cs *ConsentStore) retentionCandidates(ctx context.Context, now time.Time) ([]RetentionCandidate, error) {
+	var chains []ConsentRecord
+
+	// only chains with at least one expired record can be eligible
+	if err := cs.db(ctx).Debug().Unscoped().
+		Select("DISTINCT patient_consent_id, uuid").
+		Where("valid_to IS NOT NULL AND julianday(valid_to) < julianday(?)", now).
+		Order("uuid").
+		Find(&chains).Error; err != nil {
+		return nil, err
+	}
+
+	var candidates []RetentionCandidate
+
+	for _, chain := range chains {
+		var records []ConsentRecord
+		if err := cs.db(ctx).Debug().Unscoped().Preload("DataClasses").
+			Where("patient_consent_id = ? AND uuid = ?", chain.PatientConsentID, chain.UUID).
+			Order("version").Find(&records).Error; err != nil {
+			return nil, err
+		}
+
+		latest := records[len(records)-1]
+		if latest.ValidTo == nil {
+			continue
+		}
+
+		var pc PatientConsent
+		if err := cs.db(ctx).Debug().Where("id = ?", chain.PatientConsentID).First(&pc).Error; err != nil {
+			return nil, err
+		}
+
+		var policy *RetentionPolicy
+		for i, p := range cs.retentionPolicies {
+			if p.applies(pc, latest) && (policy == nil || p.Period > policy.Period) {
+				policy = &cs.retentionPolicies[i]
+			}
+		}
+
+		if policy == nil || latest.ValidTo.Add(policy.Period).After(now) {
+			continue
+		}
+
+		c := RetentionCandidate{
+			PatientConsentID: pc.ID,
+			Custodian:        pc.Custodian,
+			Subject:          pc.Subject,
+			Actor:            pc.Actor,
+			UUID:             chain.UUID,
+			ValidTo:          *latest.ValidTo,
+			Policy:           policy.String(),
+			version:          latest.Version,
+		}
+		for _, r := range records {
+			c.Hashes = append(c.Hashes, r.Hash)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, nil
+}
+
+// pendingArchive is an archive written to a temporary file, it's renamed to its file when the chain has been removed
+type pendingArchive struct {
+	temp string
+	file string
+}
+
+// removeArchives removes the temporary files of archives of which the chains weren't removed
+func removeArchives(archives []pendingArchive) {
+	for _, a := range archives {
+		if err := os.Remove(a.temp); err != nil {
+			Logger().Errorf("Error removing archive %s: %v", a.temp, err)
+		}
+	}
+}
+
+// archiveChain writes the complete chain as JSON to a temporary file in the archive directory
+func (cs *ConsentStore) archiveChain(tx *gorm.DB, c RetentionCandidate) (pendingArchive, error) {
+	var pc PatientConsent
+	if err := tx.Where("id = ?", c.PatientConsentID).First(&pc).Error; err != nil {
+		return pendingArchive{}, err
+	}
+	if err := tx.Unscoped().Preload("DataClasses").
+		Where("patient_consent_id = ? AND uuid = ?", c.PatientConsentID, c.UUID).
+		Order("version").Find(&pc.Records).Error; err != nil {
+		return pendingArchive{}, err
+	}
+
+	data, err := json.MarshalIndent(pc, "", "  ")
+	if err != nil {
+		return pendingArchive{}, err
+	}
+
+	if err := os.MkdirAll(cs.Config.RetentionArchiveDir, 0700); err != nil {
+		return pendingArchive{}, err
+	}
+
+	// the ID and UUID are given by the caller, so they're hashed to get a valid file name
+	digest := sha256.Sum256([]byte(c.PatientConsentID + "\x00" + c.UUID))
+	name := hex.EncodeToString(digest[:]) + ".json"
+	f, err := ioutil.TempFile(cs.Config.RetentionArchiveDir, "."+name+".*")
+	if err != nil {
+		return pendingArchive{}, err
+	}
+	archive := pendingArchive{temp: f.Name(), file: filepath.Join(cs.Config.RetentionArchiveDir, name)}
+
+	_, err = f.Write(data)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		os.Remove(archive.temp)
+		return pendingArchive{}, err
+	}
+
+	return archive, nil
+}
+
+// chainDeletedEvents returns a ConsentDeleted event for every record of the chain that isn't deleted yet
+func chainDeletedEvents(tx *gorm.DB, patientConsentID string, uuid string) ([]Event, error) {
+	var pc PatientConsent
+	if err := tx.Where("id = ?", patientConsentID).First(&pc).Error; err != nil {
+		return nil, err
+	}
+
+	var records []ConsentRecord
+	if err := tx.Preload("DataClasses").
+		Where("patient_consent_id = ? AND uuid = ?", patientConsentID, uuid).
+		Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, r := range records {
+		events = append(events, newEvent(EventConsentDeleted, pc, r))
+	}
+	return events, nil
+}
+
+// removeChain physically removes all versions of a chain, including tombstones and data classes.
+// The PatientConsent is removed as well when it has no records left.
+func removeChain(tx *gorm.DB, patientConsentID string, uuid string) error {
+	var ids []uint
+	if err := tx.Unscoped().Model(&ConsentRecord{}).
+		Where("patient_consent_id = ? AND uuid = ?", patientConsentID, uuid).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Delete(DataClass{}, "consent_record_id IN (?)", ids).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Unscoped().Delete(ConsentRecord{}, "id IN (?)", ids).Error; err != nil {
+		return err
+	}
+
+	var remaining int
+	if err := tx.Unscoped().Model(&ConsentRecord{}).Where("patient_consent_id = ?", patientConsentID).Count(&remaining).Error; err != nil {
+		return err
+	}
+
+	if remaining == 0 {
+		return tx.Exec("DELETE FROM patient_consent WHERE id = ?", patientConsentID).Error
+	}
+
+	return nil
+}
+
+// startRetentionJob periodically applies the retention policies in the background, it's stopped by Shutdown
+func (cs *ConsentStore) startRetentionJob(interval time.Duration) {
+	cs.retentionJob = startJob(interval, func() {
+		report, err := cs.ApplyRetention(context.Background(), time.Now(), false)
+		if err != nil {
+			Logger().Errorf("error applying retention policies: %v", err)
+			return
+		}
+		if len(report.Candidates) > 0 {
+			Logger().Infof("retention policies removed %d consent chains (%s)", len(report.Candidates), report.Mode)
+		}
+	})
+}
+
+// stopRetentionJob stops the background job and waits for a running iteration to finish
+func (cs *ConsentStore) stopRetentionJob() {
+	if cs.retentionJob == nil {
+		return
+	}
+
+	cs.retentionJob.Stop()
+	cs.retentionJob = nil
+}
+
+// retentionInterval returns the interval of the background job, the default is used when it's not configured.
+// An interval of 0 disables the background job.
+func (c ConsentStoreConfig) retentionInterval() (time.Duration, error) {
+	interval := c.RetentionInterval
+	if interval == "" {
+		interval = ConfigRetentionIntervalDefault
+	}
+
+	d, err := ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ConfigRetentionInterval, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,338 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/labstack/gommon/random"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const day = 24 * time.Hour
+
+func expiredPatientConsent(subject string, custodian string, dataClass string, expiredAgo time.Duration) PatientConsent {
+	validTo := time.Now().Add(-expiredAgo)
+	return PatientConsent{
+		ID:        random.String(8),
+		Actor:     "actor",
+		Custodian: custodian,
+		Subject:   subject,
+		Records: []ConsentRecord{
+			{
+				ValidFrom:   validTo.Add(-day),
+				ValidTo:     &validTo,
+				Hash:        random.String(8),
+				DataClasses: []DataClass{{Code: dataClass}},
+				UUID:        uuid.NewV4().String(),
+			},
+		},
+	}
+}
+
+func TestConsentStore_ApplyRetention(t *testing.T) {
+	t.Run("without policies nothing is removed", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.RecordConsent(context.TODO(), []PatientConsent{expiredPatientConsent("subject", "custodian", "resource", 400*day)})
+
+		report, err := client.ApplyRetention(context.TODO(), time.Now(), false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, report.Candidates)
+	})
+
+	t.Run("dry run lists chains past the longest applicable period", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.retentionPolicies = []RetentionPolicy{
+			{Period: 30 * day},
+			{DataClass: "medical", Period: 365 * day},
+		}
+		expired := expiredPatientConsent("subject1", "custodian", "resource", 60*day)
+		client.RecordConsent(context.TODO(), []PatientConsent{
+			expired,
+			expiredPatientConsent("subject2", "custodian", "medical", 60*day),
+			expiredPatientConsent("subject3", "custodian", "resource", 10*day),
+		})
+
+		report, err := client.ApplyRetention(context.TODO(), time.Now(), true)
+
+		if assert.NoError(t, err) && assert.Len(t, report.Candidates, 1) {
+			assert.True(t, report.DryRun)
+			assert.Equal(t, "subject1", report.Candidates[0].Subject)
+			assert.Equal(t, []string{expired.Records[0].Hash}, report.Candidates[0].Hashes)
+			assert.Equal(t, "period=720h0m0s", report.Candidates[0].Policy)
+		}
+
+		_, err = client.FindConsentRecordByHash(context.TODO(), expired.Records[0].Hash, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("chains with an active latest version are kept", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+		expired := expiredPatientConsent("subject", "custodian", "resource", 60*day)
+		client.RecordConsent(context.TODO(), []PatientConsent{expired})
+		update := expired
+		update.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			Hash:         random.String(8),
+			PreviousHash: &expired.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+		client.RecordConsent(context.TODO(), []PatientConsent{update})
+
+		report, err := client.ApplyRetention(context.TODO(), time.Now(), true)
+
+		assert.NoError(t, err)
+		assert.Empty(t, report.Candidates)
+	})
+
+	t.Run("chains appended to after their selection are kept", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+		expired := expiredPatientConsent("subject", "custodian", "resource", 60*day)
+		client.RecordConsent(context.TODO(), []PatientConsent{expired})
+		candidates, _ := client.retentionCandidates(context.TODO(), time.Now())
+		update := expired
+		update.Records = []ConsentRecord{{
+			ValidFrom:    time.Now(),
+			Hash:         random.String(8),
+			PreviousHash: &expired.Records[0].Hash,
+			DataClasses:  []DataClass{{Code: "resource"}},
+		}}
+		client.RecordConsent(context.TODO(), []PatientConsent{update})
+
+		removed, err := client.removeRetentionCandidates(context.TODO(), candidates)
+
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 1)
+		assert.Empty(t, removed)
+		var count int
+		client.Db.Model(&ConsentRecord{}).Count(&count)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("purge removes the chain and writes the audit trail", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.retentionPolicies = []RetentionPolicy{{Custodian: "custodian", Period: 30 * day}}
+		expired := expiredPatientConsent("subject", "custodian", "resource", 60*day)
+		client.RecordConsent(context.TODO(), []PatientConsent{expired})
+
+		report, err := client.ApplyRetention(context.TODO(), time.Now(), false)
+
+		if assert.NoError(t, err) {
+			assert.Len(t, report.Candidates, 1)
+		}
+
+		var count int
+		client.Db.Unscoped().Model(&ConsentRecord{}).Count(&count)
+		assert.Equal(t, 0, count)
+		client.Db.Model(&DataClass{}).Count(&count)
+		assert.Equal(t, 0, count)
+		client.Db.Model(&PatientConsent{}).Count(&count)
+		assert.Equal(t, 0, count)
+
+		entries, err := client.AuditEntries(context.TODO(), "subject")
+		if assert.NoError(t, err) && assert.Len(t, entries, 1) {
+			assert.Equal(t, AuditActionRetentionPurge, entries[0].Action)
+			assert.Equal(t, "retention", entries[0].Actor)
+			assert.Contains(t, entries[0].Details, expired.Records[0].Hash)
+		}
+	})
+
+	t.Run("purge publishes the deletion", func(t *testing.T) {
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+		expired := expiredPatientConsent("subject", "custodian", "resource", 60*day)
+		client.RecordConsent(context.TODO(), []PatientConsent{expired})
+		sub, _, _ := client.Events.Subscribe(0)
+
+		_, err := client.ApplyRetention(context.TODO(), time.Now(), false)
+
+		if assert.NoError(t, err) && assert.Len(t, sub.C, 1) {
+			e := <-sub.C
+			assert.Equal(t, EventConsentDeleted, e.Type)
+			assert.Equal(t, "subject", e.Subject)
+			assert.Equal(t, expired.Records[0].Hash, e.RecordHash)
+		}
+	})
+
+	t.Run("archive writes the chain before removing it", func(t *testing.T) {
+		dir := testDirectory(t)
+		defer os.RemoveAll(dir)
+
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.Config.RetentionArchiveDir = dir
+		client.retentionMode = RetentionModeArchive
+		client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+		expired := expiredPatientConsent("subject", "custodian", "resource", 60*day)
+		client.RecordConsent(context.TODO(), []PatientConsent{expired})
+
+		report, err := client.ApplyRetention(context.TODO(), time.Now(), false)
+
+		if assert.NoError(t, err) && assert.Len(t, report.Candidates, 1) {
+			data, err := ioutil.ReadFile(report.Candidates[0].ArchiveFile)
+			if assert.NoError(t, err) {
+				var archived PatientConsent
+				assert.NoError(t, json.Unmarshal(data, &archived))
+				assert.Equal(t, expired.Records[0].Hash, archived.Records[0].Hash)
+			}
+		}
+
+		entries, _ := client.AuditEntries(context.TODO(), "subject")
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, AuditActionRetentionArchive, entries[0].Action)
+		}
+	})
+
+	t.Run("archive names don't depend on the characters of the ID", func(t *testing.T) {
+		dir := testDirectory(t)
+		defer os.RemoveAll(dir)
+
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.Config.RetentionArchiveDir = dir
+		client.retentionMode = RetentionModeArchive
+		client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+		expired := expiredPatientConsent("subject", "custodian", "resource", 60*day)
+		expired.ID = "../consent/" + expired.ID
+		client.RecordConsent(context.TODO(), []PatientConsent{expired})
+
+		report, err := client.ApplyRetention(context.TODO(), time.Now(), false)
+
+		if assert.NoError(t, err) && assert.Len(t, report.Candidates, 1) {
+			assert.Equal(t, dir, filepath.Dir(report.Candidates[0].ArchiveFile))
+			assert.FileExists(t, report.Candidates[0].ArchiveFile)
+		}
+	})
+
+	t.Run("archives of chains that aren't removed are deleted", func(t *testing.T) {
+		dir := testDirectory(t)
+		defer os.RemoveAll(dir)
+
+		client := defaultConsentStore()
+		defer client.Shutdown()
+		client.Config.RetentionArchiveDir = dir
+		client.retentionMode = RetentionModeArchive
+		client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+		client.RecordConsent(context.TODO(), []PatientConsent{
+			expiredPatientConsent("subject", "custodian", "resource", 60*day),
+			expiredPatientConsent("other", "custodian", "resource", 60*day),
+		})
+
+		// removing the second chain fails, after the first chain has been archived
+		deletes := 0
+		client.Db.Callback().Delete().Before("gorm:delete").Register("test:fail", func(scope *gorm.Scope) {
+			if deletes++; deletes == 3 {
+				scope.Err(errors.New("disk I/O error"))
+			}
+		})
+
+		_, err := client.ApplyRetention(context.TODO(), time.Now(), false)
+
+		assert.Error(t, err)
+		files, _ := ioutil.ReadDir(dir)
+		assert.Empty(t, files)
+		var count int
+		client.Db.Model(&ConsentRecord{}).Count(&count)
+		assert.Equal(t, 2, count)
+	})
+}
+
+func TestConsentStoreConfig_retentionPolicies(t *testing.T) {
+	t.Run("parses default period and policies", func(t *testing.T) {
+		policies, err := ConsentStoreConfig{
+			RetentionPeriod:   "3650d",
+			RetentionPolicies: "dataClass=medical;period=30d,custodian=c;period=1h",
+		}.retentionPolicies()
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []RetentionPolicy{
+				{Period: 3650 * day},
+				{DataClass: "medical", Period: 30 * day},
+				{Custodian: "c", Period: time.Hour},
+			}, policies)
+		}
+	})
+
+	t.Run("returns error for policy without dataClass or custodian", func(t *testing.T) {
+		_, err := ConsentStoreConfig{RetentionPolicies: "period=30d"}.retentionPolicies()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("returns error for invalid period", func(t *testing.T) {
+		_, err := ConsentStoreConfig{RetentionPolicies: "dataClass=medical;period=soon"}.retentionPolicies()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConsentStoreConfig_retentionMode(t *testing.T) {
+	t.Run("defaults to purge", func(t *testing.T) {
+		mode, err := ConsentStoreConfig{}.retentionMode()
+
+		assert.NoError(t, err)
+		assert.Equal(t, RetentionModePurge, mode)
+	})
+
+	t.Run("archive requires a directory", func(t *testing.T) {
+		_, err := ConsentStoreConfig{RetentionMode: "archive"}.retentionMode()
+
+		assert.Equal(t, ErrorMissingArchiveDir, err)
+	})
+
+	t.Run("returns error for unknown mode", func(t *testing.T) {
+		_, err := ConsentStoreConfig{RetentionMode: "shred"}.retentionMode()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConsentStore_retentionJob(t *testing.T) {
+	client := defaultConsentStore()
+	client.retentionPolicies = []RetentionPolicy{{Period: 30 * day}}
+	client.RecordConsent(context.TODO(), []PatientConsent{expiredPatientConsent("subject", "custodian", "resource", 60*day)})
+
+	client.startRetentionJob(10 * time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		entries, _ := client.AuditEntries(context.TODO(), "subject")
+		return len(entries) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	client.Shutdown()
+	assert.Nil(t, client.retentionJob)
+}
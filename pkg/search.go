@@ -0,0 +1,175 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DateComparator tells how a DateRange is compared with a date of a record, the values are the FHIR search prefixes
+type DateComparator string
+
+const (
+	// DateEqual matches when the date of the record falls within the range, or for a period when it overlaps the range
+	DateEqual DateComparator = "eq"
+	// DateApproximately is treated as DateEqual
+	DateApproximately DateComparator = "ap"
+	// DateGreater matches when the record has a date after the range
+	DateGreater DateComparator = "gt"
+	// DateGreaterOrEqual matches when the record has a date within or after the range
+	DateGreaterOrEqual DateComparator = "ge"
+	// DateLess matches when the record has a date before the range
+	DateLess DateComparator = "lt"
+	// DateLessOrEqual matches when the record has a date before or within the range
+	DateLessOrEqual DateComparator = "le"
+	// DateStartsAfter matches when the record starts after the range
+	DateStartsAfter DateComparator = "sa"
+	// DateEndsBefore matches when the record ends before the range
+	DateEndsBefore DateComparator = "eb"
+)
+
+// DateRange is a date search value. Start and End are the range implied by the precision of the value, End is exclusive.
+type DateRange struct {
+	Comparator DateComparator
+	Start      time.Time
+	End        time.Time
+}
+
+// ConsentSearch holds the filters of SearchConsent, all filters are optional and combined with AND
+type ConsentSearch struct {
+	Actor     *string
+	Custodian *string
+	Subject   *string
+	// DataClass matches records holding the data class
+	DataClass *string
+	// Period matches the validity of the records
+	Period []DateRange
+	// LastUpdated matches the moment the records were stored
+	LastUpdated []DateRange
+	// Offset and Limit select a page of the results, a Limit of 0 only counts the results
+	Offset int
+	Limit  int
+}
+
+// SearchConsent finds the latest, not deleted, record of every chain matching the search. All filters are applied in the query.
+// It returns a page of the results ordered by the moment the records were stored, grouped by patient consent, and the total number of matching records.
+func (cs *ConsentStore) SearchConsent(ctx context.Context, search ConsentSearch) ([]PatientConsent, int, error) {
+	var pc PatientConsent
+	if search.Actor != nil {
+		pc.Actor = *search.Actor
+	}
+	if search.Custodian != nil {
+		pc.Custodian = *search.Custodian
+	}
+	if search.Subject != nil {
+		pc.Subject = *search.Subject
+	}
+
+	query := cs.db(ctx).Debug().
+		Table("consent_record").
+		Where("id IN (?)", latestRecords(cs.db(ctx), pc))
+
+	if search.DataClass != nil {
+		query = query.Where("id IN (SELECT consent_record_id FROM data_class WHERE code = ?)", *search.DataClass)
+	}
+
+	for _, r := range search.Period {
+		condition, args, err := periodCondition(r)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where(condition, args...)
+	}
+
+	for _, r := range search.LastUpdated {
+		condition, args, err := instantCondition("created_at", r)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where(condition, args...)
+	}
+
+	var total int
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if search.Limit <= 0 || search.Offset >= total {
+		return []PatientConsent{}, total, nil
+	}
+
+	var ids []uint
+	if err := query.Order("id").Offset(search.Offset).Limit(search.Limit).Pluck("id", &ids).Error; err != nil {
+		return nil, 0, err
+	}
+
+	consent, err := cs.patientConsentByConsentRecord(ctx, ids, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// records are added in order of ID, so the first record of a consent is stored first
+	sort.Slice(consent, func(i, j int) bool {
+		return consent[i].Records[0].ID < consent[j].Records[0].ID
+	})
+
+	return consent, total, nil
+}
+
+// periodCondition returns the condition comparing the validity of a record, which is open ended without valid_to, with the range
+func periodCondition(r DateRange) (string, []interface{}, error) {
+	switch r.Comparator {
+	case DateEqual, DateApproximately, "":
+		return "julianday(valid_from) < julianday(?) AND (valid_to IS NULL OR julianday(valid_to) > julianday(?))", []interface{}{r.End, r.Start}, nil
+	case DateGreater:
+		return "(valid_to IS NULL OR julianday(valid_to) > julianday(?))", []interface{}{r.End}, nil
+	case DateGreaterOrEqual:
+		return "(valid_to IS NULL OR julianday(valid_to) > julianday(?))", []interface{}{r.Start}, nil
+	case DateLess:
+		return "julianday(valid_from) < julianday(?)", []interface{}{r.Start}, nil
+	case DateLessOrEqual:
+		return "julianday(valid_from) < julianday(?)", []interface{}{r.End}, nil
+	case DateStartsAfter:
+		return "julianday(valid_from) >= julianday(?)", []interface{}{r.End}, nil
+	case DateEndsBefore:
+		return "valid_to IS NOT NULL AND julianday(valid_to) <= julianday(?)", []interface{}{r.Start}, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported date comparator %s", r.Comparator)
+}
+
+// instantCondition returns the condition comparing a single moment of a record with the range
+func instantCondition(column string, r DateRange) (string, []interface{}, error) {
+	switch r.Comparator {
+	case DateEqual, DateApproximately, "":
+		return "julianday(" + column + ") >= julianday(?) AND julianday(" + column + ") < julianday(?)", []interface{}{r.Start, r.End}, nil
+	case DateGreater, DateStartsAfter:
+		return "julianday(" + column + ") >= julianday(?)", []interface{}{r.End}, nil
+	case DateGreaterOrEqual:
+		return "julianday(" + column + ") >= julianday(?)", []interface{}{r.Start}, nil
+	case DateLess, DateEndsBefore:
+		return "julianday(" + column + ") < julianday(?)", []interface{}{r.Start}, nil
+	case DateLessOrEqual:
+		return "julianday(" + column + ") < julianday(?)", []interface{}{r.End}, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported date comparator %s", r.Comparator)
+}
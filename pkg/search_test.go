@@ -0,0 +1,124 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStore_SearchConsent(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	first := endingPatientConsent("first", "actor", day)
+	second := endingPatientConsent("second", "actor", 10*day)
+	second.Records[0].DataClasses = []DataClass{{Code: "medical"}}
+	open := endingPatientConsent("open", "other", day)
+	open.Records[0].ValidTo = nil
+	for _, pc := range []PatientConsent{first, second, open} {
+		if err := client.RecordConsent(context.TODO(), []PatientConsent{pc}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a new version replaces the first record
+	next := first
+	next.Records = []ConsentRecord{{
+		ValidFrom:    time.Now().Add(-day),
+		Hash:         random.String(8),
+		PreviousHash: &first.Records[0].Hash,
+		DataClasses:  []DataClass{{Code: "resource"}},
+	}}
+	client.RecordConsent(context.TODO(), []PatientConsent{next})
+
+	subjects := func(consent []PatientConsent) []string {
+		var s []string
+		for _, pc := range consent {
+			s = append(s, pc.Subject)
+		}
+		return s
+	}
+	at := func(c DateComparator, t time.Time) DateRange {
+		return DateRange{Comparator: c, Start: t, End: t.Add(time.Second)}
+	}
+	actor := "actor"
+	dataClass := "medical"
+
+	t.Run("returns the latest record of every chain, first stored first", func(t *testing.T) {
+		consent, total, err := client.SearchConsent(context.TODO(), ConsentSearch{Limit: 10})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 3, total)
+			assert.Equal(t, []string{"second", "open", "first"}, subjects(consent))
+			assert.Equal(t, next.Records[0].Hash, consent[2].Records[0].Hash)
+			assert.False(t, consent[2].Records[0].CreatedAt.IsZero())
+		}
+	})
+
+	t.Run("filters on actor and data class", func(t *testing.T) {
+		consent, total, _ := client.SearchConsent(context.TODO(), ConsentSearch{Actor: &actor, Limit: 10})
+		assert.Equal(t, 2, total)
+		assert.Len(t, consent, 2)
+
+		consent, total, _ = client.SearchConsent(context.TODO(), ConsentSearch{DataClass: &dataClass, Limit: 10})
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []string{"second"}, subjects(consent))
+	})
+
+	t.Run("filters on period", func(t *testing.T) {
+		in20Days := time.Now().Add(20 * day)
+
+		consent, _, _ := client.SearchConsent(context.TODO(), ConsentSearch{Period: []DateRange{at(DateEqual, in20Days)}, Limit: 10})
+		assert.Equal(t, []string{"open", "first"}, subjects(consent))
+
+		consent, _, _ = client.SearchConsent(context.TODO(), ConsentSearch{Period: []DateRange{at(DateEndsBefore, in20Days)}, Limit: 10})
+		assert.Equal(t, []string{"second"}, subjects(consent))
+
+		consent, _, _ = client.SearchConsent(context.TODO(), ConsentSearch{Period: []DateRange{at(DateStartsAfter, time.Now())}, Limit: 10})
+		assert.Empty(t, consent)
+	})
+
+	t.Run("filters on last updated", func(t *testing.T) {
+		_, total, _ := client.SearchConsent(context.TODO(), ConsentSearch{LastUpdated: []DateRange{at(DateGreaterOrEqual, time.Now().Add(-time.Hour))}, Limit: 10})
+		assert.Equal(t, 3, total)
+
+		_, total, _ = client.SearchConsent(context.TODO(), ConsentSearch{LastUpdated: []DateRange{at(DateLess, time.Now().Add(-time.Hour))}, Limit: 10})
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		consent, total, _ := client.SearchConsent(context.TODO(), ConsentSearch{Offset: 1, Limit: 1})
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []string{"open"}, subjects(consent))
+
+		consent, total, _ = client.SearchConsent(context.TODO(), ConsentSearch{})
+		assert.Equal(t, 3, total)
+		assert.Empty(t, consent)
+	})
+
+	t.Run("unsupported comparator", func(t *testing.T) {
+		_, _, err := client.SearchConsent(context.TODO(), ConsentSearch{Period: []DateRange{at("ne", time.Now())}, Limit: 10})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,117 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrorMissingSigningKey is returned when a document has to be signed, but no certificate and key are configured
+var ErrorMissingSigningKey = errors.New("tlsCertFile and tlsKeyFile must be configured to sign documents")
+
+// ErrorInvalidSignature is returned when the signature of a SignedDocument doesn't match
+var ErrorInvalidSignature = errors.New("invalid signature")
+
+const (
+	algorithmES256 = "ES256"
+	algorithmRS256 = "RS256"
+)
+
+// SignedDocument is a JSON document with a detached signature over the exact document bytes.
+// The signature is made with the key of the configured TLS certificate, which is included DER encoded.
+type SignedDocument struct {
+	Document    json.RawMessage `json:"document"`
+	Algorithm   string          `json:"algorithm"`
+	Signature   []byte          `json:"signature"`
+	Certificate []byte          `json:"certificate"`
+}
+
+// SignDocument marshals the document to JSON and signs it with the configured TLS key
+func (c ConsentStoreConfig) SignDocument(document interface{}) (SignedDocument, error) {
+	if c.TlsCertFile == "" || c.TlsKeyFile == "" {
+		return SignedDocument{}, ErrorMissingSigningKey
+	}
+
+	cert, err := c.loadKeyPair()
+	if err != nil {
+		return SignedDocument{}, err
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return SignedDocument{}, err
+	}
+	digest := sha256.Sum256(data)
+
+	sd := SignedDocument{
+		Document:    data,
+		Certificate: cert.Certificate[0],
+	}
+
+	switch cert.PrivateKey.(type) {
+	case *ecdsa.PrivateKey:
+		sd.Algorithm = algorithmES256
+	case *rsa.PrivateKey:
+		sd.Algorithm = algorithmRS256
+	default:
+		return SignedDocument{}, fmt.Errorf("unsupported signing key type %T", cert.PrivateKey)
+	}
+
+	// ECDSA keys produce an ASN.1 encoded signature, RSA keys a PKCS #1 v1.5 signature
+	sd.Signature, err = cert.PrivateKey.(crypto.Signer).Sign(rand.Reader, digest[:], crypto.SHA256)
+
+	return sd, err
+}
+
+// Verify checks the signature against the public key of the included certificate.
+// It doesn't verify the certificate itself, that's up to the receiver.
+func (sd SignedDocument) Verify() error {
+	cert, err := x509.ParseCertificate(sd.Certificate)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(sd.Document)
+
+	switch key := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		var sig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sd.Signature, &sig); err != nil {
+			return ErrorInvalidSignature
+		}
+		if sd.Algorithm == algorithmES256 && ecdsa.Verify(key, digest[:], sig.R, sig.S) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if sd.Algorithm == algorithmRS256 && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sd.Signature) == nil {
+			return nil
+		}
+	}
+
+	return ErrorInvalidSignature
+}
@@ -0,0 +1,67 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStoreConfig_SignDocument(t *testing.T) {
+	dir := testDirectory(t)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeTestCertificate(t, dir)
+	config := ConsentStoreConfig{TlsCertFile: certFile, TlsKeyFile: keyFile}
+
+	t.Run("signed document can be verified", func(t *testing.T) {
+		sd, err := config.SignDocument(map[string]string{"subject": "subject"})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "ES256", sd.Algorithm)
+			assert.JSONEq(t, `{"subject":"subject"}`, string(sd.Document))
+			assert.NoError(t, sd.Verify())
+		}
+	})
+
+	t.Run("signature survives JSON roundtrip", func(t *testing.T) {
+		sd, _ := config.SignDocument(map[string]string{"subject": "subject"})
+		data, _ := json.Marshal(sd)
+
+		var received SignedDocument
+		if assert.NoError(t, json.Unmarshal(data, &received)) {
+			assert.NoError(t, received.Verify())
+		}
+	})
+
+	t.Run("tampered document fails verification", func(t *testing.T) {
+		sd, _ := config.SignDocument(map[string]string{"subject": "subject"})
+		sd.Document = []byte(`{"subject":"other"}`)
+
+		assert.Equal(t, ErrorInvalidSignature, sd.Verify())
+	})
+
+	t.Run("returns error without key", func(t *testing.T) {
+		_, err := ConsentStoreConfig{}.SignDocument(map[string]string{})
+
+		assert.Equal(t, ErrorMissingSigningKey, err)
+	})
+}
@@ -0,0 +1,116 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// ErrorIncompleteTLSConfig is returned when only one of the certificate and key file is configured
+var ErrorIncompleteTLSConfig = errors.New("both tlsCertFile and tlsKeyFile must be configured")
+
+// ServerTLSConfig returns the TLS config for the standalone server. It returns nil when no certificate and CA are configured.
+// When a CA file is configured, clients are required to present a certificate signed by that CA (mTLS).
+// A CA file without certificate is an error, rather than a server silently accepting plain http.
+func (c ConsentStoreConfig) ServerTLSConfig() (*tls.Config, error) {
+	if c.TlsCertFile == "" && c.TlsKeyFile == "" && c.TlsCAFile == "" {
+		return nil, nil
+	}
+
+	cert, err := c.loadKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if c.TlsCAFile != "" {
+		pool, err := loadCertPool(c.TlsCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// ClientTLSConfig returns the TLS config used by the HttpClient. It returns nil when neither a CA nor a client certificate is configured.
+func (c ConsentStoreConfig) ClientTLSConfig() (*tls.Config, error) {
+	if c.TlsCAFile == "" && c.TlsCertFile == "" && c.TlsKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.TlsCAFile != "" {
+		pool, err := loadCertPool(c.TlsCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.TlsCertFile != "" || c.TlsKeyFile != "" {
+		cert, err := c.loadKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (c ConsentStoreConfig) loadKeyPair() (tls.Certificate, error) {
+	if c.TlsCertFile == "" || c.TlsKeyFile == "" {
+		return tls.Certificate{}, ErrorIncompleteTLSConfig
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.TlsCertFile, c.TlsKeyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to load TLS key pair: %w", err)
+	}
+
+	return cert, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,173 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentStoreConfig_ServerTLSConfig(t *testing.T) {
+	dir := testDirectory(t)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	t.Run("returns nil without certificate", func(t *testing.T) {
+		cfg, err := ConsentStoreConfig{}.ServerTLSConfig()
+
+		assert.NoError(t, err)
+		assert.Nil(t, cfg)
+	})
+
+	t.Run("returns config with certificate", func(t *testing.T) {
+		cfg, err := ConsentStoreConfig{TlsCertFile: certFile, TlsKeyFile: keyFile}.ServerTLSConfig()
+
+		if assert.NoError(t, err) {
+			assert.Len(t, cfg.Certificates, 1)
+			assert.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+		}
+	})
+
+	t.Run("requires client certificates when CA is configured", func(t *testing.T) {
+		cfg, err := ConsentStoreConfig{TlsCertFile: certFile, TlsKeyFile: keyFile, TlsCAFile: certFile}.ServerTLSConfig()
+
+		if assert.NoError(t, err) {
+			assert.NotNil(t, cfg.ClientCAs)
+			assert.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+		}
+	})
+
+	t.Run("returns error for missing key", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TlsCertFile: certFile}.ServerTLSConfig()
+
+		assert.True(t, errors.Is(err, ErrorIncompleteTLSConfig))
+	})
+
+	t.Run("returns error for CA without certificate", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TlsCAFile: certFile}.ServerTLSConfig()
+
+		assert.True(t, errors.Is(err, ErrorIncompleteTLSConfig))
+	})
+
+	t.Run("returns error for invalid CA file", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TlsCertFile: certFile, TlsKeyFile: keyFile, TlsCAFile: keyFile}.ServerTLSConfig()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConsentStoreConfig_ClientTLSConfig(t *testing.T) {
+	dir := testDirectory(t)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	t.Run("returns nil without TLS config", func(t *testing.T) {
+		cfg, err := ConsentStoreConfig{}.ClientTLSConfig()
+
+		assert.NoError(t, err)
+		assert.Nil(t, cfg)
+	})
+
+	t.Run("returns config with CA only", func(t *testing.T) {
+		cfg, err := ConsentStoreConfig{TlsCAFile: certFile}.ClientTLSConfig()
+
+		if assert.NoError(t, err) {
+			assert.NotNil(t, cfg.RootCAs)
+			assert.Empty(t, cfg.Certificates)
+		}
+	})
+
+	t.Run("returns config with client certificate", func(t *testing.T) {
+		cfg, err := ConsentStoreConfig{TlsCAFile: certFile, TlsCertFile: certFile, TlsKeyFile: keyFile}.ClientTLSConfig()
+
+		if assert.NoError(t, err) {
+			assert.Len(t, cfg.Certificates, 1)
+		}
+	})
+
+	t.Run("returns error for unknown CA file", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TlsCAFile: filepath.Join(dir, "unknown.pem")}.ClientTLSConfig()
+
+		assert.Error(t, err)
+	})
+}
+
+func testDirectory(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "consent-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeTestCertificate writes a self-signed certificate and its key to the given directory
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
@@ -0,0 +1,231 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingExporter defines where the spans of the consent store are exported to
+type TracingExporter string
+
+const (
+	// TracingExporterNone disables tracing
+	TracingExporterNone TracingExporter = "none"
+	// TracingExporterOTLP sends the spans to an OTLP/HTTP collector
+	TracingExporterOTLP TracingExporter = "otlp"
+	// TracingExporterStdout writes the spans as JSON to stdout
+	TracingExporterStdout TracingExporter = "stdout"
+	// TracingExporterFile writes the spans as JSON to the tracing file
+	TracingExporterFile TracingExporter = "file"
+)
+
+// tracerName is the instrumentation name of the spans of the consent store
+const tracerName = "github.com/nuts-foundation/nuts-consent-store"
+
+// serviceName is the name of the service in the exported spans
+const serviceName = "nuts-consent-store"
+
+// the keys of the values gorm passes to the tracing callbacks
+const (
+	gormContextKey = "tracing:context"
+	gormSpanKey    = "tracing:span"
+)
+
+// ErrorMissingTracingFile is returned when the file tracing exporter is configured without a file
+var ErrorMissingTracingFile = errors.New("tracingFile must be configured for the file tracing exporter")
+
+// Tracer returns the tracer of the consent store. It uses the global tracer provider, which is set in Configure when tracing is enabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// tracerProvider creates the tracer provider for the configured exporter, nil when tracing is disabled
+func (c ConsentStoreConfig) tracerProvider() (*sdktrace.TracerProvider, error) {
+	exporter, err := c.spanExporter()
+	if err != nil || exporter == nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	), nil
+}
+
+func (c ConsentStoreConfig) spanExporter() (sdktrace.SpanExporter, error) {
+	switch TracingExporter(c.TracingExporter) {
+	case "", TracingExporterNone:
+		return nil, nil
+	case TracingExporterOTLP:
+		var opts []otlptracehttp.Option
+		// without endpoint, the exporter uses the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost:4318
+		if c.TracingEndpoint != "" {
+			u, err := url.Parse(c.TracingEndpoint)
+			if err != nil || u.Host == "" {
+				return nil, fmt.Errorf("invalid %s, expected a URL like http://localhost:4318: %s", ConfigTracingEndpoint, c.TracingEndpoint)
+			}
+			opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+			if u.Scheme == "http" {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			if u.Path != "" && u.Path != "/" {
+				opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+			}
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	case TracingExporterStdout:
+		return stdouttrace.New()
+	case TracingExporterFile:
+		if c.TracingFile == "" {
+			return nil, ErrorMissingTracingFile
+		}
+		f, err := os.OpenFile(c.TracingFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return fileExporter{exporter, f}, nil
+	}
+	return nil, fmt.Errorf("invalid %s: %s", ConfigTracingExporter, c.TracingExporter)
+}
+
+// fileExporter closes the tracing file when the exporter is shut down
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e fileExporter) Shutdown(ctx context.Context) error {
+	if err := e.SpanExporter.Shutdown(ctx); err != nil {
+		return err
+	}
+	return e.file.Close()
+}
+
+// shutdownTracing exports the remaining spans
+func (cs *ConsentStore) shutdownTracing() {
+	if cs.tracerProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := cs.tracerProvider.Shutdown(ctx); err != nil {
+		Logger().Errorf("Error exporting spans: %v", err)
+	}
+}
+
+// startOperation starts the span of a ConsentStoreClient operation. The returned func ends the span and records the metrics of the operation,
+// it's meant to be deferred with the error of the operation.
+func (cs *ConsentStore) startOperation(ctx context.Context, operation string) (context.Context, func(*error)) {
+	start := time.Now()
+	ctx, span := Tracer().Start(ctx, "ConsentStore."+operation)
+
+	return ctx, func(err *error) {
+		if *err != nil {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+		span.End()
+		cs.Metrics.observe(operation, start, err)
+	}
+}
+
+// db returns the db connection for statements on behalf of the given context, the statements are traced as child spans of the context
+func (cs *ConsentStore) db(ctx context.Context) *gorm.DB {
+	return cs.Db.Set(gormContextKey, ctx)
+}
+
+// registerTracingCallbacks adds the gorm callbacks that create a span for every statement executed through ConsentStore.db
+func registerTracingCallbacks(db *gorm.DB) {
+	c := db.Callback()
+	c.Create().Before("gorm:begin_transaction").Register("tracing:before_create", startStatementSpan("INSERT"))
+	c.Create().After("gorm:commit_or_rollback_transaction").Register("tracing:after_create", endStatementSpan)
+	c.Query().Before("gorm:query").Register("tracing:before_query", startStatementSpan("SELECT"))
+	c.Query().After("gorm:after_query").Register("tracing:after_query", endStatementSpan)
+	c.Update().Before("gorm:begin_transaction").Register("tracing:before_update", startStatementSpan("UPDATE"))
+	c.Update().After("gorm:commit_or_rollback_transaction").Register("tracing:after_update", endStatementSpan)
+	c.Delete().Before("gorm:begin_transaction").Register("tracing:before_delete", startStatementSpan("DELETE"))
+	c.Delete().After("gorm:commit_or_rollback_transaction").Register("tracing:after_delete", endStatementSpan)
+	c.RowQuery().Before("gorm:row_query").Register("tracing:before_row_query", startStatementSpan("SELECT"))
+	c.RowQuery().After("gorm:row_query").Register("tracing:after_row_query", endStatementSpan)
+}
+
+func startStatementSpan(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		v, ok := scope.Get(gormContextKey)
+		if !ok {
+			return
+		}
+		ctx, ok := v.(context.Context)
+		if !ok {
+			return
+		}
+
+		name := operation
+		table := scope.TableName()
+		if table != "" {
+			name += " " + table
+		}
+		_, span := Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemSqlite, semconv.DBOperationKey.String(operation), semconv.DBSQLTableKey.String(table)),
+		)
+		scope.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endStatementSpan(scope *gorm.Scope) {
+	v, ok := scope.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+
+	// the statement holds placeholders, the values are left out since they contain patient identifiers
+	span.SetAttributes(semconv.DBStatementKey.String(scope.SQL))
+	if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
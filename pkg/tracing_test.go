@@ -0,0 +1,171 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+// spanRecorder sets a global tracer provider that records the spans in memory
+func spanRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+// findSpan returns the first span with the given name
+func findSpan(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, s := range spans {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestConsentStore_tracing(t *testing.T) {
+	recorder := spanRecorder()
+	client := defaultConsentStore()
+	defer client.Shutdown()
+
+	ctx, request := Tracer().Start(context.Background(), "request")
+	if err := client.RecordConsent(ctx, []PatientConsent{endingPatientConsent("subject", "actor", day)}); err != nil {
+		t.Fatal(err)
+	}
+	client.FindConsentRecordByHash(ctx, "unknown", false)
+	request.End()
+
+	spans := recorder.Ended()
+	record := findSpan(spans, "ConsentStore."+operationRecordConsent)
+	find := findSpan(spans, "ConsentStore."+operationFindConsentRecordByHash)
+	if !assert.NotNil(t, record) || !assert.NotNil(t, find) {
+		return
+	}
+
+	t.Run("operations are children of the span of the context", func(t *testing.T) {
+		assert.Equal(t, request.SpanContext().SpanID(), record.Parent().SpanID())
+		assert.Equal(t, request.SpanContext().TraceID(), record.SpanContext().TraceID())
+		assert.Equal(t, codes.Unset, record.Status().Code)
+	})
+
+	t.Run("statements are children of the operation", func(t *testing.T) {
+		insert := findSpan(spans, "INSERT consent_record")
+		if !assert.NotNil(t, insert) {
+			return
+		}
+
+		assert.Equal(t, record.SpanContext().SpanID(), insert.Parent().SpanID())
+		for _, a := range insert.Attributes() {
+			if a.Key == semconv.DBStatementKey {
+				assert.True(t, strings.HasPrefix(a.Value.AsString(), "INSERT INTO \"consent_record\""))
+				return
+			}
+		}
+		t.Error("statement not recorded")
+	})
+
+	t.Run("failed operation", func(t *testing.T) {
+		assert.Equal(t, codes.Error, find.Status().Code)
+
+		// not finding a record isn't an error of the statement
+		for _, s := range spans {
+			if s.Parent().SpanID() == find.SpanContext().SpanID() {
+				assert.Equal(t, codes.Unset, s.Status().Code)
+			}
+		}
+	})
+
+	t.Run("statements without context aren't traced", func(t *testing.T) {
+		n := len(recorder.Ended())
+
+		client.Db.Find(&[]PatientConsent{})
+
+		assert.Len(t, recorder.Ended(), n)
+	})
+}
+
+func TestConsentStoreConfig_tracerProvider(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		for _, exporter := range []string{"", string(TracingExporterNone)} {
+			tp, err := ConsentStoreConfig{TracingExporter: exporter}.tracerProvider()
+
+			assert.NoError(t, err)
+			assert.Nil(t, tp)
+		}
+	})
+
+	t.Run("otlp", func(t *testing.T) {
+		tp, err := ConsentStoreConfig{TracingExporter: string(TracingExporterOTLP), TracingEndpoint: "http://localhost:4318"}.tracerProvider()
+
+		if assert.NoError(t, err) && assert.NotNil(t, tp) {
+			tp.Shutdown(context.Background())
+		}
+	})
+
+	t.Run("otlp with invalid endpoint", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TracingExporter: string(TracingExporterOTLP), TracingEndpoint: "localhost"}.tracerProvider()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "consent-store")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "spans.json")
+
+		tp, err := ConsentStoreConfig{TracingExporter: string(TracingExporterFile), TracingFile: file}.tracerProvider()
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, span := tp.Tracer(tracerName).Start(context.Background(), "span")
+		span.End()
+		assert.NoError(t, tp.Shutdown(context.Background()))
+
+		data, _ := ioutil.ReadFile(file)
+		assert.Contains(t, string(data), `"Name":"span"`)
+	})
+
+	t.Run("file without file", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TracingExporter: string(TracingExporterFile)}.tracerProvider()
+
+		assert.True(t, errors.Is(err, ErrorMissingTracingFile))
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := ConsentStoreConfig{TracingExporter: "jaeger"}.tracerProvider()
+
+		assert.Error(t, err)
+	})
+}
@@ -57,16 +57,26 @@ func (pc PatientConsent) DataClasses() []DataClass {
 // ConsentRecord represents the individual records/attachments for a PatientConsent
 // Changes to ConsentRecords are chained by PreviousHash pointing to Hash. All member of the chain can be found by the UUID
 // The UUID remains internal
+// Deleted records are kept as tombstone: DeletedAt, DeletedReason and DeletedBy are set and the record is ignored by all reads by default.
+// ExpiringNotifiedAt and ExpiredNotifiedAt are set when the expiry scheduler emitted the corresponding event, they remain internal.
+// CreatedAt is set when the record is stored.
+// When recording, PreviousHash must be the head of the chain and a Version other than 0 must be the next version of the chain, otherwise a ConflictError is returned.
 type ConsentRecord struct {
-	ID               uint `gorm:"AUTO_INCREMENT"`
-	PatientConsentID string
-	ValidFrom        time.Time `gorm:"not null"`
-	ValidTo          *time.Time
-	Hash             string `gorm:"not null"`
-	PreviousHash     *string
-	Version          uint   `gorm:"DEFAULT:1"`
-	UUID             string `gorm:"column:uuid;not null"`
-	DataClasses      []DataClass
+	ID                 uint `gorm:"AUTO_INCREMENT"`
+	PatientConsentID   string
+	ValidFrom          time.Time `gorm:"not null"`
+	ValidTo            *time.Time
+	Hash               string `gorm:"not null"`
+	PreviousHash       *string
+	Version            uint   `gorm:"DEFAULT:1"`
+	UUID               string `gorm:"column:uuid;not null"`
+	DataClasses        []DataClass
+	DeletedAt          *time.Time
+	DeletedReason      *string
+	DeletedBy          *string
+	ExpiringNotifiedAt *time.Time
+	ExpiredNotifiedAt  *time.Time
+	CreatedAt          time.Time
 }
 
 // TableName returns the SQL table for this type
@@ -74,7 +84,22 @@ func (ConsentRecord) TableName() string {
 	return "consent_record"
 }
 
-// BeforeDelete makes sure the DataClasses of a ConsentRecords gets deleted too
+// IsDeleted returns true if the record has been replaced by a tombstone
+func (cr ConsentRecord) IsDeleted() bool {
+	return cr.DeletedAt != nil
+}
+
+// hasDataClass returns true if the record holds the data class with the given code
+func (cr ConsentRecord) hasDataClass(code string) bool {
+	for _, dc := range cr.DataClasses {
+		if dc.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// BeforeDelete makes sure the DataClasses of a ConsentRecords gets deleted too when the record is purged
 func (cr *ConsentRecord) BeforeDelete(tx *gorm.DB) (err error) {
 	return tx.Delete(DataClass{}, "consent_record_id = ?", cr.ID).Error
 }
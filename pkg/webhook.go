@@ -0,0 +1,379 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// WebhookDeliveryStatus is the state of a single webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the status of deliveries that haven't succeeded yet but will be retried
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered is the status of deliveries that were accepted by the receiver
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead is the status of deliveries that failed too often, they're only retried when replayed
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+const (
+	// WebhookSignatureHeader holds the HMAC-SHA256 signature of the request, see SignWebhookPayload
+	WebhookSignatureHeader = "X-Nuts-Signature"
+	// WebhookTimestampHeader holds the unix time at which the request was signed
+	WebhookTimestampHeader = "X-Nuts-Timestamp"
+	// WebhookEventTypeHeader holds the type of the event in the body
+	WebhookEventTypeHeader = "X-Nuts-Event-Type"
+	// WebhookDeliveryHeader holds the ID of the delivery, it's the same for every attempt
+	WebhookDeliveryHeader = "X-Nuts-Delivery"
+)
+
+var (
+	// webhookPollInterval is the interval at which the worker looks for deliveries that are due
+	webhookPollInterval = 5 * time.Second
+	// webhookRetryDelay is the delay after the first failed attempt, it doubles with every attempt
+	webhookRetryDelay = 10 * time.Second
+	// webhookMaxRetryDelay caps the delay between attempts
+	webhookMaxRetryDelay = time.Hour
+	// webhookTimeout is the timeout of a single delivery attempt
+	webhookTimeout = 10 * time.Second
+	// webhookDeliveredRetention is the period delivered deliveries are kept for inspection
+	webhookDeliveredRetention = 7 * 24 * time.Hour
+)
+
+// webhookBatchSize is the maximum number of deliveries attempted in a single run of the worker
+const webhookBatchSize = 100
+
+// Webhook is an endpoint that receives consent events. Custodian and Actor are optional filters on the events.
+type Webhook struct {
+	URL       string
+	Secret    string
+	Custodian string
+	Actor     string
+}
+
+// matches returns true if the event must be delivered to the webhook
+func (w Webhook) matches(e Event) bool {
+	return (w.Custodian == "" || w.Custodian == e.Custodian) && (w.Actor == "" || w.Actor == e.Actor)
+}
+
+// WebhookOutboxEntry is an event waiting to be delivered, it's written in the same transaction as the consent change.
+// Payload holds the JSON encoded Event, its ID is the ID of the outbox entry.
+type WebhookOutboxEntry struct {
+	ID        uint      `gorm:"AUTO_INCREMENT"`
+	CreatedAt time.Time `gorm:"not null"`
+	EventType string    `gorm:"not null"`
+	Payload   string    `gorm:"not null"`
+}
+
+// TableName returns the SQL table for this type
+func (WebhookOutboxEntry) TableName() string {
+	return "webhook_outbox"
+}
+
+// WebhookDelivery tracks the delivery of an outbox entry to a single webhook
+type WebhookDelivery struct {
+	ID            uint `gorm:"AUTO_INCREMENT"`
+	OutboxID      uint `gorm:"not null"`
+	Outbox        WebhookOutboxEntry
+	URL           string                `gorm:"column:url;not null"`
+	Status        WebhookDeliveryStatus `gorm:"not null"`
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastAttemptAt *time.Time
+	LastError     *string
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the SQL table for this type
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+// webhooks parses the configured webhooks
+func (c ConsentStoreConfig) webhooks() ([]Webhook, error) {
+	entries, err := parseConfigEntries(c.Webhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []Webhook
+	for _, e := range entries {
+		w := Webhook{
+			URL:       e["url"],
+			Secret:    e["secret"],
+			Custodian: e["custodian"],
+			Actor:     e["actor"],
+		}
+		if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid url in webhook %s", w.URL)
+		}
+		if w.Secret == "" {
+			return nil, fmt.Errorf("missing secret in webhook %s", w.URL)
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, nil
+}
+
+// webhookMaxAttempts returns the number of attempts after which a delivery is dead, the default is used when it's not configured
+func (c ConsentStoreConfig) webhookMaxAttempts() int {
+	if c.WebhookMaxAttempts <= 0 {
+		return ConfigWebhookMaxAttemptsDefault
+	}
+	return c.WebhookMaxAttempts
+}
+
+// SignWebhookPayload returns the signature of a webhook request as sent in the X-Nuts-Signature header:
+// the hex encoded HMAC-SHA256 over the timestamp header, a dot and the body, keyed with the secret of the webhook.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeOutbox stores the events for all matching webhooks within the given transaction
+func (cs *ConsentStore) writeOutbox(tx *gorm.DB, events []Event) error {
+	now := time.Now()
+
+	for _, e := range events {
+		var matching []Webhook
+		for _, w := range cs.webhooks {
+			if w.matches(e) {
+				matching = append(matching, w)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		entry := WebhookOutboxEntry{CreatedAt: now, EventType: string(e.Type), Payload: "{}"}
+		if err := tx.Create(&entry).Error; err != nil {
+			return err
+		}
+
+		e.ID = uint64(entry.ID)
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&entry).Update("payload", string(payload)).Error; err != nil {
+			return err
+		}
+
+		for _, w := range matching {
+			delivery := WebhookDelivery{
+				OutboxID:      entry.ID,
+				URL:           w.URL,
+				Status:        WebhookDeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			}
+			if err := tx.Create(&delivery).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeliverWebhooks attempts all pending deliveries that are due. It's called by the background worker and returns the number of attempts.
+func (cs *ConsentStore) DeliverWebhooks(ctx context.Context, now time.Time) (int, error) {
+	var deliveries []WebhookDelivery
+
+	if err := cs.db(ctx).Debug().Preload("Outbox").
+		Where("status = ? AND julianday(next_attempt_at) <= julianday(?)", WebhookDeliveryPending, now).
+		Order("id").Limit(webhookBatchSize).Find(&deliveries).Error; err != nil {
+		return 0, err
+	}
+
+	for _, d := range deliveries {
+		err := cs.deliver(ctx, d)
+
+		update := map[string]interface{}{
+			"attempts":        d.Attempts + 1,
+			"last_attempt_at": time.Now(),
+		}
+		switch {
+		case err == nil:
+			update["status"] = WebhookDeliveryDelivered
+			update["last_error"] = nil
+		case d.Attempts+1 >= cs.Config.webhookMaxAttempts():
+			Logger().Warnf("webhook delivery %d to %s failed permanently: %v", d.ID, d.URL, err)
+			update["status"] = WebhookDeliveryDead
+			update["last_error"] = err.Error()
+		default:
+			update["next_attempt_at"] = time.Now().Add(webhookBackoff(d.Attempts + 1))
+			update["last_error"] = err.Error()
+		}
+
+		if err := cs.db(ctx).Debug().Table(WebhookDelivery{}.TableName()).Where("id = ?", d.ID).Updates(update).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	return len(deliveries), cs.pruneWebhookDeliveries(ctx, now.Add(-webhookDeliveredRetention))
+}
+
+// deliver POSTs the event to the webhook, any response other than 2xx is an error
+func (cs *ConsentStore) deliver(ctx context.Context, d WebhookDelivery) error {
+	var webhook *Webhook
+	for _, w := range cs.webhooks {
+		if w.URL == d.URL {
+			webhook = &w
+			break
+		}
+	}
+	if webhook == nil {
+		return fmt.Errorf("webhook %s is no longer configured", d.URL)
+	}
+
+	body := []byte(d.Outbox.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, timestamp, body))
+	req.Header.Set(WebhookEventTypeHeader, d.Outbox.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(d.ID), 10))
+
+	client := http.Client{Timeout: webhookTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// webhookBackoff returns the delay before the next attempt, after the given number of failed attempts
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookRetryDelay
+	for i := 1; i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxRetryDelay {
+		delay = webhookMaxRetryDelay
+	}
+	return delay
+}
+
+// pruneWebhookDeliveries removes delivered deliveries created before the given time and outbox entries without deliveries
+func (cs *ConsentStore) pruneWebhookDeliveries(ctx context.Context, createdBefore time.Time) error {
+	if err := cs.db(ctx).Debug().Where("status = ? AND julianday(created_at) < julianday(?)", WebhookDeliveryDelivered, createdBefore).
+		Delete(WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+
+	return cs.db(ctx).Debug().Exec("DELETE FROM webhook_outbox WHERE id NOT IN (SELECT outbox_id FROM webhook_delivery)").Error
+}
+
+// WebhookDeliveries returns the deliveries with the given status, or all deliveries when status is empty. Newest first.
+func (cs *ConsentStore) WebhookDeliveries(ctx context.Context, status WebhookDeliveryStatus) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+
+	db := cs.db(ctx).Debug().Preload("Outbox")
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if err := db.Order("id desc").Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ReplayWebhookDelivery resets a delivery to pending, so it's attempted again by the worker with a fresh number of attempts.
+// ErrorNotFound is returned for an unknown delivery.
+func (cs *ConsentStore) ReplayWebhookDelivery(ctx context.Context, id uint) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+
+	if err := cs.db(ctx).Debug().Where("id = ?", id).First(&delivery).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return delivery, ErrorNotFound
+		}
+		return delivery, err
+	}
+
+	update := map[string]interface{}{
+		"status":          WebhookDeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	}
+	if err := cs.db(ctx).Debug().Table(WebhookDelivery{}.TableName()).Where("id = ?", id).Updates(update).Error; err != nil {
+		return delivery, err
+	}
+
+	if cs.webhookJob != nil {
+		cs.webhookJob.Trigger()
+	}
+
+	err := cs.db(ctx).Debug().Preload("Outbox").Where("id = ?", id).First(&delivery).Error
+	return delivery, err
+}
+
+// startWebhookJob delivers the outbox in the background, it's stopped by Shutdown
+func (cs *ConsentStore) startWebhookJob(interval time.Duration) {
+	cs.webhookJob = startJob(interval, func() {
+		if _, err := cs.DeliverWebhooks(context.Background(), time.Now()); err != nil {
+			Logger().Errorf("error delivering webhooks: %v", err)
+		}
+	})
+	// deliver what was left behind by a previous run
+	cs.webhookJob.Trigger()
+}
+
+// stopWebhookJob stops the background job and waits for a running delivery to finish
+func (cs *ConsentStore) stopWebhookJob() {
+	if cs.webhookJob == nil {
+		return
+	}
+
+	cs.webhookJob.Stop()
+	cs.webhookJob = nil
+}
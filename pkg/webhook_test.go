@@ -0,0 +1,250 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookReceiver records the requests it receives and responds with the configured status
+type webhookReceiver struct {
+	mutex    sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	body, _ := ioutil.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) count() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.requests)
+}
+
+func TestConsentStoreConfig_webhooks(t *testing.T) {
+	t.Run("parses entries", func(t *testing.T) {
+		c := ConsentStoreConfig{Webhooks: "url=https://example.com/hook?a=b;secret=s;custodian=c,url=http://localhost:8080;secret=t;actor=a"}
+
+		webhooks, err := c.webhooks()
+
+		if assert.NoError(t, err) && assert.Len(t, webhooks, 2) {
+			assert.Equal(t, Webhook{URL: "https://example.com/hook?a=b", Secret: "s", Custodian: "c"}, webhooks[0])
+			assert.Equal(t, Webhook{URL: "http://localhost:8080", Secret: "t", Actor: "a"}, webhooks[1])
+		}
+	})
+
+	t.Run("error on invalid url", func(t *testing.T) {
+		_, err := ConsentStoreConfig{Webhooks: "url=ftp://example.com;secret=s"}.webhooks()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("error on missing secret", func(t *testing.T) {
+		_, err := ConsentStoreConfig{Webhooks: "url=https://example.com"}.webhooks()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhook_matches(t *testing.T) {
+	e := Event{Custodian: "custodian", Actor: "actor"}
+
+	assert.True(t, Webhook{}.matches(e))
+	assert.True(t, Webhook{Custodian: "custodian", Actor: "actor"}.matches(e))
+	assert.False(t, Webhook{Custodian: "other"}.matches(e))
+	assert.False(t, Webhook{Actor: "other"}.matches(e))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, webhookRetryDelay, webhookBackoff(1))
+	assert.Equal(t, 4*webhookRetryDelay, webhookBackoff(3))
+	assert.Equal(t, webhookMaxRetryDelay, webhookBackoff(100))
+}
+
+func TestConsentStore_DeliverWebhooks(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	client.webhooks = []Webhook{
+		{URL: server.URL, Secret: "secret", Custodian: "custodian"},
+		{URL: server.URL + "/other", Secret: "secret", Custodian: "other"},
+	}
+
+	pcs := patientConsent()
+	if err := client.RecordConsent(context.TODO(), pcs); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("outbox is written for matching webhooks", func(t *testing.T) {
+		deliveries, err := client.WebhookDeliveries(context.TODO(), WebhookDeliveryPending)
+
+		if assert.NoError(t, err) && assert.Len(t, deliveries, 1) {
+			assert.Equal(t, server.URL, deliveries[0].URL)
+			assert.Equal(t, string(EventConsentRecorded), deliveries[0].Outbox.EventType)
+		}
+	})
+
+	t.Run("delivery is signed and marked as delivered", func(t *testing.T) {
+		n, err := client.DeliverWebhooks(context.TODO(), time.Now())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		if assert.Equal(t, 1, receiver.count()) {
+			req := receiver.requests[0]
+			body := receiver.bodies[0]
+			assert.Equal(t, SignWebhookPayload("secret", req.Header.Get(WebhookTimestampHeader), body), req.Header.Get(WebhookSignatureHeader))
+			assert.Equal(t, string(EventConsentRecorded), req.Header.Get(WebhookEventTypeHeader))
+
+			var e Event
+			if assert.NoError(t, json.Unmarshal(body, &e)) {
+				assert.Equal(t, pcs[0].Records[0].Hash, e.RecordHash)
+				assert.NotZero(t, e.ID)
+			}
+		}
+
+		deliveries, _ := client.WebhookDeliveries(context.TODO(), WebhookDeliveryDelivered)
+		assert.Len(t, deliveries, 1)
+	})
+
+	t.Run("failed delivery is retried with backoff and dead after max attempts", func(t *testing.T) {
+		receiver.status = http.StatusInternalServerError
+		client.Config.WebhookMaxAttempts = 2
+		defer func() { client.Config.WebhookMaxAttempts = 0 }()
+
+		if _, err := client.DeleteConsentRecordByHash(context.TODO(), pcs[0].Records[0].Hash, "", ""); err != nil {
+			t.Fatal(err)
+		}
+
+		client.DeliverWebhooks(context.TODO(), time.Now())
+		deliveries, _ := client.WebhookDeliveries(context.TODO(), WebhookDeliveryPending)
+		if !assert.Len(t, deliveries, 1) {
+			return
+		}
+		assert.Equal(t, 1, deliveries[0].Attempts)
+		assert.Contains(t, *deliveries[0].LastError, "500")
+		assert.True(t, deliveries[0].NextAttemptAt.After(time.Now()))
+
+		// not due yet
+		n, _ := client.DeliverWebhooks(context.TODO(), time.Now())
+		assert.Equal(t, 0, n)
+
+		client.DeliverWebhooks(context.TODO(), time.Now().Add(webhookRetryDelay+time.Minute))
+		dead, _ := client.WebhookDeliveries(context.TODO(), WebhookDeliveryDead)
+		if assert.Len(t, dead, 1) {
+			assert.Equal(t, 2, dead[0].Attempts)
+			assert.Equal(t, string(EventConsentDeleted), dead[0].Outbox.EventType)
+		}
+	})
+
+	t.Run("replayed delivery is attempted again", func(t *testing.T) {
+		receiver.status = http.StatusNoContent
+		dead, _ := client.WebhookDeliveries(context.TODO(), WebhookDeliveryDead)
+		if !assert.Len(t, dead, 1) {
+			return
+		}
+
+		replayed, err := client.ReplayWebhookDelivery(context.TODO(), dead[0].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, WebhookDeliveryPending, replayed.Status)
+		assert.Equal(t, 0, replayed.Attempts)
+
+		client.DeliverWebhooks(context.TODO(), time.Now())
+		deliveries, _ := client.WebhookDeliveries(context.TODO(), WebhookDeliveryDelivered)
+		assert.Len(t, deliveries, 2)
+	})
+
+	t.Run("replaying an unknown delivery returns ErrorNotFound", func(t *testing.T) {
+		_, err := client.ReplayWebhookDelivery(context.TODO(), 999)
+
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("delivered deliveries are pruned after the retention period", func(t *testing.T) {
+		client.DeliverWebhooks(context.TODO(), time.Now().Add(webhookDeliveredRetention+time.Hour))
+
+		deliveries, _ := client.WebhookDeliveries(context.TODO(), "")
+		assert.Empty(t, deliveries)
+		var count int
+		client.Db.Model(&WebhookOutboxEntry{}).Count(&count)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("unconfigured webhook fails", func(t *testing.T) {
+		err := client.deliver(context.TODO(), WebhookDelivery{URL: "http://localhost/unknown"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConsentStore_webhookOutboxRollback(t *testing.T) {
+	client := defaultConsentStore()
+	defer client.Shutdown()
+	client.webhooks = []Webhook{{URL: "http://localhost", Secret: "secret"}}
+
+	unknown := "unknown"
+	pcs := patientConsent()
+	pcs[0].Records = append(pcs[0].Records, ConsentRecord{ValidFrom: time.Now(), Hash: "other", PreviousHash: &unknown})
+
+	assert.Error(t, client.RecordConsent(context.TODO(), pcs))
+
+	deliveries, _ := client.WebhookDeliveries(context.TODO(), "")
+	assert.Empty(t, deliveries)
+}
+
+func TestConsentStore_webhookJob(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	client := defaultConsentStore()
+	client.webhooks = []Webhook{{URL: server.URL, Secret: "secret"}}
+	client.startWebhookJob(time.Hour)
+
+	// the job is triggered by the commit, it doesn't wait for the interval
+	client.RecordConsent(context.TODO(), patientConsent())
+
+	assert.Eventually(t, func() bool {
+		return receiver.count() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	client.Shutdown()
+	assert.Nil(t, client.webhookJob)
+}
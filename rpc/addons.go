@@ -0,0 +1,144 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FromPatientConsent converts a PatientConsent to its protobuf message
+func FromPatientConsent(pc pkg.PatientConsent) *PatientConsent {
+	m := &PatientConsent{
+		Id:        pc.ID,
+		Subject:   pc.Subject,
+		Custodian: pc.Custodian,
+		Actor:     pc.Actor,
+	}
+	for _, r := range pc.Records {
+		m.Records = append(m.Records, FromConsentRecord(r))
+	}
+	return m
+}
+
+// ToPatientConsent converts the message to a PatientConsent
+func (m *PatientConsent) ToPatientConsent() pkg.PatientConsent {
+	pc := pkg.PatientConsent{
+		ID:        m.GetId(),
+		Subject:   m.GetSubject(),
+		Custodian: m.GetCustodian(),
+		Actor:     m.GetActor(),
+	}
+	for _, r := range m.GetRecords() {
+		pc.Records = append(pc.Records, r.ToConsentRecord())
+	}
+	return pc
+}
+
+// FromConsentRecord converts a ConsentRecord to its protobuf message
+func FromConsentRecord(cr pkg.ConsentRecord) *ConsentRecord {
+	m := &ConsentRecord{
+		Hash:      cr.Hash,
+		Version:   uint32(cr.Version),
+		ValidFrom: timestamppb.New(cr.ValidFrom),
+		ValidTo:   fromTime(cr.ValidTo),
+		DeletedAt: fromTime(cr.DeletedAt),
+	}
+	if cr.PreviousHash != nil {
+		m.PreviousHash = *cr.PreviousHash
+	}
+	if cr.DeletedBy != nil {
+		m.DeletedBy = *cr.DeletedBy
+	}
+	if cr.DeletedReason != nil {
+		m.DeletedReason = *cr.DeletedReason
+	}
+	for _, dc := range cr.DataClasses {
+		m.DataClasses = append(m.DataClasses, dc.Code)
+	}
+	return m
+}
+
+// ToConsentRecord converts the message to a ConsentRecord
+func (m *ConsentRecord) ToConsentRecord() pkg.ConsentRecord {
+	cr := pkg.ConsentRecord{
+		Hash:      m.GetHash(),
+		Version:   uint(m.GetVersion()),
+		ValidTo:   toTime(m.GetValidTo()),
+		DeletedAt: toTime(m.GetDeletedAt()),
+	}
+	if m.GetValidFrom() != nil {
+		cr.ValidFrom = m.GetValidFrom().AsTime()
+	}
+	if m.GetPreviousHash() != "" {
+		previousHash := m.GetPreviousHash()
+		cr.PreviousHash = &previousHash
+	}
+	if cr.DeletedAt != nil {
+		deletedBy, deletedReason := m.GetDeletedBy(), m.GetDeletedReason()
+		cr.DeletedBy = &deletedBy
+		cr.DeletedReason = &deletedReason
+	}
+	for _, dc := range m.GetDataClasses() {
+		cr.DataClasses = append(cr.DataClasses, pkg.DataClass{Code: dc})
+	}
+	return cr
+}
+
+// FromErasureCertificate converts an ErasureCertificate to its protobuf message
+func FromErasureCertificate(ec pkg.ErasureCertificate) *ErasureCertificate {
+	return &ErasureCertificate{
+		Pseudonym:         ec.Pseudonym,
+		ErasedAt:          timestamppb.New(ec.ErasedAt),
+		ErasedBy:          ec.ErasedBy,
+		PatientConsentIds: ec.PatientConsentIDs,
+		RecordHashes:      ec.RecordHashes,
+	}
+}
+
+// ToErasureCertificate converts the message to an ErasureCertificate
+func (m *ErasureCertificate) ToErasureCertificate() pkg.ErasureCertificate {
+	ec := pkg.ErasureCertificate{
+		Pseudonym:         m.GetPseudonym(),
+		ErasedBy:          m.GetErasedBy(),
+		PatientConsentIDs: m.GetPatientConsentIds(),
+		RecordHashes:      m.GetRecordHashes(),
+	}
+	if m.GetErasedAt() != nil {
+		ec.ErasedAt = m.GetErasedAt().AsTime()
+	}
+	return ec
+}
+
+func fromTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
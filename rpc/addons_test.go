@@ -0,0 +1,61 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientConsent_ToPatientConsent(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		pc := patientConsent()
+		previousHash := "previous"
+		pc.Records[0].PreviousHash = &previousHash
+		pc.Records[0].Version = 2
+		pc.Records[0].ValidFrom = pc.Records[0].ValidFrom.UTC()
+		validTo := pc.Records[0].ValidTo.UTC()
+		pc.Records[0].ValidTo = &validTo
+
+		assert.Equal(t, pc, FromPatientConsent(pc).ToPatientConsent())
+	})
+
+	t.Run("tombstone", func(t *testing.T) {
+		deletedAt := time.Now().UTC()
+		by, reason := "dpo", "revoked"
+		cr := pkg.ConsentRecord{Hash: "hash", ValidFrom: deletedAt, DeletedAt: &deletedAt, DeletedBy: &by, DeletedReason: &reason}
+
+		assert.Equal(t, cr, FromConsentRecord(cr).ToConsentRecord())
+	})
+}
+
+func TestErasureCertificate_ToErasureCertificate(t *testing.T) {
+	ec := pkg.ErasureCertificate{
+		Pseudonym:         "pseudonym",
+		ErasedAt:          time.Now().UTC(),
+		ErasedBy:          "dpo",
+		PatientConsentIDs: []string{"id"},
+		RecordHashes:      []string{"hash"},
+	}
+
+	assert.Equal(t, ec, FromErasureCertificate(ec).ToErasureCertificate())
+}
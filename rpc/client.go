@@ -0,0 +1,205 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// AddressScheme is the prefix of the address config that selects the gRPC client in client mode, e.g. grpc://localhost:1324
+const AddressScheme = "grpc://"
+
+// GrpcClient implements the ConsentStoreClient on top of the gRPC api
+type GrpcClient struct {
+	// Timeout is the timeout of a single call, 0 is no timeout
+	Timeout time.Duration
+	Logger  *logrus.Entry
+	conn    *grpc.ClientConn
+	client  ConsentStoreClient
+}
+
+// NewGrpcClient creates a client for the server at the given address, the connection is set up on the first call.
+// TLSConfig is optional, when set the client connects over TLS using the given CA and client certificates.
+func NewGrpcClient(address string, tlsConfig *tls.Config, timeout time.Duration, logger *logrus.Entry) (*GrpcClient, error) {
+	creds := grpc.WithInsecure()
+	if tlsConfig != nil {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	conn, err := grpc.Dial(strings.TrimPrefix(address, AddressScheme), creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrpcClient{
+		Timeout: timeout,
+		Logger:  logger,
+		conn:    conn,
+		client:  NewConsentStoreClient(conn),
+	}, nil
+}
+
+// Close closes the connection to the server
+func (gc *GrpcClient) Close() error {
+	return gc.conn.Close()
+}
+
+// ConsentAuth checks if there is an active consent for a given custodian, subject, actor, dataClass and an optional moment in time (checkpoint)
+func (gc *GrpcClient) ConsentAuth(ctx context.Context, custodian string, subject string, actor string, dataClass string, checkpoint *time.Time) (bool, error) {
+	ctx, cancel := gc.context(ctx)
+	defer cancel()
+
+	res, err := gc.client.CheckConsent(ctx, &CheckConsentRequest{
+		Custodian: custodian,
+		Subject:   subject,
+		Actor:     actor,
+		DataClass: dataClass,
+		ValidAt:   fromTime(checkpoint),
+	})
+	if err != nil {
+		return false, gc.error("checking for consent", err)
+	}
+
+	return res.GetConsentGiven(), nil
+}
+
+// RecordConsent records the consent in a single transaction
+func (gc *GrpcClient) RecordConsent(ctx context.Context, consent []pkg.PatientConsent) error {
+	ctx, cancel := gc.context(ctx)
+	defer cancel()
+
+	req := &RecordConsentRequest{}
+	for _, pc := range consent {
+		req.Consent = append(req.Consent, FromPatientConsent(pc))
+	}
+
+	if _, err := gc.client.RecordConsent(ctx, req); err != nil {
+		return gc.error("storing consent", err)
+	}
+	return nil
+}
+
+// QueryConsent returns PatientConsent records based on a combination of actor, custodian and subject. The only constraint is that either actor or custodian must not be empty.
+func (gc *GrpcClient) QueryConsent(ctx context.Context, actor *string, custodian *string, subject *string, validAt *time.Time, includeDeleted bool) ([]pkg.PatientConsent, error) {
+	ctx, cancel := gc.context(ctx)
+	defer cancel()
+
+	req := &QueryConsentRequest{ValidAt: fromTime(validAt), IncludeDeleted: includeDeleted}
+	if actor != nil {
+		req.Actor = *actor
+	}
+	if custodian != nil {
+		req.Custodian = *custodian
+	}
+	if subject != nil {
+		req.Subject = *subject
+	}
+
+	res, err := gc.client.QueryConsent(ctx, req)
+	if err != nil {
+		return nil, gc.error("querying for consent", err)
+	}
+
+	var consent []pkg.PatientConsent
+	for _, m := range res.GetResults() {
+		consent = append(consent, m.ToPatientConsent())
+	}
+	return consent, nil
+}
+
+// DeleteConsentRecordByHash replaces the record with a tombstone holding the reason and who deleted it. Returns true if the record was found and deleted.
+func (gc *GrpcClient) DeleteConsentRecordByHash(ctx context.Context, consentRecordHash string, reason string, deletedBy string) (bool, error) {
+	ctx, cancel := gc.context(ctx)
+	defer cancel()
+
+	res, err := gc.client.DeleteConsentRecord(ctx, &DeleteConsentRecordRequest{Hash: consentRecordHash, Reason: reason, DeletedBy: deletedBy})
+	if err != nil {
+		return false, gc.error("deleting consent", err)
+	}
+
+	return res.GetDeleted(), nil
+}
+
+// FindConsentRecordByHash returns a ConsentRecord based on a hash. A latest flag can be added to indicate a record may only be returned if it's the latest in the chain.
+func (gc *GrpcClient) FindConsentRecordByHash(ctx context.Context, consentRecordHash string, latest bool) (pkg.ConsentRecord, error) {
+	ctx, cancel := gc.context(ctx)
+	defer cancel()
+
+	res, err := gc.client.FindConsentRecord(ctx, &FindConsentRecordRequest{Hash: consentRecordHash, Latest: latest})
+	if err != nil {
+		return pkg.ConsentRecord{}, gc.error("finding consent record", err)
+	}
+
+	return res.ToConsentRecord(), nil
+}
+
+// EraseSubject removes all consent data of the subject. The server requires a client certificate for this operation.
+func (gc *GrpcClient) EraseSubject(ctx context.Context, subject string, erasedBy string) (pkg.ErasureCertificate, error) {
+	ctx, cancel := gc.context(ctx)
+	defer cancel()
+
+	res, err := gc.client.EraseSubject(ctx, &EraseSubjectRequest{Subject: subject, ErasedBy: erasedBy})
+	if err != nil {
+		return pkg.ErasureCertificate{}, gc.error("erasing subject", err)
+	}
+
+	return res.ToErasureCertificate(), nil
+}
+
+// context applies the timeout of a single call to the context
+func (gc *GrpcClient) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if gc.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, gc.Timeout)
+}
+
+// error logs the error of a call and maps NOT_FOUND, FAILED_PRECONDITION and ABORTED back to the errors of the consent store
+func (gc *GrpcClient) error(action string, err error) error {
+	if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+		err = pkg.ErrorUnknownPreviousHash
+	} else if ok && st.Code() == codes.NotFound {
+		err = pkg.ErrorNotFound
+		if strings.Contains(st.Message(), pkg.ErrorConsentRecordNotLatest.Error()) {
+			err = pkg.ErrorConsentRecordNotLatest
+		}
+	} else if ok && st.Code() == codes.Aborted {
+		err = pkg.ErrorConflict
+		for _, d := range st.Details() {
+			if head, ok := d.(*ConsentRecord); ok {
+				err = &pkg.ConflictError{Head: head.ToConsentRecord()}
+			}
+		}
+	}
+
+	err = fmt.Errorf("error while %s in consent-store: %w", action, err)
+	gc.Logger.Error(err)
+	return err
+}
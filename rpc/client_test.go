@@ -0,0 +1,65 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrpcClient_FindConsentRecordByHash(t *testing.T) {
+	cs := defaultConsentStore()
+	defer cs.Shutdown()
+	client, stop := startServer(t, cs)
+	defer stop()
+
+	pc := patientConsent()
+	update := patientConsent()
+	update.ID = pc.ID
+	update.Records[0].PreviousHash = &pc.Records[0].Hash
+	cs.RecordConsent(context.TODO(), []pkg.PatientConsent{pc})
+	cs.RecordConsent(context.TODO(), []pkg.PatientConsent{update})
+
+	_, err := client.FindConsentRecordByHash(context.TODO(), pc.Records[0].Hash, true)
+
+	assert.True(t, errors.Is(err, pkg.ErrorConsentRecordNotLatest))
+}
+
+func TestGrpcClient_timeout(t *testing.T) {
+	client := &GrpcClient{Timeout: time.Minute}
+
+	ctx, cancel := client.context(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	client.Timeout = 0
+	ctx, cancel = client.context(context.Background())
+	defer cancel()
+
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+}
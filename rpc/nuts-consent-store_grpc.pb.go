@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// ConsentStoreClient is the client API for ConsentStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConsentStoreClient interface {
+	// CheckConsent checks if there is active consent for the data class, at valid_at or now
+	CheckConsent(ctx context.Context, in *CheckConsentRequest, opts ...grpc.CallOption) (*CheckConsentResponse, error)
+	// RecordConsent records the consent atomically, all consent is recorded or none
+	RecordConsent(ctx context.Context, in *RecordConsentRequest, opts ...grpc.CallOption) (*RecordConsentResponse, error)
+	// QueryConsent returns the consent for a combination of actor, custodian and subject, either actor or custodian is required
+	QueryConsent(ctx context.Context, in *QueryConsentRequest, opts ...grpc.CallOption) (*QueryConsentResponse, error)
+	// FindConsentRecord returns the record with the given hash, NOT_FOUND when it doesn't exist or isn't the latest while latest is requested
+	FindConsentRecord(ctx context.Context, in *FindConsentRecordRequest, opts ...grpc.CallOption) (*ConsentRecord, error)
+	// DeleteConsentRecord replaces the record with the given hash with a tombstone, NOT_FOUND when it doesn't exist
+	DeleteConsentRecord(ctx context.Context, in *DeleteConsentRecordRequest, opts ...grpc.CallOption) (*DeleteConsentRecordResponse, error)
+	// EraseSubject removes all consent data of the subject, it requires a client certificate
+	EraseSubject(ctx context.Context, in *EraseSubjectRequest, opts ...grpc.CallOption) (*ErasureCertificate, error)
+}
+
+type consentStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConsentStoreClient(cc grpc.ClientConnInterface) ConsentStoreClient {
+	return &consentStoreClient{cc}
+}
+
+func (c *consentStoreClient) CheckConsent(ctx context.Context, in *CheckConsentRequest, opts ...grpc.CallOption) (*CheckConsentResponse, error) {
+	out := new(CheckConsentResponse)
+	err := c.cc.Invoke(ctx, "/nuts.consentstore.v1.ConsentStore/CheckConsent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentStoreClient) RecordConsent(ctx context.Context, in *RecordConsentRequest, opts ...grpc.CallOption) (*RecordConsentResponse, error) {
+	out := new(RecordConsentResponse)
+	err := c.cc.Invoke(ctx, "/nuts.consentstore.v1.ConsentStore/RecordConsent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentStoreClient) QueryConsent(ctx context.Context, in *QueryConsentRequest, opts ...grpc.CallOption) (*QueryConsentResponse, error) {
+	out := new(QueryConsentResponse)
+	err := c.cc.Invoke(ctx, "/nuts.consentstore.v1.ConsentStore/QueryConsent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentStoreClient) FindConsentRecord(ctx context.Context, in *FindConsentRecordRequest, opts ...grpc.CallOption) (*ConsentRecord, error) {
+	out := new(ConsentRecord)
+	err := c.cc.Invoke(ctx, "/nuts.consentstore.v1.ConsentStore/FindConsentRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentStoreClient) DeleteConsentRecord(ctx context.Context, in *DeleteConsentRecordRequest, opts ...grpc.CallOption) (*DeleteConsentRecordResponse, error) {
+	out := new(DeleteConsentRecordResponse)
+	err := c.cc.Invoke(ctx, "/nuts.consentstore.v1.ConsentStore/DeleteConsentRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentStoreClient) EraseSubject(ctx context.Context, in *EraseSubjectRequest, opts ...grpc.CallOption) (*ErasureCertificate, error) {
+	out := new(ErasureCertificate)
+	err := c.cc.Invoke(ctx, "/nuts.consentstore.v1.ConsentStore/EraseSubject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConsentStoreServer is the server API for ConsentStore service.
+// All implementations must embed UnimplementedConsentStoreServer
+// for forward compatibility
+type ConsentStoreServer interface {
+	// CheckConsent checks if there is active consent for the data class, at valid_at or now
+	CheckConsent(context.Context, *CheckConsentRequest) (*CheckConsentResponse, error)
+	// RecordConsent records the consent atomically, all consent is recorded or none
+	RecordConsent(context.Context, *RecordConsentRequest) (*RecordConsentResponse, error)
+	// QueryConsent returns the consent for a combination of actor, custodian and subject, either actor or custodian is required
+	QueryConsent(context.Context, *QueryConsentRequest) (*QueryConsentResponse, error)
+	// FindConsentRecord returns the record with the given hash, NOT_FOUND when it doesn't exist or isn't the latest while latest is requested
+	FindConsentRecord(context.Context, *FindConsentRecordRequest) (*ConsentRecord, error)
+	// DeleteConsentRecord replaces the record with the given hash with a tombstone, NOT_FOUND when it doesn't exist
+	DeleteConsentRecord(context.Context, *DeleteConsentRecordRequest) (*DeleteConsentRecordResponse, error)
+	// EraseSubject removes all consent data of the subject, it requires a client certificate
+	EraseSubject(context.Context, *EraseSubjectRequest) (*ErasureCertificate, error)
+	mustEmbedUnimplementedConsentStoreServer()
+}
+
+// UnimplementedConsentStoreServer must be embedded to have forward compatible implementations.
+type UnimplementedConsentStoreServer struct {
+}
+
+func (UnimplementedConsentStoreServer) CheckConsent(context.Context, *CheckConsentRequest) (*CheckConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckConsent not implemented")
+}
+func (UnimplementedConsentStoreServer) RecordConsent(context.Context, *RecordConsentRequest) (*RecordConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordConsent not implemented")
+}
+func (UnimplementedConsentStoreServer) QueryConsent(context.Context, *QueryConsentRequest) (*QueryConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryConsent not implemented")
+}
+func (UnimplementedConsentStoreServer) FindConsentRecord(context.Context, *FindConsentRecordRequest) (*ConsentRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindConsentRecord not implemented")
+}
+func (UnimplementedConsentStoreServer) DeleteConsentRecord(context.Context, *DeleteConsentRecordRequest) (*DeleteConsentRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConsentRecord not implemented")
+}
+func (UnimplementedConsentStoreServer) EraseSubject(context.Context, *EraseSubjectRequest) (*ErasureCertificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseSubject not implemented")
+}
+func (UnimplementedConsentStoreServer) mustEmbedUnimplementedConsentStoreServer() {}
+
+// UnsafeConsentStoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConsentStoreServer will
+// result in compilation errors.
+type UnsafeConsentStoreServer interface {
+	mustEmbedUnimplementedConsentStoreServer()
+}
+
+func RegisterConsentStoreServer(s grpc.ServiceRegistrar, srv ConsentStoreServer) {
+	s.RegisterService(&_ConsentStore_serviceDesc, srv)
+}
+
+func _ConsentStore_CheckConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentStoreServer).CheckConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nuts.consentstore.v1.ConsentStore/CheckConsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentStoreServer).CheckConsent(ctx, req.(*CheckConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentStore_RecordConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentStoreServer).RecordConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nuts.consentstore.v1.ConsentStore/RecordConsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentStoreServer).RecordConsent(ctx, req.(*RecordConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentStore_QueryConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentStoreServer).QueryConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nuts.consentstore.v1.ConsentStore/QueryConsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentStoreServer).QueryConsent(ctx, req.(*QueryConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentStore_FindConsentRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindConsentRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentStoreServer).FindConsentRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nuts.consentstore.v1.ConsentStore/FindConsentRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentStoreServer).FindConsentRecord(ctx, req.(*FindConsentRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentStore_DeleteConsentRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConsentRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentStoreServer).DeleteConsentRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nuts.consentstore.v1.ConsentStore/DeleteConsentRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentStoreServer).DeleteConsentRecord(ctx, req.(*DeleteConsentRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentStore_EraseSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentStoreServer).EraseSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nuts.consentstore.v1.ConsentStore/EraseSubject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentStoreServer).EraseSubject(ctx, req.(*EraseSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ConsentStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nuts.consentstore.v1.ConsentStore",
+	HandlerType: (*ConsentStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckConsent",
+			Handler:    _ConsentStore_CheckConsent_Handler,
+		},
+		{
+			MethodName: "RecordConsent",
+			Handler:    _ConsentStore_RecordConsent_Handler,
+		},
+		{
+			MethodName: "QueryConsent",
+			Handler:    _ConsentStore_QueryConsent_Handler,
+		},
+		{
+			MethodName: "FindConsentRecord",
+			Handler:    _ConsentStore_FindConsentRecord_Handler,
+		},
+		{
+			MethodName: "DeleteConsentRecord",
+			Handler:    _ConsentStore_DeleteConsentRecord_Handler,
+		},
+		{
+			MethodName: "EraseSubject",
+			Handler:    _ConsentStore_EraseSubject_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "docs/_static/nuts-consent-store.proto",
+}
@@ -0,0 +1,299 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the ConsentStore gRPC service on top of the consent store, like api.Wrapper does for the REST api
+type Server struct {
+	UnimplementedConsentStoreServer
+	Cs *pkg.ConsentStore
+}
+
+// RegisterServer registers the ConsentStore service, it's the gRPC counterpart of api.RegisterHandlers
+func RegisterServer(s grpc.ServiceRegistrar, cs *pkg.ConsentStore) {
+	RegisterConsentStoreServer(s, &Server{Cs: cs})
+}
+
+// NewServer creates a gRPC server with the ConsentStore service registered. When the consent store is configured with a
+// certificate the server uses TLS and verifies client certificates when they're given, like the REST server.
+func NewServer(cs *pkg.ConsentStore) (*grpc.Server, error) {
+	tlsConfig, err := cs.Config.ServerTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	s := grpc.NewServer(opts...)
+	RegisterServer(s, cs)
+	return s, nil
+}
+
+// Serve creates the gRPC server with NewServer and serves it on the listener in the background, until the server is stopped
+func Serve(cs *pkg.ConsentStore, lis net.Listener) (*grpc.Server, error) {
+	s, err := NewServer(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			pkg.Logger().Errorf("Error serving gRPC api: %v", err)
+		}
+	}()
+	return s, nil
+}
+
+// CheckConsent checks if there is active consent for the data class
+func (s *Server) CheckConsent(ctx context.Context, req *CheckConsentRequest) (*CheckConsentResponse, error) {
+	if err := s.limit(ctx, pkg.OperationCheck); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.GetSubject() == "":
+		return nil, status.Error(codes.InvalidArgument, "missing subject")
+	case req.GetCustodian() == "":
+		return nil, status.Error(codes.InvalidArgument, "missing custodian")
+	case req.GetActor() == "":
+		return nil, status.Error(codes.InvalidArgument, "missing actor")
+	case req.GetDataClass() == "":
+		return nil, status.Error(codes.InvalidArgument, "missing dataClass")
+	}
+
+	given, err := s.Cs.ConsentAuth(ctx, req.GetCustodian(), req.GetSubject(), req.GetActor(), req.GetDataClass(), toTime(req.GetValidAt()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &CheckConsentResponse{ConsentGiven: given}, nil
+}
+
+// RecordConsent records all consent in a single transaction
+func (s *Server) RecordConsent(ctx context.Context, req *RecordConsentRequest) (*RecordConsentResponse, error) {
+	if err := s.limit(ctx, pkg.OperationWrite); err != nil {
+		return nil, err
+	}
+
+	if len(req.GetConsent()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing consent")
+	}
+
+	var consent []pkg.PatientConsent
+	for _, m := range req.GetConsent() {
+		pc := m.ToPatientConsent()
+		if err := pkg.ValidateImport(pc); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		consent = append(consent, pc)
+	}
+
+	if err := s.Cs.RecordConsent(ctx, consent); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &RecordConsentResponse{}, nil
+}
+
+// QueryConsent returns the consent matching the non empty actor, custodian and subject
+func (s *Server) QueryConsent(ctx context.Context, req *QueryConsentRequest) (*QueryConsentResponse, error) {
+	if err := s.limit(ctx, pkg.OperationQuery); err != nil {
+		return nil, err
+	}
+
+	actor, custodian, subject := optional(req.GetActor()), optional(req.GetCustodian()), optional(req.GetSubject())
+	if actor == nil && custodian == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing actor or custodian")
+	}
+
+	validAt := time.Now()
+	if req.GetValidAt() != nil {
+		validAt = req.GetValidAt().AsTime()
+	}
+
+	consent, err := s.Cs.QueryConsent(ctx, actor, custodian, subject, &validAt, req.GetIncludeDeleted())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &QueryConsentResponse{}
+	for _, pc := range consent {
+		res.Results = append(res.Results, FromPatientConsent(pc))
+	}
+	return res, nil
+}
+
+// FindConsentRecord returns the record with the given hash
+func (s *Server) FindConsentRecord(ctx context.Context, req *FindConsentRecordRequest) (*ConsentRecord, error) {
+	if err := s.limit(ctx, pkg.OperationQuery); err != nil {
+		return nil, err
+	}
+
+	if req.GetHash() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing hash")
+	}
+
+	record, err := s.Cs.FindConsentRecordByHash(ctx, req.GetHash(), req.GetLatest())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return FromConsentRecord(record), nil
+}
+
+// DeleteConsentRecord replaces the record with the given hash with a tombstone.
+// When deletedBy is not given, the authenticated caller is recorded.
+func (s *Server) DeleteConsentRecord(ctx context.Context, req *DeleteConsentRecordRequest) (*DeleteConsentRecordResponse, error) {
+	if err := s.limit(ctx, pkg.OperationWrite); err != nil {
+		return nil, err
+	}
+
+	if req.GetHash() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing hash")
+	}
+
+	deletedBy := req.GetDeletedBy()
+	if deletedBy == "" {
+		deletedBy = authenticatedCaller(ctx)
+	}
+
+	deleted, err := s.Cs.DeleteConsentRecordByHash(ctx, req.GetHash(), req.GetReason(), deletedBy)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &DeleteConsentRecordResponse{Deleted: deleted}, nil
+}
+
+// EraseSubject removes all consent data of the subject, the caller must be authenticated with a client certificate
+func (s *Server) EraseSubject(ctx context.Context, req *EraseSubjectRequest) (*ErasureCertificate, error) {
+	caller := authenticatedCaller(ctx)
+	if caller == "" {
+		return nil, status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	if err := s.limit(ctx, pkg.OperationWrite); err != nil {
+		return nil, err
+	}
+
+	if req.GetSubject() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing subject")
+	}
+
+	erasedBy := caller
+	if req.GetErasedBy() != "" {
+		erasedBy = req.GetErasedBy()
+	}
+
+	certificate, err := s.Cs.EraseSubject(ctx, req.GetSubject(), erasedBy)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return FromErasureCertificate(certificate), nil
+}
+
+// limit counts the call against the budget of the caller, it returns RESOURCE_EXHAUSTED when the budget is exhausted
+func (s *Server) limit(ctx context.Context, op pkg.Operation) error {
+	if s.Cs.RateLimiter == nil || !s.Cs.RateLimiter.Enabled() {
+		return nil
+	}
+
+	if ok, retryAfter := s.Cs.RateLimiter.Allow(callerIdentity(ctx), op); !ok {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s operations, retry after %s", op, retryAfter.Round(time.Second))
+	}
+	return nil
+}
+
+// callerIdentity returns the authenticated caller or the host of the peer address
+func callerIdentity(ctx context.Context) string {
+	if cn := authenticatedCaller(ctx); cn != "" {
+		return cn
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// authenticatedCaller returns the common name of the verified client certificate, empty when the caller is not authenticated
+func authenticatedCaller(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if ok && len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+		return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	}
+	return ""
+}
+
+// statusError maps the errors of the consent store to gRPC status codes
+func statusError(err error) error {
+	switch {
+	// an unknown previous record wraps ErrorNotFound, but it's the consent being recorded that is invalid
+	case errors.Is(err, pkg.ErrorUnknownPreviousHash):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, pkg.ErrorNotFound), errors.Is(err, pkg.ErrorConsentRecordNotLatest):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, pkg.ErrorInvalidValidTo):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// a conflict holds the current head of the chain as detail
+	var conflict *pkg.ConflictError
+	if errors.As(err, &conflict) {
+		st, dErr := status.New(codes.Aborted, err.Error()).WithDetails(FromConsentRecord(conflict.Head))
+		if dErr != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+		return st.Err()
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,242 @@
+/*
+ * Nuts consent store
+ * Copyright (C) 2020. Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/nuts-foundation/nuts-consent-store/pkg"
+	core "github.com/nuts-foundation/nuts-go-core"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func defaultConsentStore() *pkg.ConsentStore {
+	cs := &pkg.ConsentStore{
+		Config: pkg.ConsentStoreConfig{
+			Connectionstring: ":memory:",
+			Mode:             core.ServerEngineMode,
+		},
+	}
+	if err := cs.Configure(); err != nil {
+		panic(err)
+	}
+	if err := cs.Start(); err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+// startServer serves the gRPC api of the consent store on a random port and returns a client connected to it
+func startServer(t *testing.T, cs *pkg.ConsentStore) (*GrpcClient, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Serve(cs, lis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewGrpcClient(AddressScheme+lis.Addr().String(), nil, time.Second, logrus.StandardLogger().WithField("component", "API-client"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, func() {
+		client.Close()
+		s.Stop()
+	}
+}
+
+func patientConsent() pkg.PatientConsent {
+	validTo := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	return pkg.PatientConsent{
+		ID:        random.String(8),
+		Subject:   "subject",
+		Custodian: "custodian",
+		Actor:     "actor",
+		Records: []pkg.ConsentRecord{{
+			ValidFrom:   time.Now().Add(-24 * time.Hour).Truncate(time.Second),
+			ValidTo:     &validTo,
+			Hash:        random.String(8),
+			DataClasses: []pkg.DataClass{{Code: "resource"}},
+		}},
+	}
+}
+
+func TestServer(t *testing.T) {
+	cs := defaultConsentStore()
+	defer cs.Shutdown()
+	client, stop := startServer(t, cs)
+	defer stop()
+
+	pc := patientConsent()
+
+	t.Run("record and check consent", func(t *testing.T) {
+		if !assert.NoError(t, client.RecordConsent(context.TODO(), []pkg.PatientConsent{pc})) {
+			return
+		}
+
+		given, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", nil)
+		assert.NoError(t, err)
+		assert.True(t, given)
+
+		given, err = client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "other", nil)
+		assert.NoError(t, err)
+		assert.False(t, given)
+	})
+
+	t.Run("check with checkpoint", func(t *testing.T) {
+		checkpoint := time.Now().Add(48 * time.Hour)
+
+		given, err := client.ConsentAuth(context.TODO(), "custodian", "subject", "actor", "resource", &checkpoint)
+
+		assert.NoError(t, err)
+		assert.False(t, given)
+	})
+
+	t.Run("invalid consent is not recorded", func(t *testing.T) {
+		invalid := patientConsent()
+		invalid.ID = ""
+
+		err := client.RecordConsent(context.TODO(), []pkg.PatientConsent{invalid})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(unwrap(err)))
+	})
+
+	t.Run("query consent", func(t *testing.T) {
+		actor := "actor"
+
+		consent, err := client.QueryConsent(context.TODO(), &actor, nil, nil, nil, false)
+
+		if assert.NoError(t, err) && assert.Len(t, consent, 1) {
+			assert.Equal(t, pc.ID, consent[0].ID)
+			if assert.Len(t, consent[0].Records, 1) {
+				assert.Equal(t, pc.Records[0].Hash, consent[0].Records[0].Hash)
+				assert.True(t, pc.Records[0].ValidTo.Equal(*consent[0].Records[0].ValidTo))
+			}
+		}
+	})
+
+	t.Run("query without actor or custodian is invalid", func(t *testing.T) {
+		subject := "subject"
+
+		_, err := client.QueryConsent(context.TODO(), nil, nil, &subject, nil, false)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(unwrap(err)))
+	})
+
+	t.Run("find consent record", func(t *testing.T) {
+		record, err := client.FindConsentRecordByHash(context.TODO(), pc.Records[0].Hash, true)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, pc.Records[0].Hash, record.Hash)
+			assert.Equal(t, uint(1), record.Version)
+		}
+	})
+
+	t.Run("unknown record returns ErrorNotFound", func(t *testing.T) {
+		_, err := client.FindConsentRecordByHash(context.TODO(), "unknown", false)
+
+		assert.True(t, errors.Is(err, pkg.ErrorNotFound))
+	})
+
+	t.Run("delete consent record", func(t *testing.T) {
+		deleted, err := client.DeleteConsentRecordByHash(context.TODO(), pc.Records[0].Hash, "revoked", "dpo")
+
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		_, err = client.FindConsentRecordByHash(context.TODO(), pc.Records[0].Hash, false)
+		assert.True(t, errors.Is(err, pkg.ErrorNotFound))
+	})
+
+	t.Run("deleting an unknown record returns ErrorNotFound", func(t *testing.T) {
+		deleted, err := client.DeleteConsentRecordByHash(context.TODO(), "unknown", "", "")
+
+		assert.False(t, deleted)
+		assert.True(t, errors.Is(err, pkg.ErrorNotFound))
+	})
+
+	t.Run("erasing without client certificate is unauthenticated", func(t *testing.T) {
+		_, err := client.EraseSubject(context.TODO(), "subject", "dpo")
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(unwrap(err)))
+	})
+}
+
+func TestServer_EraseSubject(t *testing.T) {
+	cs := defaultConsentStore()
+	defer cs.Shutdown()
+	server := &Server{Cs: cs}
+	pc := patientConsent()
+	cs.RecordConsent(context.TODO(), []pkg.PatientConsent{pc})
+
+	ctx := peer.NewContext(context.TODO(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "dpo"}}}},
+		}},
+	})
+
+	certificate, err := server.EraseSubject(ctx, &EraseSubjectRequest{Subject: "subject"})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "dpo", certificate.GetErasedBy())
+		assert.Equal(t, []string{pc.Records[0].Hash}, certificate.GetRecordHashes())
+	}
+}
+
+func TestServer_limit(t *testing.T) {
+	cs := defaultConsentStore()
+	defer cs.Shutdown()
+	cs.RateLimiter = pkg.NewRateLimiter(pkg.RateLimit{Check: 1}, nil)
+	server := &Server{Cs: cs}
+	req := &CheckConsentRequest{Custodian: "custodian", Subject: "subject", Actor: "actor", DataClass: "resource"}
+
+	_, err := server.CheckConsent(context.TODO(), req)
+	assert.NoError(t, err)
+
+	_, err = server.CheckConsent(context.TODO(), req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// unwrap returns the innermost error, which holds the gRPC status
+func unwrap(err error) error {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return err
+		}
+		err = inner
+	}
+}